@@ -6,9 +6,23 @@ import (
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/utils"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// MaxMessageSize is the size of the largest UDP datagram over IPv4, which limits the size of a marshalled message.
+// Processes split contents which do not fit, e.g. the transactions of a pull reply, across messages.
+const MaxMessageSize = 65507
+
+// StampBlockSize is the number of message stamps reserved at once in the StampStore.
+const StampBlockSize = 1000
+
+// StampStore persists reserved message stamps, so that a restarted process does not reuse stamps,
+// which processes receiving its messages would discard as duplicates.
+type StampStore interface {
+	ReserveStamps(limit int32) error
+}
+
 type Packet struct {
 	To   int32
 	Data []byte
@@ -25,6 +39,15 @@ type ReliableContext struct {
 	messageCounter int32
 	counterMutex   *sync.RWMutex
 
+	stampStore     StampStore
+	reservedStamps int32
+
+	// A stopped context neither sends new messages nor retransmits the sent ones
+	stopped        atomic.Bool
+	sentMessages   atomic.Int64
+	messageLimit   int64
+	onMessageLimit func()
+
 	writeChan chan Packet
 
 	receivedAcks map[int32]chan bool
@@ -54,9 +77,44 @@ func NewReliableContext(
 	return c
 }
 
+// UseStampStore makes the context continue numbering messages from the given stamp
+// and reserve stamps in the store before using them.
+func (c *ReliableContext) UseStampStore(store StampStore, nextStamp int32) {
+	c.counterMutex.Lock()
+	defer c.counterMutex.Unlock()
+
+	c.stampStore = store
+	c.messageCounter = nextStamp
+	c.reservedStamps = nextStamp
+}
+
+// Stop makes the context drop all messages sent from now on, which simulates a crash of the process.
+func (c *ReliableContext) Stop() {
+	c.stopped.Store(true)
+}
+
+// Stopped returns whether the context is stopped, so that processes can stop their background work.
+func (c *ReliableContext) Stopped() bool {
+	return c.stopped.Load()
+}
+
+// StopAfterMessagesSent makes the context stop once the given number of messages was sent
+// and call onLimit afterwards. Retransmissions and acknowledgements are not counted.
+func (c *ReliableContext) StopAfterMessagesSent(limit int, onLimit func()) {
+	c.messageLimit = int64(limit)
+	c.onMessageLimit = onLimit
+}
+
 func (c *ReliableContext) MakeNewMessage() *messages.Message {
 	c.counterMutex.Lock()
 	defer c.counterMutex.Unlock()
+
+	if c.stampStore != nil && c.messageCounter >= c.reservedStamps {
+		c.reservedStamps = c.messageCounter + StampBlockSize
+		if e := c.stampStore.ReserveStamps(c.reservedStamps); e != nil {
+			log.Fatalf("Could not reserve message stamps: %v\n", e)
+		}
+	}
 	msg := &messages.Message{
 		Sender: c.processIndex,
 		Stamp:  c.messageCounter,
@@ -66,11 +124,18 @@ func (c *ReliableContext) MakeNewMessage() *messages.Message {
 }
 
 func (c *ReliableContext) send(to int32, msg *messages.Message) {
+	if c.stopped.Load() {
+		return
+	}
 	data, e := utils.Marshal(msg)
 	if e != nil {
 		log.Printf("Error while serializing message happened: %e\n", e)
 		return
 	}
+	if len(data) > MaxMessageSize {
+		log.Printf("Message of %d bytes does not fit in a datagram of %d bytes\n", len(data), MaxMessageSize)
+		return
+	}
 	c.writeChan <- Packet{
 		To:   to,
 		Data: data,
@@ -79,6 +144,10 @@ func (c *ReliableContext) send(to int32, msg *messages.Message) {
 }
 
 func (c *ReliableContext) Send(to int32, msg *messages.Message) {
+	if c.stopped.Load() {
+		return
+	}
+
 	c.mutex.Lock()
 	ackChan := make(chan bool)
 	c.receivedAcks[msg.Stamp] = ackChan
@@ -86,6 +155,11 @@ func (c *ReliableContext) Send(to int32, msg *messages.Message) {
 
 	c.send(to, msg)
 
+	if c.onMessageLimit != nil && c.sentMessages.Add(1) == c.messageLimit {
+		c.Stop()
+		go c.onMessageLimit()
+	}
+
 	go func() {
 		t := time.NewTicker(time.Duration(c.retransmissionTimeoutNs))
 		for {
@@ -93,6 +167,10 @@ func (c *ReliableContext) Send(to int32, msg *messages.Message) {
 			case <-ackChan:
 				return
 			case <-t.C:
+				if c.stopped.Load() {
+					t.Stop()
+					return
+				}
 				msg.RetransmissionStamp++
 				c.send(to, msg)
 			}
@@ -4,15 +4,17 @@ go 1.20
 
 require (
 	github.com/stretchr/testify v1.7.1
+	golang.org/x/crypto v0.21.0
 	golang.org/x/exp v0.0.0-20220518171630-0b5c67f07fdf
 	gonum.org/v1/gonum v0.12.0
 	google.golang.org/protobuf v1.28.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -5,12 +5,15 @@ import (
 	"stochastic-checking-simulation/impl/hashing"
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/utils"
+	"sync/atomic"
 )
 
 // EventLogger logs new events.
 type EventLogger struct {
 	pid    int32
 	logger *log.Logger
+
+	rejectedMessages atomic.Int64
 }
 
 func InitEventLogger(pid int32, logger *log.Logger) *EventLogger {
@@ -36,6 +39,12 @@ func (el *EventLogger) OnSimulationStart() {
 		el.pid, utils.GetNow())
 }
 
+func (el *EventLogger) OnSimulationFinished() {
+	el.logger.Printf(
+		"Simulation finished: %d, timestamp: %d\n",
+		el.pid, utils.GetNow())
+}
+
 func (el *EventLogger) OnTransactionInit(
 	broadcastInstance *messages.BroadcastInstance,
 ) {
@@ -44,10 +53,13 @@ func (el *EventLogger) OnTransactionInit(
 		broadcastInstance.ToString(), utils.GetNow())
 }
 
+// OnWitnessSetSelected logs the witness set of the given type selected for a transaction, and the epoch
+// of the checkpoint whose history hash it was selected with, which is 0 if checkpoints are not used.
 func (el *EventLogger) OnWitnessSetSelected(
 	wsType string,
 	broadcastInstance *messages.BroadcastInstance,
 	ws map[string]bool,
+	historyEpoch int32,
 ) {
 	pids := make([]string, len(ws))
 	i := 0
@@ -57,8 +69,33 @@ func (el *EventLogger) OnWitnessSetSelected(
 	}
 
 	el.logger.Printf(
-		"Witness set selected; type: %s, transaction: %s, pids: %v, timestamp: %d\n",
-		wsType, broadcastInstance.ToString(), pids, utils.GetNow())
+		"Witness set selected; type: %s, transaction: %s, pids: %v, history epoch: %d, timestamp: %d\n",
+		wsType, broadcastInstance.ToString(), pids, historyEpoch, utils.GetNow())
+}
+
+// OnWitnessSelfSelected logs that the process has selected itself as a witness of the given type for a transaction
+// with a verifiable random function.
+func (el *EventLogger) OnWitnessSelfSelected(wsType string, broadcastInstance *messages.BroadcastInstance) {
+	el.logger.Printf(
+		"Witness self-selected; type: %s, transaction: %s, timestamp: %d\n",
+		wsType, broadcastInstance.ToString(), utils.GetNow())
+}
+
+func (el *EventLogger) OnCheckpoint(epoch int32, cut []int32) {
+	el.logger.Printf("History checkpoint; epoch: %d, cut: %v, timestamp: %d\n", epoch, cut, utils.GetNow())
+}
+
+// OnCertificateSent logs the number of signatures of a certificate the process broadcasts
+// and the size of the message carrying it, as sent over the network.
+func (el *EventLogger) OnCertificateSent(
+	broadcastInstance *messages.BroadcastInstance,
+	stage string,
+	signatures int,
+	messageSize int,
+) {
+	el.logger.Printf(
+		"Certificate sent: %s, stage: %s, signatures: %d, message size: %d, timestamp: %d\n",
+		broadcastInstance.ToString(), stage, signatures, messageSize, utils.GetNow())
 }
 
 func (el *EventLogger) OnRecoveryProtocolSwitch(broadcastInstance *messages.BroadcastInstance) {
@@ -77,6 +114,56 @@ func (el *EventLogger) OnDeliver(
 		utils.GetNow())
 }
 
+func (el *EventLogger) OnOrderedDeliver(
+	broadcastInstance *messages.BroadcastInstance, value int32, position int) {
+	el.logger.Printf(
+		"Ordered transaction: %s, value: %d, position: %d, timestamp: %d\n",
+		broadcastInstance.ToString(),
+		value,
+		position,
+		utils.GetNow())
+}
+
+func (el *EventLogger) OnFifoDeliver(
+	broadcastInstance *messages.BroadcastInstance, value int32, bufferingDelay int64) {
+	el.logger.Printf(
+		"FIFO transaction: %s, value: %d, buffering delay: %d, timestamp: %d\n",
+		broadcastInstance.ToString(),
+		value,
+		bufferingDelay,
+		utils.GetNow())
+}
+
+func (el *EventLogger) OnRecovered(records int, transactionCounter int32) {
+	el.logger.Printf(
+		"Recovered from write-ahead log: %d records, transaction counter: %d, timestamp: %d\n",
+		records,
+		transactionCounter,
+		utils.GetNow())
+}
+
+func (el *EventLogger) OnReconfiguration(epoch int32, members []int32) {
+	el.logger.Printf(
+		"Membership epoch: %d, members: %v, timestamp: %d\n",
+		epoch,
+		members,
+		utils.GetNow())
+}
+
+func (el *EventLogger) OnCrash(reason string) {
+	el.logger.Printf("Process crashed: %s, timestamp: %d\n", reason, utils.GetNow())
+}
+
+func (el *EventLogger) OnProcessRestart(pid int32) {
+	el.logger.Printf("Process restarted: %d, timestamp: %d\n", pid, utils.GetNow())
+}
+
+func (el *EventLogger) OnDeliveryPath(broadcastInstance *messages.BroadcastInstance, path string) {
+	el.logger.Printf(
+		"Delivery path: %s, path: %s, timestamp: %d\n",
+		broadcastInstance.ToString(), path, utils.GetNow())
+}
+
 func (el *EventLogger) OnHistoryUsedInWitnessSetSelection(
 	broadcastInstance *messages.BroadcastInstance,
 	historyHash *hashing.HistoryHash,
@@ -90,6 +177,25 @@ func (el *EventLogger) OnHistoryUsedInWitnessSetSelection(
 		utils.GetNow())
 }
 
+func (el *EventLogger) OnSampleCompleted(
+	broadcastInstance *messages.BroadcastInstance,
+	round int32,
+	value int32,
+	votes int,
+	sampleSize int,
+	confidence int,
+) {
+	el.logger.Printf(
+		"Sample completed: %s, round: %d, value: %d, votes: %d, sample size: %d, confidence: %d, timestamp: %d\n",
+		broadcastInstance.ToString(),
+		round,
+		value,
+		votes,
+		sampleSize,
+		confidence,
+		utils.GetNow())
+}
+
 func (el *EventLogger) OnAttack(
 	broadcastInstance *messages.BroadcastInstance,
 	receivedValue int32,
@@ -116,6 +222,20 @@ func (el *EventLogger) OnMessageReceived(senderPid int32, msgId int32) {
 		senderPid, msgId, utils.GetNow())
 }
 
+// OnMessageRejected logs a malformed or unexpected message, which is dropped instead of being processed.
+// The sender is -1 if the message could not be decoded.
+func (el *EventLogger) OnMessageRejected(senderPid int32, reason string) {
+	el.rejectedMessages.Add(1)
+	el.logger.Printf(
+		"Rejected message; sender: %d, reason: %s, timestamp: %d\n",
+		senderPid, reason, utils.GetNow())
+}
+
+// RejectedMessages returns the number of messages rejected so far.
+func (el *EventLogger) RejectedMessages() int64 {
+	return el.rejectedMessages.Load()
+}
+
 func (el *EventLogger) OnAckReceived(msgId int32) {
 	el.logger.Printf("Received ack: %d\n", msgId)
 }
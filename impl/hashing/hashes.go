@@ -3,6 +3,11 @@ package hashing
 import (
 	"crypto/sha256"
 	"crypto/sha512"
+	"fmt"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+	"hash/fnv"
+	"sort"
 )
 
 // Hasher is the interface that wraps a Hash method.
@@ -24,3 +29,65 @@ func (h HashSHA512) Hash(data []byte) []byte {
 	bytes := sha512.Sum512(data)
 	return bytes[:]
 }
+
+type HashSHA3256 struct{}
+
+func (h HashSHA3256) Hash(data []byte) []byte {
+	bytes := sha3.Sum256(data)
+	return bytes[:]
+}
+
+type HashBLAKE2b struct{}
+
+func (h HashBLAKE2b) Hash(data []byte) []byte {
+	bytes := blake2b.Sum512(data)
+	return bytes[:]
+}
+
+// HashFNV is the 128-bit FNV-1a hash. It is not cryptographic, so byzantine processes
+// can choose transactions steering witness selection, and is meant only for simulations measuring performance.
+type HashFNV struct{}
+
+func (h HashFNV) Hash(data []byte) []byte {
+	hash := fnv.New128a()
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// HashFunction describes a hash function which can be selected in the input file.
+type HashFunction struct {
+	// Name identifies the hash function in the input file.
+	Name string
+	// DigestSize is the size of digests in bits.
+	DigestSize int
+	// Cryptographic is false for hash functions which are only fit for simulations.
+	Cryptographic bool
+	Hasher        Hasher
+}
+
+var hashFunctions = map[string]*HashFunction{
+	"sha256":   {Name: "sha256", DigestSize: 256, Cryptographic: true, Hasher: HashSHA256{}},
+	"sha512":   {Name: "sha512", DigestSize: 512, Cryptographic: true, Hasher: HashSHA512{}},
+	"sha3-256": {Name: "sha3-256", DigestSize: 256, Cryptographic: true, Hasher: HashSHA3256{}},
+	"blake2b":  {Name: "blake2b", DigestSize: 512, Cryptographic: true, Hasher: HashBLAKE2b{}},
+	"fnv":      {Name: "fnv", DigestSize: 128, Cryptographic: false, Hasher: HashFNV{}},
+}
+
+// GetHashFunction returns the hash function with the given name.
+func GetHashFunction(name string) (*HashFunction, error) {
+	hashFunction, registered := hashFunctions[name]
+	if !registered {
+		return nil, fmt.Errorf("invalid hash function: %s, expected one of %v", name, HashFunctionNames())
+	}
+	return hashFunction, nil
+}
+
+// HashFunctionNames returns names of all hash functions sorted alphabetically.
+func HashFunctionNames() []string {
+	names := make([]string, 0, len(hashFunctions))
+	for name := range hashFunctions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,97 @@
+package hashing
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestHashFunctions_digestSize(t *testing.T) {
+	for _, name := range HashFunctionNames() {
+		hashFunction, e := GetHashFunction(name)
+
+		assert.Nil(t, e)
+		assert.Equal(t, name, hashFunction.Name)
+		assert.Len(t, hashFunction.Hasher.Hash([]byte("transaction")), hashFunction.DigestSize/8, name)
+	}
+}
+
+func TestHashFunctions_deterministic(t *testing.T) {
+	for _, name := range HashFunctionNames() {
+		hashFunction, _ := GetHashFunction(name)
+		hasher := hashFunction.Hasher
+
+		assert.Equal(t, hasher.Hash([]byte("transaction")), hasher.Hash([]byte("transaction")), name)
+		assert.NotEqual(t, hasher.Hash([]byte("transaction")), hasher.Hash([]byte("transactioN")), name)
+	}
+}
+
+func TestGetHashFunction_unknown(t *testing.T) {
+	_, e := GetHashFunction("md5")
+
+	assert.ErrorContains(t, e, "invalid hash function: md5")
+}
+
+func BenchmarkHash(b *testing.B) {
+	data := []byte("127.0.0.1:5001127.0.0.1:500242")
+	for _, name := range HashFunctionNames() {
+		hashFunction, _ := GetHashFunction(name)
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				hashFunction.Hasher.Hash(data)
+			}
+		})
+	}
+}
+
+// BenchmarkGetWitnessSet_hash measures the share of hashing in the witness selection with every hash function.
+func BenchmarkGetWitnessSet_hash(b *testing.B) {
+	const n = 100
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("10.0.0.%d:5001", i)
+	}
+
+	for _, name := range HashFunctionNames() {
+		hashFunction, _ := GetHashFunction(name)
+		nodeIdSize := 128
+		if hashFunction.DigestSize >= 256 {
+			nodeIdSize = 256
+		}
+		binNum := uint(32)
+		binCapacity := uint(1) << (nodeIdSize / int(binNum))
+
+		ws := WitnessesSelector{
+			Hasher:               hashFunction.Hasher,
+			MinPotWitnessSetSize: 10,
+			MinOwnWitnessSetSize: 5,
+		}
+		historyHash := NewHistoryHash(binNum, binCapacity, hashFunction.Hasher)
+		for i := 0; i < 1000; i++ {
+			historyHash.Insert([]byte(strconv.Itoa(i)))
+		}
+
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ws.GetWitnessSet(ids, int32(i%n), int32(i), historyHash)
+			}
+		})
+	}
+}
+
+// Digests of fnv have fewer bytes than the default number of bins, which must not make all id rings equal
+func TestGetWitnessSet_fnvDigestShorterThanBins(t *testing.T) {
+	hashFunction, _ := GetHashFunction("fnv")
+	ws := WitnessesSelector{Hasher: hashFunction.Hasher, MinPotWitnessSetSize: 2, MinOwnWitnessSetSize: 2}
+	ids := makeNodeIds(16)
+	historyHash := NewHistoryHash(32, 1<<(128/32), hashFunction.Hasher)
+
+	witnessSets := make(map[string]bool)
+	for seqNumber := int32(0); seqNumber < 20; seqNumber++ {
+		ownWitnessSet, _ := ws.GetWitnessSet(ids, 0, seqNumber, historyHash)
+		witnessSets[fmt.Sprint(ownWitnessSet)] = true
+	}
+
+	assert.Greater(t, len(witnessSets), 1)
+}
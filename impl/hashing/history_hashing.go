@@ -1,8 +1,11 @@
 package hashing
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"stochastic-checking-simulation/impl/utils"
+	"time"
 )
 
 type HistoryHash struct {
@@ -10,6 +13,19 @@ type HistoryHash struct {
 	binCapacity uint
 	hasher      Hasher
 	bins        *MultiRing
+
+	// Limits of the window of transactions contributing to the history hash, unlimited if zero
+	windowSize     int
+	windowDuration time.Duration
+	// Transactions in the window, oldest first, kept only if the window is limited
+	window []windowEntry
+	now    func() time.Time
+}
+
+type windowEntry struct {
+	binIndex  uint64
+	direction int
+	inserted  time.Time
 }
 
 func NewHistoryHash(binNum uint, binCapacity uint, hasher Hasher) *HistoryHash {
@@ -18,21 +34,158 @@ func NewHistoryHash(binNum uint, binCapacity uint, hasher Hasher) *HistoryHash {
 	hh.binCapacity = binCapacity
 	hh.hasher = hasher
 	hh.bins = NewMultiRing(binCapacity, binNum)
+	hh.now = time.Now
 	return hh
 }
 
-// Insert adds a new transaction into the multiRing representing history hash
-func (hh *HistoryHash) Insert(bytes []byte) {
+// Copy returns a history hash with the same bins and hash function, whose window is not limited.
+func (hh *HistoryHash) Copy() *HistoryHash {
+	copied := NewHistoryHash(hh.binNum, hh.binCapacity, hh.hasher)
+	copied.bins = hh.bins.copy()
+	return copied
+}
+
+// SetWindow limits the transactions contributing to the history hash to the last size inserted ones
+// and to the ones inserted during the last duration. A zero size or duration does not limit the window.
+// Transactions inserted before the window is set are not removed.
+func (hh *HistoryHash) SetWindow(size int, duration time.Duration) {
+	hh.windowSize = size
+	hh.windowDuration = duration
+}
+
+// bin returns the bin a transaction is added to and the direction in which the bin is changed
+func (hh *HistoryHash) bin(bytes []byte) (uint64, int) {
 	h := utils.ToUint64(hh.hasher.Hash(bytes))
 	binIndex := h % uint64(hh.binNum)
 	direction := 1
 	if (h & uint64(hh.binNum)) == 0 {
 		direction = -1
 	}
+	return binIndex, direction
+}
+
+// Insert adds a new transaction into the multiRing representing history hash.
+// If the window is limited, transactions which leave the window are removed.
+func (hh *HistoryHash) Insert(bytes []byte) {
+	binIndex, direction := hh.bin(bytes)
 	hh.bins.add(binIndex, direction)
+
+	if hh.windowSize > 0 || hh.windowDuration > 0 {
+		hh.window = append(hh.window, windowEntry{binIndex: binIndex, direction: direction, inserted: hh.now()})
+		if hh.windowSize > 0 && len(hh.window) > hh.windowSize {
+			hh.removeOldest(len(hh.window) - hh.windowSize)
+		}
+		hh.Expire()
+	}
+}
+
+// Remove removes a transaction from the multiRing representing history hash, changing its bin
+// in the direction opposite to Insert, so that inserting and removing a transaction leaves the history hash unchanged.
+// It does not change the window.
+func (hh *HistoryHash) Remove(bytes []byte) {
+	binIndex, direction := hh.bin(bytes)
+	hh.bins.add(binIndex, -direction)
+}
+
+// Expire removes the transactions inserted before the window duration, if it is limited.
+// It should be called before the history hash is used, since time passes without transactions being inserted.
+func (hh *HistoryHash) Expire() {
+	if hh.windowDuration <= 0 {
+		return
+	}
+	threshold := hh.now().Add(-hh.windowDuration)
+	expired := 0
+	for expired < len(hh.window) && hh.window[expired].inserted.Before(threshold) {
+		expired++
+	}
+	hh.removeOldest(expired)
+}
+
+func (hh *HistoryHash) removeOldest(count int) {
+	for _, entry := range hh.window[:count] {
+		hh.bins.add(entry.binIndex, -entry.direction)
+	}
+	hh.window = hh.window[count:]
 }
 
 // ToString converts the current historyHash to string
 func (hh *HistoryHash) ToString() string {
 	return fmt.Sprintf("%v", hh.bins.vector)
 }
+
+// MarshalBinary encodes the number of bins, the bin capacity and the values of the bins as uvarints.
+// The hash function is not encoded.
+func (hh *HistoryHash) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, (2+hh.binNum)*binary.MaxVarintLen64)
+	data = binary.AppendUvarint(data, uint64(hh.binNum))
+	data = binary.AppendUvarint(data, uint64(hh.binCapacity))
+	for _, value := range hh.bins.vector {
+		data = binary.AppendUvarint(data, uint64(value))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a history hash encoded by MarshalBinary into hh, keeping its hash function.
+// The number of bins and the bin capacity must be the same as the ones of hh.
+func (hh *HistoryHash) UnmarshalBinary(data []byte) error {
+	readUvarint := func() (uint64, error) {
+		value, size := binary.Uvarint(data)
+		if size <= 0 {
+			return 0, errors.New("history hash is truncated or malformed")
+		}
+		data = data[size:]
+		return value, nil
+	}
+
+	binNum, e := readUvarint()
+	if e != nil {
+		return e
+	}
+	binCapacity, e := readUvarint()
+	if e != nil {
+		return e
+	}
+	if binNum != uint64(hh.binNum) || binCapacity != uint64(hh.binCapacity) {
+		return fmt.Errorf("history hash has %d bins of capacity %d, expected %d bins of capacity %d",
+			binNum, binCapacity, hh.binNum, hh.binCapacity)
+	}
+
+	vector := make([]int, hh.binNum)
+	for i := range vector {
+		value, e := readUvarint()
+		if e != nil {
+			return e
+		}
+		if value >= binCapacity {
+			return fmt.Errorf("bin %d of the history hash is %d, which exceeds the bin capacity %d",
+				i, value, binCapacity)
+		}
+		vector[i] = int(value)
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("history hash has %d trailing bytes", len(data))
+	}
+
+	hh.bins.vector = vector
+	hh.window = nil
+	return nil
+}
+
+// Equal returns true if both history hashes have the same bins.
+func (hh *HistoryHash) Equal(other *HistoryHash) bool {
+	if hh.binNum != other.binNum || hh.binCapacity != other.binCapacity {
+		return false
+	}
+	for i, value := range hh.bins.vector {
+		if other.bins.vector[i] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Distance returns the distance between the bins of two history hashes in the given metric.
+// It returns an error if the history hashes have different numbers of bins or bin capacities.
+func (hh *HistoryHash) Distance(other *HistoryHash, metric Metric) (float64, error) {
+	return multiRingMetricDistance(metric, hh.bins, other.bins)
+}
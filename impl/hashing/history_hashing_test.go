@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"stochastic-checking-simulation/impl/utils"
 	"testing"
+	"time"
 )
 
 const binCapacity = uint(8)
@@ -50,3 +51,109 @@ func TestToString(t *testing.T) {
 
 	assert.Equal(t, "[1 0 0 0]", hhString)
 }
+
+func TestMarshalBinary_roundTrip(t *testing.T) {
+	hh := NewHistoryHash(binNum, 1<<20, &mockHasher{})
+	hh.bins.set(0, 1)
+	hh.bins.set(3, 1<<20-1)
+
+	data, e := hh.MarshalBinary()
+	assert.Nil(t, e)
+
+	decoded := NewHistoryHash(binNum, 1<<20, &mockHasher{})
+	e = decoded.UnmarshalBinary(data)
+
+	assert.Nil(t, e)
+	assert.Exactly(t, hh.bins.vector, decoded.bins.vector)
+	assert.True(t, hh.Equal(decoded))
+}
+
+func TestUnmarshalBinary_invalid(t *testing.T) {
+	hh := NewHistoryHash(binNum, binCapacity, &mockHasher{})
+	hh.bins.set(1, 5)
+	data, _ := hh.MarshalBinary()
+
+	otherBinNum := NewHistoryHash(binNum+1, binCapacity, &mockHasher{})
+	assert.ErrorContains(t, otherBinNum.UnmarshalBinary(data), "expected 5 bins of capacity 8")
+
+	decoded := NewHistoryHash(binNum, binCapacity, &mockHasher{})
+	assert.ErrorContains(t, decoded.UnmarshalBinary(data[:len(data)-1]), "truncated")
+	assert.ErrorContains(t, decoded.UnmarshalBinary(append(data, 0)), "trailing")
+
+	tooLarge := append([]byte(nil), data...)
+	tooLarge[3] = byte(binCapacity)
+	assert.ErrorContains(t, decoded.UnmarshalBinary(tooLarge), "exceeds the bin capacity")
+
+	assert.Exactly(t, make([]int, binNum), decoded.bins.vector)
+}
+
+func TestDistance(t *testing.T) {
+	hh1 := NewHistoryHash(binNum, binCapacity, &mockHasher{})
+	hh2 := NewHistoryHash(binNum, binCapacity, &mockHasher{})
+	hh2.bins.set(0, 7)
+	hh2.bins.set(2, 3)
+
+	distance, e := hh1.Distance(hh2, L1Metric{})
+
+	assert.Nil(t, e)
+	assert.Equal(t, 4.0, distance)
+	assert.False(t, hh1.Equal(hh2))
+
+	_, e = hh1.Distance(NewHistoryHash(binNum+1, binCapacity, &mockHasher{}), L1Metric{})
+	assert.NotNil(t, e)
+}
+
+func TestRemove_inverseOfInsert(t *testing.T) {
+	hh := NewHistoryHash(binNum, binCapacity, HashSHA256{})
+	for i := 0; i < 10; i++ {
+		hh.Insert(utils.TransactionToBytes("author", int64(i)))
+	}
+	expected := hh.bins.copy()
+
+	hh.Insert(utils.TransactionToBytes("author", 10))
+	hh.Remove(utils.TransactionToBytes("author", 10))
+
+	assert.Exactly(t, expected.vector, hh.bins.vector)
+}
+
+func TestSetWindow_lastTransactions(t *testing.T) {
+	hh := NewHistoryHash(binNum, binCapacity, HashSHA256{})
+	hh.SetWindow(3, 0)
+	expected := NewHistoryHash(binNum, binCapacity, HashSHA256{})
+
+	for i := 0; i < 10; i++ {
+		hh.Insert(utils.TransactionToBytes("author", int64(i)))
+		if i >= 7 {
+			expected.Insert(utils.TransactionToBytes("author", int64(i)))
+		}
+	}
+
+	assert.True(t, expected.Equal(hh))
+	assert.Len(t, hh.window, 3)
+}
+
+func TestSetWindow_duration(t *testing.T) {
+	now := time.Unix(0, 0)
+	hh := NewHistoryHash(binNum, binCapacity, HashSHA256{})
+	hh.now = func() time.Time { return now }
+	hh.SetWindow(0, 10*time.Second)
+	expected := NewHistoryHash(binNum, binCapacity, HashSHA256{})
+
+	for i := 0; i < 10; i++ {
+		hh.Insert(utils.TransactionToBytes("author", int64(i)))
+		if i >= 5 {
+			expected.Insert(utils.TransactionToBytes("author", int64(i)))
+		}
+		now = now.Add(2 * time.Second)
+	}
+	// Transactions 0 to 4 were inserted more than 10 seconds ago
+	hh.Expire()
+
+	assert.True(t, expected.Equal(hh))
+
+	now = now.Add(time.Minute)
+	hh.Expire()
+
+	assert.Exactly(t, make([]int, binNum), hh.bins.vector)
+	assert.Empty(t, hh.window)
+}
@@ -0,0 +1,123 @@
+package hashing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Metric is the interface that wraps a Distance method.
+// Distance returns the distance between two multi rings with the given modulo, given the offsets of their bins,
+// i.e. the values of the bins of the first ring minus the bins of the second one, in [0, modulo).
+type Metric interface {
+	Distance(offsets []int, modulo int) float64
+}
+
+// binDistance returns the distance of a bin from zero, going around the ring in the shorter direction.
+func binDistance(offset int, modulo int) int {
+	if modulo-offset < offset {
+		return modulo - offset
+	}
+	return offset
+}
+
+// L1Metric is the toroidal L1 distance, the sum of the bin distances.
+type L1Metric struct{}
+
+func (m L1Metric) Distance(offsets []int, modulo int) float64 {
+	distance := 0
+	for _, offset := range offsets {
+		distance += binDistance(offset, modulo)
+	}
+	return float64(distance)
+}
+
+// L2Metric is the toroidal euclidean distance.
+type L2Metric struct{}
+
+func (m L2Metric) Distance(offsets []int, modulo int) float64 {
+	sum := 0.0
+	for _, offset := range offsets {
+		d := float64(binDistance(offset, modulo))
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// LInfMetric is the toroidal Chebyshev distance, the largest bin distance.
+type LInfMetric struct{}
+
+func (m LInfMetric) Distance(offsets []int, modulo int) float64 {
+	distance := 0
+	for _, offset := range offsets {
+		if d := binDistance(offset, modulo); d > distance {
+			distance = d
+		}
+	}
+	return float64(distance)
+}
+
+// HammingMetric is the number of bins which differ.
+type HammingMetric struct{}
+
+func (m HammingMetric) Distance(offsets []int, modulo int) float64 {
+	distance := 0
+	for _, offset := range offsets {
+		if offset != 0 {
+			distance++
+		}
+	}
+	return float64(distance)
+}
+
+// RingMetric is the distance used in consistent hashing. The bins are read as the digits of a point on a single ring
+// of length modulo, the first bin being the most significant one, and the distance is measured clockwise.
+// Unlike the other metrics, it is not symmetric, so that witnesses of a transaction are the nodes following it
+// on the ring.
+type RingMetric struct{}
+
+func (m RingMetric) Distance(offsets []int, modulo int) float64 {
+	distance := 0.0
+	scale := 1.0
+	for _, offset := range offsets {
+		distance += float64(offset) * scale
+		scale /= float64(modulo)
+	}
+	return distance
+}
+
+// DistanceMetric describes a metric which can be selected in the input file.
+type DistanceMetric struct {
+	// Name identifies the metric in the input file.
+	Name string
+	// Symmetric is false for metrics whose distance from x to y may differ from the one from y to x.
+	Symmetric bool
+	Metric    Metric
+}
+
+var distanceMetrics = map[string]*DistanceMetric{
+	"l1":      {Name: "l1", Symmetric: true, Metric: L1Metric{}},
+	"l2":      {Name: "l2", Symmetric: true, Metric: L2Metric{}},
+	"linf":    {Name: "linf", Symmetric: true, Metric: LInfMetric{}},
+	"hamming": {Name: "hamming", Symmetric: true, Metric: HammingMetric{}},
+	"ring":    {Name: "ring", Symmetric: false, Metric: RingMetric{}},
+}
+
+// GetDistanceMetric returns the metric with the given name.
+func GetDistanceMetric(name string) (*DistanceMetric, error) {
+	distanceMetric, registered := distanceMetrics[name]
+	if !registered {
+		return nil, fmt.Errorf("invalid metric: %s, expected one of %v", name, DistanceMetricNames())
+	}
+	return distanceMetric, nil
+}
+
+// DistanceMetricNames returns names of all metrics sorted alphabetically.
+func DistanceMetricNames() []string {
+	names := make([]string, 0, len(distanceMetrics))
+	for name := range distanceMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
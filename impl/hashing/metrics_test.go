@@ -0,0 +1,106 @@
+package hashing
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestMetrics_distance(t *testing.T) {
+	offsets := []int{0, 1, 7, 4}
+	const modulo = 8
+
+	assert.Equal(t, 6.0, L1Metric{}.Distance(offsets, modulo))
+	assert.Equal(t, math.Sqrt(18), L2Metric{}.Distance(offsets, modulo))
+	assert.Equal(t, 4.0, LInfMetric{}.Distance(offsets, modulo))
+	assert.Equal(t, 3.0, HammingMetric{}.Distance(offsets, modulo))
+	assert.Equal(t, 0+1.0/8+7.0/64+4.0/512, RingMetric{}.Distance(offsets, modulo))
+}
+
+func TestMetrics_zeroDistanceToItself(t *testing.T) {
+	for _, name := range DistanceMetricNames() {
+		distanceMetric, e := GetDistanceMetric(name)
+		assert.Nil(t, e)
+		assert.Equal(t, name, distanceMetric.Name)
+
+		mr := makeDefaultMultiRing()
+		distance, e := multiRingMetricDistance(distanceMetric.Metric, mr, mr.copy())
+
+		assert.Nil(t, e)
+		assert.Equal(t, 0.0, distance, name)
+	}
+}
+
+func TestMetrics_symmetric(t *testing.T) {
+	mr1 := makeDefaultMultiRing()
+	mr2 := makeDefaultMultiRing()
+	mr2.set(0, 3)
+	mr2.set(2, 1)
+
+	for _, name := range DistanceMetricNames() {
+		distanceMetric, _ := GetDistanceMetric(name)
+
+		d12, _ := multiRingMetricDistance(distanceMetric.Metric, mr1, mr2)
+		d21, _ := multiRingMetricDistance(distanceMetric.Metric, mr2, mr1)
+
+		assert.Equal(t, distanceMetric.Symmetric, d12 == d21, name)
+	}
+}
+
+func FuzzMetrics_symmetricMetricsAreMetrics(f *testing.F) {
+	f.Add(uint16(8), uint8(4), []byte{0, 0, 1, 0, 7, 0, 4, 0, 3, 0, 3, 0, 1, 0, 1, 0, 5, 0, 6, 0})
+	f.Add(uint16(2), uint8(1), []byte{1, 0})
+	f.Add(uint16(1024), uint8(32), []byte("three multi-rings of history hash bins"))
+	f.Fuzz(func(t *testing.T, modulo uint16, dimension uint8, data []byte) {
+		if modulo == 0 || dimension == 0 {
+			t.Skip()
+		}
+		rings := makeMultiRings(uint(modulo), uint(dimension), 3, data)
+		a, b, c := rings[0], rings[1], rings[2]
+
+		for _, name := range DistanceMetricNames() {
+			distanceMetric, _ := GetDistanceMetric(name)
+			if !distanceMetric.Symmetric {
+				continue
+			}
+			distance := func(r1 *MultiRing, r2 *MultiRing) float64 {
+				d, e := multiRingMetricDistance(distanceMetric.Metric, r1, r2)
+				assert.Nil(t, e)
+				return d
+			}
+
+			dab, dba, dbc, dac := distance(a, b), distance(b, a), distance(b, c), distance(a, c)
+
+			assert.Equal(t, 0.0, distance(a, a), name)
+			assert.GreaterOrEqual(t, dab, 0.0, name)
+			assert.Equal(t, assert.ObjectsAreEqual(a.vector, b.vector), dab == 0, name)
+			assert.Equal(t, dab, dba, name)
+			assert.LessOrEqual(t, dac, dab+dbc+1e-9*(1+dac), name)
+		}
+	})
+}
+
+func TestGetDistanceMetric_unknown(t *testing.T) {
+	_, e := GetDistanceMetric("cosine")
+
+	assert.ErrorContains(t, e, "invalid metric: cosine")
+}
+
+func TestGetWitnessSet_metricChangesDistances(t *testing.T) {
+	hasher := HashSHA256{}
+	nodeIds := makeNodeIds(16)
+	historyHash := makeHistoryHash(8, 32, 10)
+
+	l1 := &WitnessesSelector{Hasher: hasher}
+	hamming := &WitnessesSelector{Hasher: hasher, Metric: HammingMetric{}}
+
+	l1Distances := l1.Distances(nodeIds, nodeIds[0], 1, historyHash, nil)
+	hammingDistances := hamming.Distances(nodeIds, nodeIds[0], 1, historyHash, nil)
+
+	assert.Len(t, hammingDistances, len(nodeIds))
+	for i := range nodeIds {
+		assert.LessOrEqual(t, hammingDistances[i], l1Distances[i])
+		assert.LessOrEqual(t, hammingDistances[i], 8.0)
+	}
+	assert.NotEqual(t, l1Distances, hammingDistances)
+}
@@ -2,8 +2,6 @@ package hashing
 
 import (
 	"errors"
-	"math"
-	"stochastic-checking-simulation/impl/utils"
 )
 
 type MultiRing struct {
@@ -50,32 +48,53 @@ func (r *MultiRing) subtract(x int, y int) float64 {
 
 // Calculates L1 distance between the two multiRings
 func multiRingDistance(r1 *MultiRing, r2 *MultiRing) (float64, error) {
+	return multiRingMetricDistance(L1Metric{}, r1, r2)
+}
+
+// Calculates the distance between the two multiRings in the given metric
+func multiRingMetricDistance(metric Metric, r1 *MultiRing, r2 *MultiRing) (float64, error) {
 	if r1.modulo != r2.modulo || r1.dimension != r2.dimension {
 		return 0.0,
 			errors.New("cannot calculate distance between two rings with different modulo or dimension")
 	}
-	sum := 0.0
+	offsets := make([]int, r1.dimension)
 	for i := uint(0); i < r1.dimension; i++ {
-		sum += math.Min(
-			r1.subtract(r1.vector[i], r2.vector[i]),
-			r1.subtract(r2.vector[i], r1.vector[i]))
+		offsets[i] = int(r1.subtract(r1.vector[i], r2.vector[i]))
 	}
-	return sum, nil
+	return metric.Distance(offsets, int(r1.modulo)), nil
 }
 
-// Creates a new multiRing with a given modulo and dimension from array of bytes
+// Creates a new multiRing with a given modulo and dimension from array of bytes, see ringBin
 func multiRingFromBytes(modulo uint, dimension uint, bytes []byte) *MultiRing {
 	mr := NewMultiRing(modulo, dimension)
-	bytesPerDimension := uint(len(bytes) / int(dimension))
-	if bytesPerDimension > 8 {
-		bytesPerDimension = 8
+	for i := 0; i < int(dimension); i++ {
+		mr.set(uint64(i), int(ringBin(bytes, int(dimension), i)))
 	}
-	value := make([]byte, 8)
-	for i := uint(0); i < dimension; i++ {
-		for j := uint(0); j < bytesPerDimension; j++ {
-			value[j] = bytes[i*bytesPerDimension+j]
+	return mr
+}
+
+// ringBin returns the value of the bin i of a ring of the given dimension read from bytes,
+// before it is reduced modulo the bin capacity. If there are at least as many bytes as bins, every bin is read
+// from the next len(bytes) / dimension bytes, at most 8, as a little-endian number. Otherwise, every bin is read
+// from the next len(bytes) * 8 / dimension bits, so that short hashes do not make all bins zero,
+// and bins which no bits are left for are zero.
+func ringBin(bytes []byte, dimension int, i int) uint64 {
+	var value uint64
+	bytesPerDimension := len(bytes) / dimension
+	if bytesPerDimension > 0 {
+		if bytesPerDimension > 8 {
+			bytesPerDimension = 8
 		}
-		mr.set(uint64(i), int(utils.ToUint64(value)))
+		for j := bytesPerDimension - 1; j >= 0; j-- {
+			value = value<<8 | uint64(bytes[i*bytesPerDimension+j])
+		}
+		return value
 	}
-	return mr
+
+	bitsPerDimension := len(bytes) * 8 / dimension
+	for j := bitsPerDimension - 1; j >= 0; j-- {
+		bit := i*bitsPerDimension + j
+		value = value<<1 | uint64(bytes[bit/8]>>(bit%8)&1)
+	}
+	return value
 }
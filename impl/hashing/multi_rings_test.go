@@ -1,6 +1,7 @@
 package hashing
 
 import (
+	"encoding/binary"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -59,6 +60,91 @@ func TestMultiRingFromBytes(t *testing.T) {
 	}
 }
 
+func TestMultiRingFromBytes_fewerBytesThanBins(t *testing.T) {
+	// Every bin is read from 8 / 4 = 2 bits, the lowest bits first
+	mr := multiRingFromBytes(modulo, dimension, []byte{0b10110100})
+
+	assert.Equal(t, []int{0, 1, 3, 2}, mr.vector)
+}
+
+func TestMultiRingFromBytes_fewerBitsThanBins(t *testing.T) {
+	mr := multiRingFromBytes(modulo, 16, []byte{0xff})
+
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, mr.vector)
+}
+
+func FuzzMultiRingFromBytes(f *testing.F) {
+	f.Add(uint16(256), uint8(4), []byte{0, 10, 20, 30})
+	f.Add(uint16(8), uint8(32), make([]byte, 16))
+	f.Add(uint16(1), uint8(1), []byte{})
+	f.Fuzz(func(t *testing.T, modulo uint16, dimension uint8, bytes []byte) {
+		if modulo == 0 || dimension == 0 {
+			t.Skip()
+		}
+
+		mr := multiRingFromBytes(uint(modulo), uint(dimension), bytes)
+
+		assert.Len(t, mr.vector, int(dimension))
+		for _, value := range mr.vector {
+			assert.True(t, value >= 0 && value < int(modulo), "bin %d is out of [0, %d)", value, modulo)
+		}
+	})
+}
+
+// makeMultiRings returns count multiRings whose bins are set to the 16-bit signed integers read from data,
+// which may be out of the range of the bins, and to zero once data runs out.
+func makeMultiRings(modulo uint, dimension uint, count int, data []byte) []*MultiRing {
+	rings := make([]*MultiRing, count)
+	for r := range rings {
+		rings[r] = NewMultiRing(modulo, dimension)
+		for i := 0; i < int(dimension); i++ {
+			value := 0
+			if offset := 2 * (r*int(dimension) + i); offset+2 <= len(data) {
+				value = int(int16(binary.LittleEndian.Uint16(data[offset:])))
+			}
+			rings[r].set(uint64(i), value)
+		}
+	}
+	return rings
+}
+
+func FuzzMultiRing_set(f *testing.F) {
+	f.Add(uint16(8), 5)
+	f.Add(uint16(8), -13)
+	f.Add(uint16(1<<15), -1<<63)
+	f.Fuzz(func(t *testing.T, modulo uint16, value int) {
+		if modulo == 0 {
+			t.Skip()
+		}
+		mr := NewMultiRing(uint(modulo), 1)
+
+		mr.set(0, value)
+
+		assert.True(t, mr.vector[0] >= 0 && mr.vector[0] < int(modulo), "bin %d is out of [0, %d)", mr.vector[0], modulo)
+		assert.Zero(t, (value%int(modulo)-mr.vector[0])%int(modulo), "bin %d is not congruent to %d", mr.vector[0], value)
+	})
+}
+
+func FuzzMultiRing_mergeCommutative(f *testing.F) {
+	f.Add(uint16(8), uint8(4), []byte{1, 0, 2, 0, 3, 0, 250, 255, 255, 255, 0, 128})
+	f.Add(uint16(256), uint8(32), []byte("bins of two history hashes"))
+	f.Fuzz(func(t *testing.T, modulo uint16, dimension uint8, data []byte) {
+		if modulo == 0 || dimension == 0 {
+			t.Skip()
+		}
+		rings := makeMultiRings(uint(modulo), uint(dimension), 2, data)
+		merged12, merged21 := rings[0].copy(), rings[1].copy()
+
+		merged12.merge(rings[1])
+		merged21.merge(rings[0])
+
+		assert.Equal(t, merged12.vector, merged21.vector)
+		for _, value := range merged12.vector {
+			assert.True(t, value >= 0 && value < int(modulo), "bin %d is out of [0, %d)", value, modulo)
+		}
+	})
+}
+
 func TestMultiRingDistance_multiRingsEqual(t *testing.T) {
 	mr1 := makeDefaultMultiRing()
 	mr2 := makeDefaultMultiRing()
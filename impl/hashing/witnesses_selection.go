@@ -1,21 +1,36 @@
 package hashing
 
 import (
-	"log"
 	"math/rand"
 	"sort"
 	"stochastic-checking-simulation/impl/utils"
 	"strconv"
+	"sync"
 )
 
 // WitnessesSelector enables witness set selection.
+// It keeps buffers between the calls, so that selecting witness sets does not allocate memory per node,
+// and is safe for concurrent use.
 type WitnessesSelector struct {
 	Hasher Hasher
+	// Metric measures distances of nodes, the toroidal L1 distance is used if it is nil
+	Metric Metric
 
 	MinPotWitnessSetSize int
 	MinOwnWitnessSetSize int
 	PotWitnessSetRadius  float64
 	OwnWitnessSetRadius  float64
+
+	mutex sync.Mutex
+
+	// Source of the shuffles of the history hash bins, reseeded for every node instead of creating a source
+	source      rand.Source
+	random      *rand.Rand
+	hashInput   []byte
+	permutation []int
+	offsets     []int
+	distances   []dist
+	selection   []float64
 }
 
 type dist struct {
@@ -43,44 +58,194 @@ func (ws *WitnessesSelector) GetWitnessSet(
 	nodeIds []string,
 	authorIndex int32, seqNumber int32, historyHash *HistoryHash,
 ) (map[string]bool, map[string]bool) {
-	distances := make([]dist, len(nodeIds))
-	for i, pid := range nodeIds {
-		historyHashRingCopy := historyHash.bins.copy()
-		pidHash := ws.Hasher.Hash([]byte(pid + nodeIds[authorIndex] + strconv.Itoa(int(seqNumber))))
-		idRing := multiRingFromBytes(historyHash.binCapacity, historyHash.binNum, pidHash)
-		rd := rand.New(rand.NewSource(int64(utils.ToUint64(pidHash))))
-		rd.Shuffle(
-			int(historyHashRingCopy.dimension),
-			func(i, j int) {
-				historyHashRingCopy.vector[i], historyHashRingCopy.vector[j] =
-					historyHashRingCopy.vector[j], historyHashRingCopy.vector[i]
-			})
-
-		idRing.merge(historyHashRingCopy)
-		defaultRing := NewMultiRing(historyHash.binCapacity, historyHash.binNum)
-		d, e := multiRingDistance(defaultRing, idRing)
-
-		if e != nil {
-			log.Printf("Error while generating a witness set happened: %s\n", e)
-			return nil, nil
-		}
+	return ws.GetWitnessSetOf(nodeIds, nodeIds[authorIndex], seqNumber, historyHash)
+}
 
-		distances[i] = dist{ind: i, d: d}
-	}
+// GetWitnessSetOf returns own and potential witness sets for a given transaction
+// of the author with the given id. Witnesses are selected among nodeIds,
+// which do not have to contain the author, e.g. if the author has left the system.
+//
+// Every node is at the distance in Metric from zero of its id ring, derived from the hash of the node id and the transaction,
+// merged with the history hash, whose bins are shuffled with the hash as the seed.
+// Pot witnesses are the MinPotWitnessSetSize closest nodes and all nodes closer than PotWitnessSetRadius,
+// own witnesses are the pot witnesses among the MinOwnWitnessSetSize closest nodes and the nodes closer
+// than OwnWitnessSetRadius.
+func (ws *WitnessesSelector) GetWitnessSetOf(
+	nodeIds []string,
+	authorId string, seqNumber int32, historyHash *HistoryHash,
+) (map[string]bool, map[string]bool) {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
 
-	sort.Sort(byDist(distances))
+	n := len(nodeIds)
+	ws.computeDistances(nodeIds, authorId, seqNumber, historyHash)
 
 	potWitnessSet := make(map[string]bool)
 	ownWitnessSet := make(map[string]bool)
 
-	for i := 0; i < len(nodeIds) &&
-		(i < ws.MinPotWitnessSetSize || distances[i].d < ws.PotWitnessSetRadius); i++ {
-		id := nodeIds[distances[i].ind]
+	potSize, potMaxDistance, unique := ws.closest(ws.MinPotWitnessSetSize, ws.PotWitnessSetRadius)
+	ownSize, ownMaxDistance, ownUnique := ws.closest(ws.MinOwnWitnessSetSize, ws.OwnWitnessSetRadius)
+	if !unique || !ownUnique {
+		// Nodes at the same distance are ordered by the sort, so it decides which of them are selected
+		sort.Sort(byDist(ws.distances))
+		for i := 0; i < n &&
+			(i < ws.MinPotWitnessSetSize || ws.distances[i].d < ws.PotWitnessSetRadius); i++ {
+			id := nodeIds[ws.distances[i].ind]
+			potWitnessSet[id] = true
+			if i < ws.MinOwnWitnessSetSize || ws.distances[i].d < ws.OwnWitnessSetRadius {
+				ownWitnessSet[id] = true
+			}
+		}
+		return ownWitnessSet, potWitnessSet
+	}
+
+	for _, distance := range ws.distances {
+		if distance.d > potMaxDistance || potSize == 0 {
+			continue
+		}
+		id := nodeIds[distance.ind]
 		potWitnessSet[id] = true
-		if i < ws.MinOwnWitnessSetSize || distances[i].d < ws.OwnWitnessSetRadius {
+		if distance.d <= ownMaxDistance && ownSize > 0 {
 			ownWitnessSet[id] = true
 		}
 	}
 
 	return ownWitnessSet, potWitnessSet
 }
+
+// Distances appends to distances the distance of every node from the given transaction, in the order of nodeIds,
+// as used by GetWitnessSetOf.
+func (ws *WitnessesSelector) Distances(
+	nodeIds []string,
+	authorId string, seqNumber int32, historyHash *HistoryHash,
+	distances []float64,
+) []float64 {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	ws.computeDistances(nodeIds, authorId, seqNumber, historyHash)
+	for _, distance := range ws.distances {
+		distances = append(distances, distance.d)
+	}
+	return distances
+}
+
+// computeDistances fills distances with the distance of every node, in the order of nodeIds.
+func (ws *WitnessesSelector) computeDistances(
+	nodeIds []string,
+	authorId string, seqNumber int32, historyHash *HistoryHash,
+) {
+	history := historyHash.bins
+	dimension := int(history.dimension)
+	modulo := int(history.modulo)
+	if cap(ws.permutation) < dimension {
+		ws.permutation = make([]int, dimension)
+		ws.offsets = make([]int, dimension)
+	}
+	permutation := ws.permutation[:dimension]
+	offsets := ws.offsets[:dimension]
+	metric := ws.Metric
+	if metric == nil {
+		metric = L1Metric{}
+	}
+	ws.distances = ws.distances[:0]
+	if ws.random == nil {
+		ws.source = rand.NewSource(0)
+		ws.random = rand.New(ws.source)
+	}
+
+	// Id rings are not cached per node and author, since the hashed id includes the sequence number of the transaction,
+	// so that witness sets of transactions of the same author differ
+	for i, pid := range nodeIds {
+		ws.hashInput = append(ws.hashInput[:0], pid...)
+		ws.hashInput = append(ws.hashInput, authorId...)
+		ws.hashInput = strconv.AppendInt(ws.hashInput, int64(seqNumber), 10)
+		pidHash := ws.Hasher.Hash(ws.hashInput)
+
+		// The history ring is shuffled through a permutation of its bins, instead of shuffling its copy
+		for j := range permutation {
+			permutation[j] = j
+		}
+		ws.source.Seed(int64(utils.ToUint64(pidHash)))
+		ws.random.Shuffle(dimension, func(i, j int) {
+			permutation[i], permutation[j] = permutation[j], permutation[i]
+		})
+
+		for j := 0; j < dimension; j++ {
+			// Bins of the id ring are read from the hash as in multiRingFromBytes
+			idBin := ((int(ringBin(pidHash, dimension, j)) % modulo) + modulo) % modulo
+			offsets[j] = (((idBin + history.vector[permutation[j]]) % modulo) + modulo) % modulo
+		}
+
+		ws.distances = append(ws.distances, dist{ind: i, d: metric.Distance(offsets, modulo)})
+	}
+}
+
+// closest returns the number of nodes among the minSize closest nodes and the nodes closer than radius,
+// and the largest distance of such a node. It returns false if nodes at the largest distance are not all selected,
+// so that the selected nodes are determined by how nodes at the same distance are ordered.
+func (ws *WitnessesSelector) closest(minSize int, radius float64) (int, float64, bool) {
+	n := len(ws.distances)
+	inRadius := 0
+	maxInRadius := 0.0
+	for _, distance := range ws.distances {
+		if distance.d < radius {
+			inRadius++
+			if inRadius == 1 || distance.d > maxInRadius {
+				maxInRadius = distance.d
+			}
+		}
+	}
+
+	size := minSize
+	if size > n {
+		size = n
+	}
+	if size <= inRadius {
+		return inRadius, maxInRadius, true
+	}
+
+	ws.selection = ws.selection[:0]
+	for _, distance := range ws.distances {
+		ws.selection = append(ws.selection, distance.d)
+	}
+	maxDistance := selectKth(ws.selection, size-1)
+
+	notFarther := 0
+	for _, distance := range ws.distances {
+		if distance.d <= maxDistance {
+			notFarther++
+		}
+	}
+	return size, maxDistance, notFarther == size
+}
+
+// selectKth returns the k-th smallest value, counting from 0, reordering the values.
+func selectKth(values []float64, k int) float64 {
+	left, right := 0, len(values)-1
+	for left < right {
+		pivot := values[(left+right)/2]
+		i, j := left, right
+		for i <= j {
+			for values[i] < pivot {
+				i++
+			}
+			for values[j] > pivot {
+				j--
+			}
+			if i <= j {
+				values[i], values[j] = values[j], values[i]
+				i++
+				j--
+			}
+		}
+		if k <= j {
+			right = j
+		} else if k >= i {
+			left = i
+		} else {
+			return values[k]
+		}
+	}
+	return values[k]
+}
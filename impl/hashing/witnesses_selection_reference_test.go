@@ -0,0 +1,150 @@
+package hashing
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"stochastic-checking-simulation/impl/utils"
+	"strconv"
+	"testing"
+)
+
+// getWitnessSetReference is the straightforward implementation of the witness selection,
+// which hashes, copies and shuffles rings and sorts all nodes by their distance.
+func getWitnessSetReference(
+	ws *WitnessesSelector,
+	nodeIds []string,
+	authorId string, seqNumber int32, historyHash *HistoryHash,
+) (map[string]bool, map[string]bool) {
+	distances := make([]dist, len(nodeIds))
+	for i, pid := range nodeIds {
+		historyHashRingCopy := historyHash.bins.copy()
+		pidHash := ws.Hasher.Hash([]byte(pid + authorId + strconv.Itoa(int(seqNumber))))
+		idRing := multiRingFromBytes(historyHash.binCapacity, historyHash.binNum, pidHash)
+		rd := rand.New(rand.NewSource(int64(utils.ToUint64(pidHash))))
+		rd.Shuffle(
+			int(historyHashRingCopy.dimension),
+			func(i, j int) {
+				historyHashRingCopy.vector[i], historyHashRingCopy.vector[j] =
+					historyHashRingCopy.vector[j], historyHashRingCopy.vector[i]
+			})
+
+		idRing.merge(historyHashRingCopy)
+		defaultRing := NewMultiRing(historyHash.binCapacity, historyHash.binNum)
+		d, _ := multiRingDistance(defaultRing, idRing)
+
+		distances[i] = dist{ind: i, d: d}
+	}
+
+	sort.Sort(byDist(distances))
+
+	potWitnessSet := make(map[string]bool)
+	ownWitnessSet := make(map[string]bool)
+
+	for i := 0; i < len(nodeIds) &&
+		(i < ws.MinPotWitnessSetSize || distances[i].d < ws.PotWitnessSetRadius); i++ {
+		id := nodeIds[distances[i].ind]
+		potWitnessSet[id] = true
+		if i < ws.MinOwnWitnessSetSize || distances[i].d < ws.OwnWitnessSetRadius {
+			ownWitnessSet[id] = true
+		}
+	}
+
+	return ownWitnessSet, potWitnessSet
+}
+
+func makeNodeIds(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("10.0.%d.%d:5001", i/256, i%256)
+	}
+	return ids
+}
+
+func makeHistoryHash(binNum uint, binCapacity uint, transactions int) *HistoryHash {
+	historyHash := NewHistoryHash(binNum, binCapacity, HashSHA256{})
+	for i := 0; i < transactions; i++ {
+		historyHash.Insert(utils.TransactionToBytes(strconv.Itoa(i%7), int64(i)))
+	}
+	return historyHash
+}
+
+func TestGetWitnessSet_sameAsReference(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	for iteration := 0; iteration < 300; iteration++ {
+		n := 1 + random.Intn(300)
+		// Small bins make many nodes share the same distance
+		binNum := []uint{4, 8, 32}[random.Intn(3)]
+		binCapacity := []uint{4, 16, 256}[random.Intn(3)]
+		ws := &WitnessesSelector{
+			Hasher:               []Hasher{HashSHA256{}, HashSHA512{}, HashFNV{}}[random.Intn(3)],
+			MinPotWitnessSetSize: random.Intn(n + 2),
+			MinOwnWitnessSetSize: random.Intn(n + 2),
+			PotWitnessSetRadius:  random.Float64() * float64(binNum*binCapacity) / 2,
+			OwnWitnessSetRadius:  random.Float64() * float64(binNum*binCapacity) / 2,
+		}
+		nodeIds := makeNodeIds(n)
+		historyHash := makeHistoryHash(binNum, binCapacity, random.Intn(50))
+		author := nodeIds[random.Intn(n)]
+		seqNumber := int32(random.Intn(1000))
+
+		expectedOwn, expectedPot := getWitnessSetReference(ws, nodeIds, author, seqNumber, historyHash)
+		own, pot := ws.GetWitnessSetOf(nodeIds, author, seqNumber, historyHash)
+
+		message := fmt.Sprintf("iteration %d: %+v", iteration, ws)
+		assert.Equal(t, expectedPot, pot, message)
+		assert.Equal(t, expectedOwn, own, message)
+	}
+}
+
+func TestSelectKth(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	for iteration := 0; iteration < 200; iteration++ {
+		values := make([]float64, 1+random.Intn(50))
+		for i := range values {
+			values[i] = float64(random.Intn(10))
+		}
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		k := random.Intn(len(values))
+
+		assert.Equal(t, sorted[k], selectKth(values, k))
+	}
+}
+
+func benchmarkWitnessSelection(
+	b *testing.B,
+	getWitnessSet func(*WitnessesSelector, []string, string, int32, *HistoryHash),
+) {
+	for _, n := range []int{16, 64, 256, 1000} {
+		nodeIds := makeNodeIds(n)
+		ws := &WitnessesSelector{
+			Hasher:               HashSHA256{},
+			MinPotWitnessSetSize: 16,
+			MinOwnWitnessSetSize: 8,
+			PotWitnessSetRadius:  1910,
+			OwnWitnessSetRadius:  1900,
+		}
+		historyHash := makeHistoryHash(32, 256, 1000)
+
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				getWitnessSet(ws, nodeIds, nodeIds[i%n], int32(i), historyHash)
+			}
+		})
+	}
+}
+
+func BenchmarkGetWitnessSet(b *testing.B) {
+	benchmarkWitnessSelection(b, func(ws *WitnessesSelector, nodeIds []string, author string, seq int32, hh *HistoryHash) {
+		ws.GetWitnessSetOf(nodeIds, author, seq, hh)
+	})
+}
+
+func BenchmarkGetWitnessSet_reference(b *testing.B) {
+	benchmarkWitnessSelection(b, func(ws *WitnessesSelector, nodeIds []string, author string, seq int32, hh *HistoryHash) {
+		getWitnessSetReference(ws, nodeIds, author, seq, hh)
+	})
+}
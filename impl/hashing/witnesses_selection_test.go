@@ -2,6 +2,7 @@ package hashing
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 )
 
@@ -73,3 +74,56 @@ func TestGetWitnessSet_allWitnessesInRadiusReturned(t *testing.T) {
 	assert.Exactly(t, expectedWitnessSet, ownWitnessSet)
 	assert.Exactly(t, expectedWitnessSet, potWitnessSet)
 }
+
+func FuzzGetWitnessSet(f *testing.F) {
+	f.Add(uint8(16), int32(3), int32(5), 2, 4, 0.0, 0.0, uint8(0), uint8(0), uint8(8), uint8(5), []byte{1, 2, 3})
+	f.Add(uint8(3), int32(0), int32(2), 0, 3, 0.0, 0.0, uint8(2), uint8(1), uint8(3), uint8(3), []byte{})
+	f.Add(uint8(64), int32(63), int32(-7), 8, 8, 10.0, 30.0, uint8(3), uint8(4), uint8(32), uint8(3), []byte("history"))
+	f.Fuzz(func(t *testing.T, n uint8, authorIndex int32, seqNumber int32,
+		minOwn int, minPot int, ownRadius float64, potRadius float64,
+		hashFunctionIndex uint8, metricIndex uint8, binNum uint8, binCapacityBits uint8, history []byte) {
+		if n == 0 || binNum == 0 || binCapacityBits == 0 || binCapacityBits > 16 ||
+			math.IsNaN(ownRadius) || math.IsNaN(potRadius) {
+			t.Skip()
+		}
+		// Own witness sets are only contained in pot ones if their minimal sizes and radii are not larger
+		if minOwn > minPot {
+			minOwn, minPot = minPot, minOwn
+		}
+		if ownRadius > potRadius {
+			ownRadius, potRadius = potRadius, ownRadius
+		}
+		hashFunctionNames, metricNames := HashFunctionNames(), DistanceMetricNames()
+		hashFunction, _ := GetHashFunction(hashFunctionNames[int(hashFunctionIndex)%len(hashFunctionNames)])
+		distanceMetric, _ := GetDistanceMetric(metricNames[int(metricIndex)%len(metricNames)])
+		ws := WitnessesSelector{
+			Hasher:               hashFunction.Hasher,
+			Metric:               distanceMetric.Metric,
+			MinPotWitnessSetSize: minPot,
+			MinOwnWitnessSetSize: minOwn,
+			PotWitnessSetRadius:  potRadius,
+			OwnWitnessSetRadius:  ownRadius,
+		}
+		historyHash := NewHistoryHash(uint(binNum), 1<<binCapacityBits, hashFunction.Hasher)
+		for i := range history {
+			historyHash.Insert(history[i : i+1])
+		}
+		nodeIds := makeNodeIds(int(n))
+		if minOwn > int(n) {
+			minOwn = int(n)
+		}
+		if minPot > int(n) {
+			minPot = int(n)
+		}
+		authorIndex = int32(uint32(authorIndex) % uint32(n))
+
+		ownWitnessSet, potWitnessSet := ws.GetWitnessSet(nodeIds, authorIndex, seqNumber, historyHash)
+
+		for id := range ownWitnessSet {
+			assert.True(t, potWitnessSet[id], "own witness %s is not a pot witness", id)
+		}
+		assert.GreaterOrEqual(t, len(potWitnessSet), minPot)
+		assert.GreaterOrEqual(t, len(ownWitnessSet), minOwn)
+		assert.LessOrEqual(t, len(potWitnessSet), int(n))
+	})
+}
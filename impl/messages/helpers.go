@@ -35,8 +35,11 @@ func (m *ConsistentProtocolMessage) Copy() *ConsistentProtocolMessage {
 		return nil
 	}
 	return &ConsistentProtocolMessage{
-		Stage: m.Stage,
-		Value: m.Value,
+		Stage:        m.Stage,
+		Value:        m.Value,
+		HistoryHash:  m.HistoryHash,
+		HistoryEpoch: m.HistoryEpoch,
+		WitnessProof: m.WitnessProof,
 	}
 }
 
@@ -45,8 +48,11 @@ func (m *ReliableProtocolMessage) Copy() *ReliableProtocolMessage {
 		return nil
 	}
 	return &ReliableProtocolMessage{
-		Stage: m.Stage,
-		Value: m.Value,
+		Stage:        m.Stage,
+		Value:        m.Value,
+		HistoryHash:  m.HistoryHash,
+		HistoryEpoch: m.HistoryEpoch,
+		WitnessProof: m.WitnessProof,
 	}
 }
 
@@ -69,3 +75,15 @@ func (m *ScalableProtocolMessage) Copy() *ScalableProtocolMessage {
 		Value: m.Value,
 	}
 }
+
+func (m *GenericProtocolMessage) Copy() *GenericProtocolMessage {
+	if m == nil {
+		return nil
+	}
+	payload := make([]byte, len(m.Payload))
+	copy(payload, m.Payload)
+	return &GenericProtocolMessage{
+		Protocol: m.Protocol,
+		Payload:  payload,
+	}
+}
@@ -66,14 +66,17 @@ func (x BrachaProtocolMessage_Stage) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BrachaProtocolMessage_Stage.Descriptor instead.
 func (BrachaProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{5, 0}
+	return file_messages_proto_rawDescGZIP(), []int{11, 0}
 }
 
 type ConsistentProtocolMessage_Stage int32
 
 const (
-	ConsistentProtocolMessage_ECHO   ConsistentProtocolMessage_Stage = 0
-	ConsistentProtocolMessage_VERIFY ConsistentProtocolMessage_Stage = 1
+	ConsistentProtocolMessage_ECHO           ConsistentProtocolMessage_Stage = 0
+	ConsistentProtocolMessage_VERIFY         ConsistentProtocolMessage_Stage = 1
+	ConsistentProtocolMessage_RECOVERY_ECHO  ConsistentProtocolMessage_Stage = 2
+	ConsistentProtocolMessage_RECOVERY_READY ConsistentProtocolMessage_Stage = 3
+	ConsistentProtocolMessage_RECOVERY_REPLY ConsistentProtocolMessage_Stage = 4
 )
 
 // Enum value maps for ConsistentProtocolMessage_Stage.
@@ -81,10 +84,16 @@ var (
 	ConsistentProtocolMessage_Stage_name = map[int32]string{
 		0: "ECHO",
 		1: "VERIFY",
+		2: "RECOVERY_ECHO",
+		3: "RECOVERY_READY",
+		4: "RECOVERY_REPLY",
 	}
 	ConsistentProtocolMessage_Stage_value = map[string]int32{
-		"ECHO":   0,
-		"VERIFY": 1,
+		"ECHO":           0,
+		"VERIFY":         1,
+		"RECOVERY_ECHO":  2,
+		"RECOVERY_READY": 3,
+		"RECOVERY_REPLY": 4,
 	}
 )
 
@@ -112,7 +121,7 @@ func (x ConsistentProtocolMessage_Stage) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConsistentProtocolMessage_Stage.Descriptor instead.
 func (ConsistentProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{6, 0}
+	return file_messages_proto_rawDescGZIP(), []int{12, 0}
 }
 
 type ReliableProtocolMessage_Stage int32
@@ -170,7 +179,7 @@ func (x ReliableProtocolMessage_Stage) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ReliableProtocolMessage_Stage.Descriptor instead.
 func (ReliableProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{7, 0}
+	return file_messages_proto_rawDescGZIP(), []int{13, 0}
 }
 
 type RecoveryProtocolMessage_Stage int32
@@ -222,7 +231,7 @@ func (x RecoveryProtocolMessage_Stage) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RecoveryProtocolMessage_Stage.Descriptor instead.
 func (RecoveryProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{8, 0}
+	return file_messages_proto_rawDescGZIP(), []int{14, 0}
 }
 
 type ScalableProtocolMessage_Stage int32
@@ -280,13 +289,216 @@ func (x ScalableProtocolMessage_Stage) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ScalableProtocolMessage_Stage.Descriptor instead.
 func (ScalableProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{9, 0}
+	return file_messages_proto_rawDescGZIP(), []int{15, 0}
+}
+
+type ImbsRaynalProtocolMessage_Stage int32
+
+const (
+	ImbsRaynalProtocolMessage_INITIAL ImbsRaynalProtocolMessage_Stage = 0
+	ImbsRaynalProtocolMessage_WITNESS ImbsRaynalProtocolMessage_Stage = 1
+)
+
+// Enum value maps for ImbsRaynalProtocolMessage_Stage.
+var (
+	ImbsRaynalProtocolMessage_Stage_name = map[int32]string{
+		0: "INITIAL",
+		1: "WITNESS",
+	}
+	ImbsRaynalProtocolMessage_Stage_value = map[string]int32{
+		"INITIAL": 0,
+		"WITNESS": 1,
+	}
+)
+
+func (x ImbsRaynalProtocolMessage_Stage) Enum() *ImbsRaynalProtocolMessage_Stage {
+	p := new(ImbsRaynalProtocolMessage_Stage)
+	*p = x
+	return p
+}
+
+func (x ImbsRaynalProtocolMessage_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImbsRaynalProtocolMessage_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_messages_proto_enumTypes[5].Descriptor()
+}
+
+func (ImbsRaynalProtocolMessage_Stage) Type() protoreflect.EnumType {
+	return &file_messages_proto_enumTypes[5]
+}
+
+func (x ImbsRaynalProtocolMessage_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImbsRaynalProtocolMessage_Stage.Descriptor instead.
+func (ImbsRaynalProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{16, 0}
+}
+
+type GossipProtocolMessage_Stage int32
+
+const (
+	GossipProtocolMessage_PUSH         GossipProtocolMessage_Stage = 0
+	GossipProtocolMessage_PULL_REQUEST GossipProtocolMessage_Stage = 1
+	GossipProtocolMessage_PULL_REPLY   GossipProtocolMessage_Stage = 2
+)
+
+// Enum value maps for GossipProtocolMessage_Stage.
+var (
+	GossipProtocolMessage_Stage_name = map[int32]string{
+		0: "PUSH",
+		1: "PULL_REQUEST",
+		2: "PULL_REPLY",
+	}
+	GossipProtocolMessage_Stage_value = map[string]int32{
+		"PUSH":         0,
+		"PULL_REQUEST": 1,
+		"PULL_REPLY":   2,
+	}
+)
+
+func (x GossipProtocolMessage_Stage) Enum() *GossipProtocolMessage_Stage {
+	p := new(GossipProtocolMessage_Stage)
+	*p = x
+	return p
+}
+
+func (x GossipProtocolMessage_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GossipProtocolMessage_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_messages_proto_enumTypes[6].Descriptor()
+}
+
+func (GossipProtocolMessage_Stage) Type() protoreflect.EnumType {
+	return &file_messages_proto_enumTypes[6]
+}
+
+func (x GossipProtocolMessage_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GossipProtocolMessage_Stage.Descriptor instead.
+func (GossipProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{18, 0}
+}
+
+type SnowballProtocolMessage_Stage int32
+
+const (
+	SnowballProtocolMessage_PROPOSE  SnowballProtocolMessage_Stage = 0
+	SnowballProtocolMessage_QUERY    SnowballProtocolMessage_Stage = 1
+	SnowballProtocolMessage_RESPONSE SnowballProtocolMessage_Stage = 2
+)
+
+// Enum value maps for SnowballProtocolMessage_Stage.
+var (
+	SnowballProtocolMessage_Stage_name = map[int32]string{
+		0: "PROPOSE",
+		1: "QUERY",
+		2: "RESPONSE",
+	}
+	SnowballProtocolMessage_Stage_value = map[string]int32{
+		"PROPOSE":  0,
+		"QUERY":    1,
+		"RESPONSE": 2,
+	}
+)
+
+func (x SnowballProtocolMessage_Stage) Enum() *SnowballProtocolMessage_Stage {
+	p := new(SnowballProtocolMessage_Stage)
+	*p = x
+	return p
+}
+
+func (x SnowballProtocolMessage_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SnowballProtocolMessage_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_messages_proto_enumTypes[7].Descriptor()
+}
+
+func (SnowballProtocolMessage_Stage) Type() protoreflect.EnumType {
+	return &file_messages_proto_enumTypes[7]
+}
+
+func (x SnowballProtocolMessage_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SnowballProtocolMessage_Stage.Descriptor instead.
+func (SnowballProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{19, 0}
+}
+
+type AuthenticatedProtocolMessage_Stage int32
+
+const (
+	AuthenticatedProtocolMessage_SEND              AuthenticatedProtocolMessage_Stage = 0
+	AuthenticatedProtocolMessage_ECHO              AuthenticatedProtocolMessage_Stage = 1
+	AuthenticatedProtocolMessage_ECHO_CERTIFICATE  AuthenticatedProtocolMessage_Stage = 2
+	AuthenticatedProtocolMessage_READY             AuthenticatedProtocolMessage_Stage = 3
+	AuthenticatedProtocolMessage_READY_CERTIFICATE AuthenticatedProtocolMessage_Stage = 4
+)
+
+// Enum value maps for AuthenticatedProtocolMessage_Stage.
+var (
+	AuthenticatedProtocolMessage_Stage_name = map[int32]string{
+		0: "SEND",
+		1: "ECHO",
+		2: "ECHO_CERTIFICATE",
+		3: "READY",
+		4: "READY_CERTIFICATE",
+	}
+	AuthenticatedProtocolMessage_Stage_value = map[string]int32{
+		"SEND":              0,
+		"ECHO":              1,
+		"ECHO_CERTIFICATE":  2,
+		"READY":             3,
+		"READY_CERTIFICATE": 4,
+	}
+)
+
+func (x AuthenticatedProtocolMessage_Stage) Enum() *AuthenticatedProtocolMessage_Stage {
+	p := new(AuthenticatedProtocolMessage_Stage)
+	*p = x
+	return p
+}
+
+func (x AuthenticatedProtocolMessage_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthenticatedProtocolMessage_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_messages_proto_enumTypes[8].Descriptor()
+}
+
+func (AuthenticatedProtocolMessage_Stage) Type() protoreflect.EnumType {
+	return &file_messages_proto_enumTypes[8]
+}
+
+func (x AuthenticatedProtocolMessage_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthenticatedProtocolMessage_Stage.Descriptor instead.
+func (AuthenticatedProtocolMessage_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{21, 0}
 }
 
 type Started struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Member    bool   `protobuf:"varint,1,opt,name=member,proto3" json:"member,omitempty"`
+	Finishes  bool   `protobuf:"varint,2,opt,name=finishes,proto3" json:"finishes,omitempty"`
+	PublicKey []byte `protobuf:"bytes,3,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
 }
 
 func (x *Started) Reset() {
@@ -321,10 +533,34 @@ func (*Started) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *Started) GetMember() bool {
+	if x != nil {
+		return x.Member
+	}
+	return false
+}
+
+func (x *Started) GetFinishes() bool {
+	if x != nil {
+		return x.Finishes
+	}
+	return false
+}
+
+func (x *Started) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
 type Simulate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Membership *Membership `protobuf:"bytes,1,opt,name=membership,proto3" json:"membership,omitempty"`
+	PublicKeys [][]byte    `protobuf:"bytes,2,rep,name=publicKeys,proto3" json:"publicKeys,omitempty"`
 }
 
 func (x *Simulate) Reset() {
@@ -359,16 +595,31 @@ func (*Simulate) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{1}
 }
 
-type Broadcast struct {
+func (x *Simulate) GetMembership() *Membership {
+	if x != nil {
+		return x.Membership
+	}
+	return nil
+}
+
+func (x *Simulate) GetPublicKeys() [][]byte {
+	if x != nil {
+		return x.PublicKeys
+	}
+	return nil
+}
+
+type Membership struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value int32 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Epoch   int32   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Members []int32 `protobuf:"varint,2,rep,packed,name=members,proto3" json:"members,omitempty"`
 }
 
-func (x *Broadcast) Reset() {
-	*x = Broadcast{}
+func (x *Membership) Reset() {
+	*x = Membership{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_messages_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -376,13 +627,13 @@ func (x *Broadcast) Reset() {
 	}
 }
 
-func (x *Broadcast) String() string {
+func (x *Membership) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Broadcast) ProtoMessage() {}
+func (*Membership) ProtoMessage() {}
 
-func (x *Broadcast) ProtoReflect() protoreflect.Message {
+func (x *Membership) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -394,29 +645,35 @@ func (x *Broadcast) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Broadcast.ProtoReflect.Descriptor instead.
-func (*Broadcast) Descriptor() ([]byte, []int) {
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Broadcast) GetValue() int32 {
+func (x *Membership) GetEpoch() int32 {
 	if x != nil {
-		return x.Value
+		return x.Epoch
 	}
 	return 0
 }
 
-type Ack struct {
+func (x *Membership) GetMembers() []int32 {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type MembershipRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sender int32 `protobuf:"varint,1,opt,name=sender,proto3" json:"sender,omitempty"`
-	Stamp  int32 `protobuf:"varint,2,opt,name=stamp,proto3" json:"stamp,omitempty"`
+	Leave bool `protobuf:"varint,1,opt,name=leave,proto3" json:"leave,omitempty"`
 }
 
-func (x *Ack) Reset() {
-	*x = Ack{}
+func (x *MembershipRequest) Reset() {
+	*x = MembershipRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_messages_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -424,13 +681,13 @@ func (x *Ack) Reset() {
 	}
 }
 
-func (x *Ack) String() string {
+func (x *MembershipRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Ack) ProtoMessage() {}
+func (*MembershipRequest) ProtoMessage() {}
 
-func (x *Ack) ProtoReflect() protoreflect.Message {
+func (x *MembershipRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -442,36 +699,26 @@ func (x *Ack) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Ack.ProtoReflect.Descriptor instead.
-func (*Ack) Descriptor() ([]byte, []int) {
+// Deprecated: Use MembershipRequest.ProtoReflect.Descriptor instead.
+func (*MembershipRequest) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Ack) GetSender() int32 {
-	if x != nil {
-		return x.Sender
-	}
-	return 0
-}
-
-func (x *Ack) GetStamp() int32 {
+func (x *MembershipRequest) GetLeave() bool {
 	if x != nil {
-		return x.Stamp
+		return x.Leave
 	}
-	return 0
+	return false
 }
 
-type BroadcastInstance struct {
+type Finished struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Author    int32 `protobuf:"varint,1,opt,name=author,proto3" json:"author,omitempty"`
-	SeqNumber int32 `protobuf:"varint,2,opt,name=seqNumber,proto3" json:"seqNumber,omitempty"`
 }
 
-func (x *BroadcastInstance) Reset() {
-	*x = BroadcastInstance{}
+func (x *Finished) Reset() {
+	*x = Finished{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_messages_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -479,13 +726,13 @@ func (x *BroadcastInstance) Reset() {
 	}
 }
 
-func (x *BroadcastInstance) String() string {
+func (x *Finished) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastInstance) ProtoMessage() {}
+func (*Finished) ProtoMessage() {}
 
-func (x *BroadcastInstance) ProtoReflect() protoreflect.Message {
+func (x *Finished) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -497,36 +744,19 @@ func (x *BroadcastInstance) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastInstance.ProtoReflect.Descriptor instead.
-func (*BroadcastInstance) Descriptor() ([]byte, []int) {
+// Deprecated: Use Finished.ProtoReflect.Descriptor instead.
+func (*Finished) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *BroadcastInstance) GetAuthor() int32 {
-	if x != nil {
-		return x.Author
-	}
-	return 0
-}
-
-func (x *BroadcastInstance) GetSeqNumber() int32 {
-	if x != nil {
-		return x.SeqNumber
-	}
-	return 0
-}
-
-type BrachaProtocolMessage struct {
+type Shutdown struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Stage BrachaProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.BrachaProtocolMessage_Stage" json:"stage,omitempty"`
-	Value int32                       `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *BrachaProtocolMessage) Reset() {
-	*x = BrachaProtocolMessage{}
+func (x *Shutdown) Reset() {
+	*x = Shutdown{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_messages_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -534,13 +764,13 @@ func (x *BrachaProtocolMessage) Reset() {
 	}
 }
 
-func (x *BrachaProtocolMessage) String() string {
+func (x *Shutdown) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BrachaProtocolMessage) ProtoMessage() {}
+func (*Shutdown) ProtoMessage() {}
 
-func (x *BrachaProtocolMessage) ProtoReflect() protoreflect.Message {
+func (x *Shutdown) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -552,36 +782,22 @@ func (x *BrachaProtocolMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BrachaProtocolMessage.ProtoReflect.Descriptor instead.
-func (*BrachaProtocolMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use Shutdown.ProtoReflect.Descriptor instead.
+func (*Shutdown) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *BrachaProtocolMessage) GetStage() BrachaProtocolMessage_Stage {
-	if x != nil {
-		return x.Stage
-	}
-	return BrachaProtocolMessage_INITIAL
-}
-
-func (x *BrachaProtocolMessage) GetValue() int32 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
-}
-
-type ConsistentProtocolMessage struct {
+type HistoryReport struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stage ConsistentProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.ConsistentProtocolMessage_Stage" json:"stage,omitempty"`
-	Value int32                           `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Epoch     int32   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Delivered []int32 `protobuf:"varint,2,rep,packed,name=delivered,proto3" json:"delivered,omitempty"`
 }
 
-func (x *ConsistentProtocolMessage) Reset() {
-	*x = ConsistentProtocolMessage{}
+func (x *HistoryReport) Reset() {
+	*x = HistoryReport{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_messages_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -589,13 +805,13 @@ func (x *ConsistentProtocolMessage) Reset() {
 	}
 }
 
-func (x *ConsistentProtocolMessage) String() string {
+func (x *HistoryReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConsistentProtocolMessage) ProtoMessage() {}
+func (*HistoryReport) ProtoMessage() {}
 
-func (x *ConsistentProtocolMessage) ProtoReflect() protoreflect.Message {
+func (x *HistoryReport) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -607,36 +823,36 @@ func (x *ConsistentProtocolMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConsistentProtocolMessage.ProtoReflect.Descriptor instead.
-func (*ConsistentProtocolMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use HistoryReport.ProtoReflect.Descriptor instead.
+func (*HistoryReport) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ConsistentProtocolMessage) GetStage() ConsistentProtocolMessage_Stage {
+func (x *HistoryReport) GetEpoch() int32 {
 	if x != nil {
-		return x.Stage
+		return x.Epoch
 	}
-	return ConsistentProtocolMessage_ECHO
+	return 0
 }
 
-func (x *ConsistentProtocolMessage) GetValue() int32 {
+func (x *HistoryReport) GetDelivered() []int32 {
 	if x != nil {
-		return x.Value
+		return x.Delivered
 	}
-	return 0
+	return nil
 }
 
-type ReliableProtocolMessage struct {
+type Checkpoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stage ReliableProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.ReliableProtocolMessage_Stage" json:"stage,omitempty"`
-	Value int32                         `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Epoch int32   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Cut   []int32 `protobuf:"varint,2,rep,packed,name=cut,proto3" json:"cut,omitempty"`
 }
 
-func (x *ReliableProtocolMessage) Reset() {
-	*x = ReliableProtocolMessage{}
+func (x *Checkpoint) Reset() {
+	*x = Checkpoint{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_messages_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -644,13 +860,13 @@ func (x *ReliableProtocolMessage) Reset() {
 	}
 }
 
-func (x *ReliableProtocolMessage) String() string {
+func (x *Checkpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReliableProtocolMessage) ProtoMessage() {}
+func (*Checkpoint) ProtoMessage() {}
 
-func (x *ReliableProtocolMessage) ProtoReflect() protoreflect.Message {
+func (x *Checkpoint) ProtoReflect() protoreflect.Message {
 	mi := &file_messages_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -662,51 +878,856 @@ func (x *ReliableProtocolMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReliableProtocolMessage.ProtoReflect.Descriptor instead.
-func (*ReliableProtocolMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
+func (*Checkpoint) Descriptor() ([]byte, []int) {
 	return file_messages_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ReliableProtocolMessage) GetStage() ReliableProtocolMessage_Stage {
+func (x *Checkpoint) GetEpoch() int32 {
 	if x != nil {
-		return x.Stage
+		return x.Epoch
 	}
-	return ReliableProtocolMessage_NOTIFY
+	return 0
 }
 
-func (x *ReliableProtocolMessage) GetValue() int32 {
+func (x *Checkpoint) GetCut() []int32 {
 	if x != nil {
-		return x.Value
+		return x.Cut
 	}
-	return 0
+	return nil
 }
 
-type RecoveryProtocolMessage struct {
+type Broadcast struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stage                   RecoveryProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.RecoveryProtocolMessage_Stage" json:"stage,omitempty"`
-	ReliableProtocolMessage *ReliableProtocolMessage      `protobuf:"bytes,2,opt,name=reliableProtocolMessage,proto3" json:"reliableProtocolMessage,omitempty"`
+	Value int32 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *RecoveryProtocolMessage) Reset() {
-	*x = RecoveryProtocolMessage{}
+func (x *Broadcast) Reset() {
+	*x = Broadcast{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Broadcast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Broadcast) ProtoMessage() {}
+
+func (x *Broadcast) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Broadcast.ProtoReflect.Descriptor instead.
+func (*Broadcast) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Broadcast) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type Ack struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sender int32 `protobuf:"varint,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Stamp  int32 `protobuf:"varint,2,opt,name=stamp,proto3" json:"stamp,omitempty"`
+}
+
+func (x *Ack) Reset() {
+	*x = Ack{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ack) ProtoMessage() {}
+
+func (x *Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ack.ProtoReflect.Descriptor instead.
+func (*Ack) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Ack) GetSender() int32 {
+	if x != nil {
+		return x.Sender
+	}
+	return 0
+}
+
+func (x *Ack) GetStamp() int32 {
+	if x != nil {
+		return x.Stamp
+	}
+	return 0
+}
+
+type BroadcastInstance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Author    int32 `protobuf:"varint,1,opt,name=author,proto3" json:"author,omitempty"`
+	SeqNumber int32 `protobuf:"varint,2,opt,name=seqNumber,proto3" json:"seqNumber,omitempty"`
+}
+
+func (x *BroadcastInstance) Reset() {
+	*x = BroadcastInstance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastInstance) ProtoMessage() {}
+
+func (x *BroadcastInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastInstance.ProtoReflect.Descriptor instead.
+func (*BroadcastInstance) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BroadcastInstance) GetAuthor() int32 {
+	if x != nil {
+		return x.Author
+	}
+	return 0
+}
+
+func (x *BroadcastInstance) GetSeqNumber() int32 {
+	if x != nil {
+		return x.SeqNumber
+	}
+	return 0
+}
+
+type BrachaProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage BrachaProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.BrachaProtocolMessage_Stage" json:"stage,omitempty"`
+	Value int32                       `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *BrachaProtocolMessage) Reset() {
+	*x = BrachaProtocolMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BrachaProtocolMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrachaProtocolMessage) ProtoMessage() {}
+
+func (x *BrachaProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrachaProtocolMessage.ProtoReflect.Descriptor instead.
+func (*BrachaProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BrachaProtocolMessage) GetStage() BrachaProtocolMessage_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return BrachaProtocolMessage_INITIAL
+}
+
+func (x *BrachaProtocolMessage) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type ConsistentProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage        ConsistentProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.ConsistentProtocolMessage_Stage" json:"stage,omitempty"`
+	Value        int32                           `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	HistoryHash  []byte                          `protobuf:"bytes,3,opt,name=historyHash,proto3" json:"historyHash,omitempty"`
+	HistoryEpoch int32                           `protobuf:"varint,4,opt,name=historyEpoch,proto3" json:"historyEpoch,omitempty"`
+	WitnessProof []byte                          `protobuf:"bytes,5,opt,name=witnessProof,proto3" json:"witnessProof,omitempty"`
+}
+
+func (x *ConsistentProtocolMessage) Reset() {
+	*x = ConsistentProtocolMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsistentProtocolMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistentProtocolMessage) ProtoMessage() {}
+
+func (x *ConsistentProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistentProtocolMessage.ProtoReflect.Descriptor instead.
+func (*ConsistentProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConsistentProtocolMessage) GetStage() ConsistentProtocolMessage_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return ConsistentProtocolMessage_ECHO
+}
+
+func (x *ConsistentProtocolMessage) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ConsistentProtocolMessage) GetHistoryHash() []byte {
+	if x != nil {
+		return x.HistoryHash
+	}
+	return nil
+}
+
+func (x *ConsistentProtocolMessage) GetHistoryEpoch() int32 {
+	if x != nil {
+		return x.HistoryEpoch
+	}
+	return 0
+}
+
+func (x *ConsistentProtocolMessage) GetWitnessProof() []byte {
+	if x != nil {
+		return x.WitnessProof
+	}
+	return nil
+}
+
+type ReliableProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage        ReliableProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.ReliableProtocolMessage_Stage" json:"stage,omitempty"`
+	Value        int32                         `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	HistoryHash  []byte                        `protobuf:"bytes,3,opt,name=historyHash,proto3" json:"historyHash,omitempty"`
+	HistoryEpoch int32                         `protobuf:"varint,4,opt,name=historyEpoch,proto3" json:"historyEpoch,omitempty"`
+	WitnessProof []byte                        `protobuf:"bytes,5,opt,name=witnessProof,proto3" json:"witnessProof,omitempty"`
+}
+
+func (x *ReliableProtocolMessage) Reset() {
+	*x = ReliableProtocolMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReliableProtocolMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReliableProtocolMessage) ProtoMessage() {}
+
+func (x *ReliableProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReliableProtocolMessage.ProtoReflect.Descriptor instead.
+func (*ReliableProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReliableProtocolMessage) GetStage() ReliableProtocolMessage_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return ReliableProtocolMessage_NOTIFY
+}
+
+func (x *ReliableProtocolMessage) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ReliableProtocolMessage) GetHistoryHash() []byte {
+	if x != nil {
+		return x.HistoryHash
+	}
+	return nil
+}
+
+func (x *ReliableProtocolMessage) GetHistoryEpoch() int32 {
+	if x != nil {
+		return x.HistoryEpoch
+	}
+	return 0
+}
+
+func (x *ReliableProtocolMessage) GetWitnessProof() []byte {
+	if x != nil {
+		return x.WitnessProof
+	}
+	return nil
+}
+
+type RecoveryProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage                   RecoveryProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.RecoveryProtocolMessage_Stage" json:"stage,omitempty"`
+	ReliableProtocolMessage *ReliableProtocolMessage      `protobuf:"bytes,2,opt,name=reliableProtocolMessage,proto3" json:"reliableProtocolMessage,omitempty"`
+}
+
+func (x *RecoveryProtocolMessage) Reset() {
+	*x = RecoveryProtocolMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecoveryProtocolMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryProtocolMessage) ProtoMessage() {}
+
+func (x *RecoveryProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryProtocolMessage.ProtoReflect.Descriptor instead.
+func (*RecoveryProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RecoveryProtocolMessage) GetStage() RecoveryProtocolMessage_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return RecoveryProtocolMessage_RECOVER
+}
+
+func (x *RecoveryProtocolMessage) GetReliableProtocolMessage() *ReliableProtocolMessage {
+	if x != nil {
+		return x.ReliableProtocolMessage
+	}
+	return nil
+}
+
+type ScalableProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage ScalableProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.ScalableProtocolMessage_Stage" json:"stage,omitempty"`
+	Value int32                         `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ScalableProtocolMessage) Reset() {
+	*x = ScalableProtocolMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScalableProtocolMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScalableProtocolMessage) ProtoMessage() {}
+
+func (x *ScalableProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScalableProtocolMessage.ProtoReflect.Descriptor instead.
+func (*ScalableProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ScalableProtocolMessage) GetStage() ScalableProtocolMessage_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return ScalableProtocolMessage_GOSSIP
+}
+
+func (x *ScalableProtocolMessage) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type ImbsRaynalProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage ImbsRaynalProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.ImbsRaynalProtocolMessage_Stage" json:"stage,omitempty"`
+	Value int32                           `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ImbsRaynalProtocolMessage) Reset() {
+	*x = ImbsRaynalProtocolMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImbsRaynalProtocolMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImbsRaynalProtocolMessage) ProtoMessage() {}
+
+func (x *ImbsRaynalProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImbsRaynalProtocolMessage.ProtoReflect.Descriptor instead.
+func (*ImbsRaynalProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImbsRaynalProtocolMessage) GetStage() ImbsRaynalProtocolMessage_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return ImbsRaynalProtocolMessage_INITIAL
+}
+
+func (x *ImbsRaynalProtocolMessage) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type GossipTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BroadcastInstance *BroadcastInstance `protobuf:"bytes,1,opt,name=broadcastInstance,proto3" json:"broadcastInstance,omitempty"`
+	Value             int32              `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *GossipTransaction) Reset() {
+	*x = GossipTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GossipTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipTransaction) ProtoMessage() {}
+
+func (x *GossipTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipTransaction.ProtoReflect.Descriptor instead.
+func (*GossipTransaction) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GossipTransaction) GetBroadcastInstance() *BroadcastInstance {
+	if x != nil {
+		return x.BroadcastInstance
+	}
+	return nil
+}
+
+func (x *GossipTransaction) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type GossipProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage        GossipProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.GossipProtocolMessage_Stage" json:"stage,omitempty"`
+	Value        int32                       `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Rounds       int32                       `protobuf:"varint,3,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	Digest       []int32                     `protobuf:"varint,4,rep,packed,name=digest,proto3" json:"digest,omitempty"`
+	Transactions []*GossipTransaction        `protobuf:"bytes,5,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Pull         int32                       `protobuf:"varint,6,opt,name=pull,proto3" json:"pull,omitempty"`
+	Held         []*BroadcastInstance        `protobuf:"bytes,7,rep,name=held,proto3" json:"held,omitempty"`
+}
+
+func (x *GossipProtocolMessage) Reset() {
+	*x = GossipProtocolMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GossipProtocolMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipProtocolMessage) ProtoMessage() {}
+
+func (x *GossipProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipProtocolMessage.ProtoReflect.Descriptor instead.
+func (*GossipProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GossipProtocolMessage) GetStage() GossipProtocolMessage_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return GossipProtocolMessage_PUSH
+}
+
+func (x *GossipProtocolMessage) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *GossipProtocolMessage) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *GossipProtocolMessage) GetDigest() []int32 {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *GossipProtocolMessage) GetTransactions() []*GossipTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *GossipProtocolMessage) GetPull() int32 {
+	if x != nil {
+		return x.Pull
+	}
+	return 0
+}
+
+func (x *GossipProtocolMessage) GetHeld() []*BroadcastInstance {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+type SnowballProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage SnowballProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.SnowballProtocolMessage_Stage" json:"stage,omitempty"`
+	Value int32                         `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Round int32                         `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (x *SnowballProtocolMessage) Reset() {
+	*x = SnowballProtocolMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnowballProtocolMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnowballProtocolMessage) ProtoMessage() {}
+
+func (x *SnowballProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnowballProtocolMessage.ProtoReflect.Descriptor instead.
+func (*SnowballProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SnowballProtocolMessage) GetStage() SnowballProtocolMessage_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return SnowballProtocolMessage_PROPOSE
+}
+
+func (x *SnowballProtocolMessage) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *SnowballProtocolMessage) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+type Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signer    int32  `protobuf:"varint,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *Signature) Reset() {
+	*x = Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messages_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Signature) ProtoMessage() {}
+
+func (x *Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
+func (*Signature) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Signature) GetSigner() int32 {
+	if x != nil {
+		return x.Signer
+	}
+	return 0
+}
+
+func (x *Signature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type AuthenticatedProtocolMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage       AuthenticatedProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.AuthenticatedProtocolMessage_Stage" json:"stage,omitempty"`
+	Value       int32                              `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Signature   *Signature                         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Certificate []*Signature                       `protobuf:"bytes,4,rep,name=certificate,proto3" json:"certificate,omitempty"`
+}
+
+func (x *AuthenticatedProtocolMessage) Reset() {
+	*x = AuthenticatedProtocolMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_messages_proto_msgTypes[8]
+		mi := &file_messages_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecoveryProtocolMessage) String() string {
+func (x *AuthenticatedProtocolMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecoveryProtocolMessage) ProtoMessage() {}
+func (*AuthenticatedProtocolMessage) ProtoMessage() {}
 
-func (x *RecoveryProtocolMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[8]
+func (x *AuthenticatedProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,51 +1738,65 @@ func (x *RecoveryProtocolMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecoveryProtocolMessage.ProtoReflect.Descriptor instead.
-func (*RecoveryProtocolMessage) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use AuthenticatedProtocolMessage.ProtoReflect.Descriptor instead.
+func (*AuthenticatedProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *RecoveryProtocolMessage) GetStage() RecoveryProtocolMessage_Stage {
+func (x *AuthenticatedProtocolMessage) GetStage() AuthenticatedProtocolMessage_Stage {
 	if x != nil {
 		return x.Stage
 	}
-	return RecoveryProtocolMessage_RECOVER
+	return AuthenticatedProtocolMessage_SEND
 }
 
-func (x *RecoveryProtocolMessage) GetReliableProtocolMessage() *ReliableProtocolMessage {
+func (x *AuthenticatedProtocolMessage) GetValue() int32 {
 	if x != nil {
-		return x.ReliableProtocolMessage
+		return x.Value
+	}
+	return 0
+}
+
+func (x *AuthenticatedProtocolMessage) GetSignature() *Signature {
+	if x != nil {
+		return x.Signature
 	}
 	return nil
 }
 
-type ScalableProtocolMessage struct {
+func (x *AuthenticatedProtocolMessage) GetCertificate() []*Signature {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+type GenericProtocolMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stage ScalableProtocolMessage_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=messages.ScalableProtocolMessage_Stage" json:"stage,omitempty"`
-	Value int32                         `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Protocol string `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Payload  []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
 }
 
-func (x *ScalableProtocolMessage) Reset() {
-	*x = ScalableProtocolMessage{}
+func (x *GenericProtocolMessage) Reset() {
+	*x = GenericProtocolMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_messages_proto_msgTypes[9]
+		mi := &file_messages_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ScalableProtocolMessage) String() string {
+func (x *GenericProtocolMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScalableProtocolMessage) ProtoMessage() {}
+func (*GenericProtocolMessage) ProtoMessage() {}
 
-func (x *ScalableProtocolMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[9]
+func (x *GenericProtocolMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messages_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,23 +1807,23 @@ func (x *ScalableProtocolMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScalableProtocolMessage.ProtoReflect.Descriptor instead.
-func (*ScalableProtocolMessage) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GenericProtocolMessage.ProtoReflect.Descriptor instead.
+func (*GenericProtocolMessage) Descriptor() ([]byte, []int) {
+	return file_messages_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ScalableProtocolMessage) GetStage() ScalableProtocolMessage_Stage {
+func (x *GenericProtocolMessage) GetProtocol() string {
 	if x != nil {
-		return x.Stage
+		return x.Protocol
 	}
-	return ScalableProtocolMessage_GOSSIP
+	return ""
 }
 
-func (x *ScalableProtocolMessage) GetValue() int32 {
+func (x *GenericProtocolMessage) GetPayload() []byte {
 	if x != nil {
-		return x.Value
+		return x.Payload
 	}
-	return 0
+	return nil
 }
 
 type BroadcastInstanceMessage struct {
@@ -804,13 +1839,14 @@ type BroadcastInstanceMessage struct {
 	//	*BroadcastInstanceMessage_ReliableProtocolMessage
 	//	*BroadcastInstanceMessage_RecoveryProtocolMessage
 	//	*BroadcastInstanceMessage_ScalableProtocolMessage
+	//	*BroadcastInstanceMessage_GenericProtocolMessage
 	Message isBroadcastInstanceMessage_Message `protobuf_oneof:"message"`
 }
 
 func (x *BroadcastInstanceMessage) Reset() {
 	*x = BroadcastInstanceMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_messages_proto_msgTypes[10]
+		mi := &file_messages_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -823,7 +1859,7 @@ func (x *BroadcastInstanceMessage) String() string {
 func (*BroadcastInstanceMessage) ProtoMessage() {}
 
 func (x *BroadcastInstanceMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[10]
+	mi := &file_messages_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -836,7 +1872,7 @@ func (x *BroadcastInstanceMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BroadcastInstanceMessage.ProtoReflect.Descriptor instead.
 func (*BroadcastInstanceMessage) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{10}
+	return file_messages_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *BroadcastInstanceMessage) GetBroadcastInstance() *BroadcastInstance {
@@ -888,6 +1924,13 @@ func (x *BroadcastInstanceMessage) GetScalableProtocolMessage() *ScalableProtoco
 	return nil
 }
 
+func (x *BroadcastInstanceMessage) GetGenericProtocolMessage() *GenericProtocolMessage {
+	if x, ok := x.GetMessage().(*BroadcastInstanceMessage_GenericProtocolMessage); ok {
+		return x.GenericProtocolMessage
+	}
+	return nil
+}
+
 type isBroadcastInstanceMessage_Message interface {
 	isBroadcastInstanceMessage_Message()
 }
@@ -912,6 +1955,10 @@ type BroadcastInstanceMessage_ScalableProtocolMessage struct {
 	ScalableProtocolMessage *ScalableProtocolMessage `protobuf:"bytes,8,opt,name=scalableProtocolMessage,proto3,oneof"`
 }
 
+type BroadcastInstanceMessage_GenericProtocolMessage struct {
+	GenericProtocolMessage *GenericProtocolMessage `protobuf:"bytes,9,opt,name=genericProtocolMessage,proto3,oneof"`
+}
+
 func (*BroadcastInstanceMessage_BrachaProtocolMessage) isBroadcastInstanceMessage_Message() {}
 
 func (*BroadcastInstanceMessage_ConsistentProtocolMessage) isBroadcastInstanceMessage_Message() {}
@@ -922,6 +1969,8 @@ func (*BroadcastInstanceMessage_RecoveryProtocolMessage) isBroadcastInstanceMess
 
 func (*BroadcastInstanceMessage_ScalableProtocolMessage) isBroadcastInstanceMessage_Message() {}
 
+func (*BroadcastInstanceMessage_GenericProtocolMessage) isBroadcastInstanceMessage_Message() {}
+
 type Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -937,13 +1986,19 @@ type Message struct {
 	//	*Message_BroadcastInstanceMessage
 	//	*Message_Ack
 	//	*Message_Broadcast
+	//	*Message_MembershipRequest
+	//	*Message_Membership
+	//	*Message_Finished
+	//	*Message_Shutdown
+	//	*Message_HistoryReport
+	//	*Message_Checkpoint
 	Content isMessage_Content `protobuf_oneof:"content"`
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_messages_proto_msgTypes[11]
+		mi := &file_messages_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -956,7 +2011,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_messages_proto_msgTypes[11]
+	mi := &file_messages_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -969,7 +2024,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_messages_proto_rawDescGZIP(), []int{11}
+	return file_messages_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *Message) GetSender() int32 {
@@ -1035,6 +2090,48 @@ func (x *Message) GetBroadcast() *Broadcast {
 	return nil
 }
 
+func (x *Message) GetMembershipRequest() *MembershipRequest {
+	if x, ok := x.GetContent().(*Message_MembershipRequest); ok {
+		return x.MembershipRequest
+	}
+	return nil
+}
+
+func (x *Message) GetMembership() *Membership {
+	if x, ok := x.GetContent().(*Message_Membership); ok {
+		return x.Membership
+	}
+	return nil
+}
+
+func (x *Message) GetFinished() *Finished {
+	if x, ok := x.GetContent().(*Message_Finished); ok {
+		return x.Finished
+	}
+	return nil
+}
+
+func (x *Message) GetShutdown() *Shutdown {
+	if x, ok := x.GetContent().(*Message_Shutdown); ok {
+		return x.Shutdown
+	}
+	return nil
+}
+
+func (x *Message) GetHistoryReport() *HistoryReport {
+	if x, ok := x.GetContent().(*Message_HistoryReport); ok {
+		return x.HistoryReport
+	}
+	return nil
+}
+
+func (x *Message) GetCheckpoint() *Checkpoint {
+	if x, ok := x.GetContent().(*Message_Checkpoint); ok {
+		return x.Checkpoint
+	}
+	return nil
+}
+
 type isMessage_Content interface {
 	isMessage_Content()
 }
@@ -1059,6 +2156,30 @@ type Message_Broadcast struct {
 	Broadcast *Broadcast `protobuf:"bytes,8,opt,name=broadcast,proto3,oneof"`
 }
 
+type Message_MembershipRequest struct {
+	MembershipRequest *MembershipRequest `protobuf:"bytes,9,opt,name=membershipRequest,proto3,oneof"`
+}
+
+type Message_Membership struct {
+	Membership *Membership `protobuf:"bytes,10,opt,name=membership,proto3,oneof"`
+}
+
+type Message_Finished struct {
+	Finished *Finished `protobuf:"bytes,11,opt,name=finished,proto3,oneof"`
+}
+
+type Message_Shutdown struct {
+	Shutdown *Shutdown `protobuf:"bytes,12,opt,name=shutdown,proto3,oneof"`
+}
+
+type Message_HistoryReport struct {
+	HistoryReport *HistoryReport `protobuf:"bytes,13,opt,name=historyReport,proto3,oneof"`
+}
+
+type Message_Checkpoint struct {
+	Checkpoint *Checkpoint `protobuf:"bytes,14,opt,name=checkpoint,proto3,oneof"`
+}
+
 func (*Message_Started) isMessage_Content() {}
 
 func (*Message_Simulate) isMessage_Content() {}
@@ -1069,151 +2190,312 @@ func (*Message_Ack) isMessage_Content() {}
 
 func (*Message_Broadcast) isMessage_Content() {}
 
+func (*Message_MembershipRequest) isMessage_Content() {}
+
+func (*Message_Membership) isMessage_Content() {}
+
+func (*Message_Finished) isMessage_Content() {}
+
+func (*Message_Shutdown) isMessage_Content() {}
+
+func (*Message_HistoryReport) isMessage_Content() {}
+
+func (*Message_Checkpoint) isMessage_Content() {}
+
 var File_messages_proto protoreflect.FileDescriptor
 
 var file_messages_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x09, 0x0a, 0x07, 0x53, 0x74,
-	0x61, 0x72, 0x74, 0x65, 0x64, 0x22, 0x0a, 0x0a, 0x08, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74,
-	0x65, 0x22, 0x21, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x22, 0x33, 0x0a, 0x03, 0x41, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x65, 0x6e,
-	0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x49, 0x0a, 0x11, 0x42, 0x72, 0x6f,
-	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
-	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x71, 0x4e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x65, 0x71, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x22, 0x95, 0x01, 0x0a, 0x15, 0x42, 0x72, 0x61, 0x63, 0x68, 0x61, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3b,
-	0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x63, 0x68, 0x61, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53,
-	0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x29, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e,
-	0x49, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x43, 0x48, 0x4f, 0x10,
-	0x01, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x02, 0x22, 0x91, 0x01, 0x0a,
-	0x19, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3f, 0x0a, 0x05, 0x73, 0x74,
-	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53,
-	0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x1d, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x43,
-	0x48, 0x4f, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x10, 0x01,
-	0x22, 0xef, 0x01, 0x0a, 0x17, 0x52, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3d, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53,
-	0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x7f, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f,
-	0x54, 0x49, 0x46, 0x59, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x43, 0x48, 0x4f, 0x5f, 0x46,
-	0x52, 0x4f, 0x4d, 0x5f, 0x57, 0x49, 0x54, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x15, 0x0a,
-	0x11, 0x45, 0x43, 0x48, 0x4f, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x50, 0x52, 0x4f, 0x43, 0x45,
-	0x53, 0x53, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x46, 0x52,
-	0x4f, 0x4d, 0x5f, 0x57, 0x49, 0x54, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12,
-	0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x50, 0x52, 0x4f, 0x43, 0x45,
-	0x53, 0x53, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x41, 0x54, 0x45,
-	0x10, 0x05, 0x22, 0xeb, 0x01, 0x0a, 0x17, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3d,
-	0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
-	0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x5b, 0x0a,
-	0x17, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
-	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6c, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x52, 0x17, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x34, 0x0a, 0x05, 0x53, 0x74,
-	0x61, 0x67, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x00,
-	0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x50, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x45,
-	0x43, 0x48, 0x4f, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x03,
-	0x22, 0xd7, 0x01, 0x0a, 0x17, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3d, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53,
-	0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x67, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x4f,
-	0x53, 0x53, 0x49, 0x50, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x4f, 0x53, 0x53, 0x49, 0x50,
-	0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04,
-	0x45, 0x43, 0x48, 0x4f, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x43, 0x48, 0x4f, 0x5f, 0x53,
-	0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45,
-	0x41, 0x44, 0x59, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x53,
-	0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x10, 0x05, 0x22, 0xcb, 0x04, 0x0a, 0x18, 0x42,
-	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x49, 0x0a, 0x11, 0x62, 0x72, 0x6f, 0x61, 0x64,
-	0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72,
-	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52,
-	0x11, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x12, 0x57, 0x0a, 0x15, 0x62, 0x72, 0x61, 0x63, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x61,
+	0x12, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x5b, 0x0a, 0x07, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x60, 0x0a, 0x08, 0x53, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x0a, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x3c, 0x0a, 0x0a, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x29, 0x0a, 0x11, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x65, 0x61, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x65, 0x61,
+	0x76, 0x65, 0x22, 0x0a, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x22, 0x0a,
+	0x0a, 0x08, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x22, 0x43, 0x0a, 0x0d, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x22,
+	0x34, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x75, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x03, 0x63, 0x75, 0x74, 0x22, 0x21, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x33, 0x0a, 0x03, 0x41, 0x63, 0x6b, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x49, 0x0a,
+	0x11, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65,
+	0x71, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73,
+	0x65, 0x71, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x95, 0x01, 0x0a, 0x15, 0x42, 0x72, 0x61,
+	0x63, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x3b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x61,
 	0x63, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x15, 0x62, 0x72, 0x61, 0x63, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x63, 0x0a, 0x19, 0x63,
-	0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
-	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73,
-	0x74, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x19, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
-	0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x5d, 0x0a, 0x17, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x29, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0b,
+	0x0a, 0x07, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x45,
+	0x43, 0x48, 0x4f, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x02,
+	0x22, 0xb6, 0x02, 0x0a, 0x19, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3f,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x77,
+	0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0c, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22,
+	0x58, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x43, 0x48, 0x4f,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x10, 0x01, 0x12, 0x11,
+	0x0a, 0x0d, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x45, 0x43, 0x48, 0x4f, 0x10,
+	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x52, 0x45,
+	0x41, 0x44, 0x59, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52,
+	0x59, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x59, 0x10, 0x04, 0x22, 0xd9, 0x02, 0x0a, 0x17, 0x52, 0x65,
+	0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x52, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x22, 0x0a, 0x0c,
+	0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0c, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x70, 0x6f, 0x63, 0x68,
+	0x12, 0x22, 0x0a, 0x0c, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x22, 0x7f, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x0a,
+	0x06, 0x4e, 0x4f, 0x54, 0x49, 0x46, 0x59, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x43, 0x48,
+	0x4f, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x57, 0x49, 0x54, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x01,
+	0x12, 0x15, 0x0a, 0x11, 0x45, 0x43, 0x48, 0x4f, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x50, 0x52,
+	0x4f, 0x43, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x41, 0x44, 0x59,
+	0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x57, 0x49, 0x54, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x03, 0x12,
+	0x16, 0x0a, 0x12, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x50, 0x52,
+	0x4f, 0x43, 0x45, 0x53, 0x53, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x56, 0x41, 0x4c, 0x49, 0x44,
+	0x41, 0x54, 0x45, 0x10, 0x05, 0x22, 0xeb, 0x01, 0x0a, 0x17, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x3d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x27, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x12, 0x5b, 0x0a, 0x17, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6c,
 	0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x17, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x17, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x34, 0x0a,
+	0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45,
+	0x52, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x50, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x08,
+	0x0a, 0x04, 0x45, 0x43, 0x48, 0x4f, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x41, 0x44,
+	0x59, 0x10, 0x03, 0x22, 0xd7, 0x01, 0x0a, 0x17, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x62, 0x6c, 0x65,
 	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x5d, 0x0a, 0x17, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x21, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x6f,
-	0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x17, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x5d,
-	0x0a, 0x17, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x21, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61,
-	0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x17, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x09, 0x0a,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8f, 0x03, 0x0a, 0x07, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x12, 0x30, 0x0a, 0x13, 0x72, 0x65, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x13, 0x72, 0x65, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53,
-	0x74, 0x61, 0x6d, 0x70, 0x12, 0x2d, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
-	0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x08, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
-	0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x08, 0x73, 0x69, 0x6d,
-	0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x60, 0x0a, 0x18, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
-	0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x18, 0x62,
-	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
-	0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x12, 0x33, 0x0a, 0x09, 0x62, 0x72,
-	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
-	0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x42,
-	0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x42, 0x2e, 0x5a, 0x2c, 0x73, 0x74,
-	0x6f, 0x63, 0x68, 0x61, 0x73, 0x74, 0x69, 0x63, 0x2d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e,
-	0x67, 0x2d, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x69, 0x6d, 0x70,
-	0x6c, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x3d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x67, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x0a,
+	0x06, 0x47, 0x4f, 0x53, 0x53, 0x49, 0x50, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x4f, 0x53,
+	0x53, 0x49, 0x50, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x10, 0x01, 0x12,
+	0x08, 0x0a, 0x04, 0x45, 0x43, 0x48, 0x4f, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x43, 0x48,
+	0x4f, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x10, 0x03, 0x12, 0x09, 0x0a,
+	0x05, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x41, 0x44,
+	0x59, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x10, 0x05, 0x22, 0x95, 0x01,
+	0x0a, 0x19, 0x49, 0x6d, 0x62, 0x73, 0x52, 0x61, 0x79, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3f, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6d, 0x62, 0x73, 0x52, 0x61, 0x79, 0x6e, 0x61, 0x6c,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x22, 0x21, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49,
+	0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x49, 0x54, 0x4e,
+	0x45, 0x53, 0x53, 0x10, 0x01, 0x22, 0x74, 0x0a, 0x11, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x11, 0x62, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x52, 0x11, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd5, 0x02, 0x0a, 0x15,
+	0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75, 0x6c,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x75, 0x6c, 0x6c, 0x12, 0x2f, 0x0a,
+	0x04, 0x68, 0x65, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x04, 0x68, 0x65, 0x6c, 0x64, 0x22, 0x33,
+	0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x55, 0x53, 0x48, 0x10,
+	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x55, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53,
+	0x54, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x55, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x50, 0x4c,
+	0x59, 0x10, 0x02, 0x22, 0xb3, 0x01, 0x0a, 0x17, 0x53, 0x6e, 0x6f, 0x77, 0x62, 0x61, 0x6c, 0x6c,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x3d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x62, 0x61,
+	0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x2d, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x67, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x4f, 0x50, 0x4f, 0x53, 0x45, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x51, 0x55, 0x45, 0x52, 0x59, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52,
+	0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0x02, 0x22, 0x41, 0x0a, 0x09, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xb7, 0x02, 0x0a,
+	0x1c, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x42, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x63, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x52, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x22, 0x53, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x45,
+	0x4e, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x43, 0x48, 0x4f, 0x10, 0x01, 0x12, 0x14,
+	0x0a, 0x10, 0x45, 0x43, 0x48, 0x4f, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41,
+	0x54, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x03, 0x12,
+	0x15, 0x0a, 0x11, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49,
+	0x43, 0x41, 0x54, 0x45, 0x10, 0x04, 0x22, 0x4e, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69,
+	0x63, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xa7, 0x05, 0x0a, 0x18, 0x42, 0x72, 0x6f, 0x61, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x49, 0x0a, 0x11, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x11, 0x62, 0x72, 0x6f,
+	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x57,
+	0x0a, 0x15, 0x62, 0x72, 0x61, 0x63, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x63, 0x68, 0x61, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x15, 0x62, 0x72, 0x61, 0x63, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x63, 0x0a, 0x19, 0x63, 0x6f, 0x6e, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x19, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x5d, 0x0a, 0x17,
+	0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x17, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x5d, 0x0a, 0x17, 0x72,
+	0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x17, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x5d, 0x0a, 0x17, 0x73, 0x63,
+	0x61, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x17, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x5a, 0x0a, 0x16, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x16, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0xf1, 0x05, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x30, 0x0a, 0x13, 0x72, 0x65,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x72, 0x65, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2d, 0x0a, 0x07,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x08, 0x73,
+	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74,
+	0x65, 0x48, 0x00, 0x52, 0x08, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x60, 0x0a,
+	0x18, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x18, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x21, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03, 0x61,
+	0x63, 0x6b, 0x12, 0x33, 0x0a, 0x09, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x62, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x11, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x11, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68,
+	0x69, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x48, 0x00,
+	0x52, 0x0a, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x30, 0x0a, 0x08,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x48, 0x00, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x30,
+	0x0a, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x68, 0x75, 0x74,
+	0x64, 0x6f, 0x77, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e,
+	0x12, 0x3f, 0x0a, 0x0d, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x48, 0x00, 0x52, 0x0d, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x36, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x42, 0x2e, 0x5a, 0x2c, 0x73, 0x74, 0x6f, 0x63, 0x68, 0x61, 0x73, 0x74,
+	0x69, 0x63, 0x2d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x2d, 0x73, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x69, 0x6d, 0x70, 0x6c, 0x2f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1228,50 +2510,84 @@ func file_messages_proto_rawDescGZIP() []byte {
 	return file_messages_proto_rawDescData
 }
 
-var file_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_messages_proto_goTypes = []interface{}{
-	(BrachaProtocolMessage_Stage)(0),     // 0: messages.BrachaProtocolMessage.Stage
-	(ConsistentProtocolMessage_Stage)(0), // 1: messages.ConsistentProtocolMessage.Stage
-	(ReliableProtocolMessage_Stage)(0),   // 2: messages.ReliableProtocolMessage.Stage
-	(RecoveryProtocolMessage_Stage)(0),   // 3: messages.RecoveryProtocolMessage.Stage
-	(ScalableProtocolMessage_Stage)(0),   // 4: messages.ScalableProtocolMessage.Stage
-	(*Started)(nil),                      // 5: messages.Started
-	(*Simulate)(nil),                     // 6: messages.Simulate
-	(*Broadcast)(nil),                    // 7: messages.Broadcast
-	(*Ack)(nil),                          // 8: messages.Ack
-	(*BroadcastInstance)(nil),            // 9: messages.BroadcastInstance
-	(*BrachaProtocolMessage)(nil),        // 10: messages.BrachaProtocolMessage
-	(*ConsistentProtocolMessage)(nil),    // 11: messages.ConsistentProtocolMessage
-	(*ReliableProtocolMessage)(nil),      // 12: messages.ReliableProtocolMessage
-	(*RecoveryProtocolMessage)(nil),      // 13: messages.RecoveryProtocolMessage
-	(*ScalableProtocolMessage)(nil),      // 14: messages.ScalableProtocolMessage
-	(*BroadcastInstanceMessage)(nil),     // 15: messages.BroadcastInstanceMessage
-	(*Message)(nil),                      // 16: messages.Message
+	(BrachaProtocolMessage_Stage)(0),        // 0: messages.BrachaProtocolMessage.Stage
+	(ConsistentProtocolMessage_Stage)(0),    // 1: messages.ConsistentProtocolMessage.Stage
+	(ReliableProtocolMessage_Stage)(0),      // 2: messages.ReliableProtocolMessage.Stage
+	(RecoveryProtocolMessage_Stage)(0),      // 3: messages.RecoveryProtocolMessage.Stage
+	(ScalableProtocolMessage_Stage)(0),      // 4: messages.ScalableProtocolMessage.Stage
+	(ImbsRaynalProtocolMessage_Stage)(0),    // 5: messages.ImbsRaynalProtocolMessage.Stage
+	(GossipProtocolMessage_Stage)(0),        // 6: messages.GossipProtocolMessage.Stage
+	(SnowballProtocolMessage_Stage)(0),      // 7: messages.SnowballProtocolMessage.Stage
+	(AuthenticatedProtocolMessage_Stage)(0), // 8: messages.AuthenticatedProtocolMessage.Stage
+	(*Started)(nil),                         // 9: messages.Started
+	(*Simulate)(nil),                        // 10: messages.Simulate
+	(*Membership)(nil),                      // 11: messages.Membership
+	(*MembershipRequest)(nil),               // 12: messages.MembershipRequest
+	(*Finished)(nil),                        // 13: messages.Finished
+	(*Shutdown)(nil),                        // 14: messages.Shutdown
+	(*HistoryReport)(nil),                   // 15: messages.HistoryReport
+	(*Checkpoint)(nil),                      // 16: messages.Checkpoint
+	(*Broadcast)(nil),                       // 17: messages.Broadcast
+	(*Ack)(nil),                             // 18: messages.Ack
+	(*BroadcastInstance)(nil),               // 19: messages.BroadcastInstance
+	(*BrachaProtocolMessage)(nil),           // 20: messages.BrachaProtocolMessage
+	(*ConsistentProtocolMessage)(nil),       // 21: messages.ConsistentProtocolMessage
+	(*ReliableProtocolMessage)(nil),         // 22: messages.ReliableProtocolMessage
+	(*RecoveryProtocolMessage)(nil),         // 23: messages.RecoveryProtocolMessage
+	(*ScalableProtocolMessage)(nil),         // 24: messages.ScalableProtocolMessage
+	(*ImbsRaynalProtocolMessage)(nil),       // 25: messages.ImbsRaynalProtocolMessage
+	(*GossipTransaction)(nil),               // 26: messages.GossipTransaction
+	(*GossipProtocolMessage)(nil),           // 27: messages.GossipProtocolMessage
+	(*SnowballProtocolMessage)(nil),         // 28: messages.SnowballProtocolMessage
+	(*Signature)(nil),                       // 29: messages.Signature
+	(*AuthenticatedProtocolMessage)(nil),    // 30: messages.AuthenticatedProtocolMessage
+	(*GenericProtocolMessage)(nil),          // 31: messages.GenericProtocolMessage
+	(*BroadcastInstanceMessage)(nil),        // 32: messages.BroadcastInstanceMessage
+	(*Message)(nil),                         // 33: messages.Message
 }
 var file_messages_proto_depIdxs = []int32{
-	0,  // 0: messages.BrachaProtocolMessage.stage:type_name -> messages.BrachaProtocolMessage.Stage
-	1,  // 1: messages.ConsistentProtocolMessage.stage:type_name -> messages.ConsistentProtocolMessage.Stage
-	2,  // 2: messages.ReliableProtocolMessage.stage:type_name -> messages.ReliableProtocolMessage.Stage
-	3,  // 3: messages.RecoveryProtocolMessage.stage:type_name -> messages.RecoveryProtocolMessage.Stage
-	12, // 4: messages.RecoveryProtocolMessage.reliableProtocolMessage:type_name -> messages.ReliableProtocolMessage
-	4,  // 5: messages.ScalableProtocolMessage.stage:type_name -> messages.ScalableProtocolMessage.Stage
-	9,  // 6: messages.BroadcastInstanceMessage.broadcastInstance:type_name -> messages.BroadcastInstance
-	10, // 7: messages.BroadcastInstanceMessage.brachaProtocolMessage:type_name -> messages.BrachaProtocolMessage
-	11, // 8: messages.BroadcastInstanceMessage.consistentProtocolMessage:type_name -> messages.ConsistentProtocolMessage
-	12, // 9: messages.BroadcastInstanceMessage.reliableProtocolMessage:type_name -> messages.ReliableProtocolMessage
-	13, // 10: messages.BroadcastInstanceMessage.recoveryProtocolMessage:type_name -> messages.RecoveryProtocolMessage
-	14, // 11: messages.BroadcastInstanceMessage.scalableProtocolMessage:type_name -> messages.ScalableProtocolMessage
-	5,  // 12: messages.Message.started:type_name -> messages.Started
-	6,  // 13: messages.Message.simulate:type_name -> messages.Simulate
-	15, // 14: messages.Message.broadcastInstanceMessage:type_name -> messages.BroadcastInstanceMessage
-	8,  // 15: messages.Message.ack:type_name -> messages.Ack
-	7,  // 16: messages.Message.broadcast:type_name -> messages.Broadcast
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	11, // 0: messages.Simulate.membership:type_name -> messages.Membership
+	0,  // 1: messages.BrachaProtocolMessage.stage:type_name -> messages.BrachaProtocolMessage.Stage
+	1,  // 2: messages.ConsistentProtocolMessage.stage:type_name -> messages.ConsistentProtocolMessage.Stage
+	2,  // 3: messages.ReliableProtocolMessage.stage:type_name -> messages.ReliableProtocolMessage.Stage
+	3,  // 4: messages.RecoveryProtocolMessage.stage:type_name -> messages.RecoveryProtocolMessage.Stage
+	22, // 5: messages.RecoveryProtocolMessage.reliableProtocolMessage:type_name -> messages.ReliableProtocolMessage
+	4,  // 6: messages.ScalableProtocolMessage.stage:type_name -> messages.ScalableProtocolMessage.Stage
+	5,  // 7: messages.ImbsRaynalProtocolMessage.stage:type_name -> messages.ImbsRaynalProtocolMessage.Stage
+	19, // 8: messages.GossipTransaction.broadcastInstance:type_name -> messages.BroadcastInstance
+	6,  // 9: messages.GossipProtocolMessage.stage:type_name -> messages.GossipProtocolMessage.Stage
+	26, // 10: messages.GossipProtocolMessage.transactions:type_name -> messages.GossipTransaction
+	19, // 11: messages.GossipProtocolMessage.held:type_name -> messages.BroadcastInstance
+	7,  // 12: messages.SnowballProtocolMessage.stage:type_name -> messages.SnowballProtocolMessage.Stage
+	8,  // 13: messages.AuthenticatedProtocolMessage.stage:type_name -> messages.AuthenticatedProtocolMessage.Stage
+	29, // 14: messages.AuthenticatedProtocolMessage.signature:type_name -> messages.Signature
+	29, // 15: messages.AuthenticatedProtocolMessage.certificate:type_name -> messages.Signature
+	19, // 16: messages.BroadcastInstanceMessage.broadcastInstance:type_name -> messages.BroadcastInstance
+	20, // 17: messages.BroadcastInstanceMessage.brachaProtocolMessage:type_name -> messages.BrachaProtocolMessage
+	21, // 18: messages.BroadcastInstanceMessage.consistentProtocolMessage:type_name -> messages.ConsistentProtocolMessage
+	22, // 19: messages.BroadcastInstanceMessage.reliableProtocolMessage:type_name -> messages.ReliableProtocolMessage
+	23, // 20: messages.BroadcastInstanceMessage.recoveryProtocolMessage:type_name -> messages.RecoveryProtocolMessage
+	24, // 21: messages.BroadcastInstanceMessage.scalableProtocolMessage:type_name -> messages.ScalableProtocolMessage
+	31, // 22: messages.BroadcastInstanceMessage.genericProtocolMessage:type_name -> messages.GenericProtocolMessage
+	9,  // 23: messages.Message.started:type_name -> messages.Started
+	10, // 24: messages.Message.simulate:type_name -> messages.Simulate
+	32, // 25: messages.Message.broadcastInstanceMessage:type_name -> messages.BroadcastInstanceMessage
+	18, // 26: messages.Message.ack:type_name -> messages.Ack
+	17, // 27: messages.Message.broadcast:type_name -> messages.Broadcast
+	12, // 28: messages.Message.membershipRequest:type_name -> messages.MembershipRequest
+	11, // 29: messages.Message.membership:type_name -> messages.Membership
+	13, // 30: messages.Message.finished:type_name -> messages.Finished
+	14, // 31: messages.Message.shutdown:type_name -> messages.Shutdown
+	15, // 32: messages.Message.historyReport:type_name -> messages.HistoryReport
+	16, // 33: messages.Message.checkpoint:type_name -> messages.Checkpoint
+	34, // [34:34] is the sub-list for method output_type
+	34, // [34:34] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_messages_proto_init() }
@@ -1305,7 +2621,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Broadcast); i {
+			switch v := v.(*Membership); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1317,7 +2633,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Ack); i {
+			switch v := v.(*MembershipRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1329,7 +2645,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BroadcastInstance); i {
+			switch v := v.(*Finished); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1341,7 +2657,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BrachaProtocolMessage); i {
+			switch v := v.(*Shutdown); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1353,7 +2669,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConsistentProtocolMessage); i {
+			switch v := v.(*HistoryReport); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1365,7 +2681,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReliableProtocolMessage); i {
+			switch v := v.(*Checkpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1377,7 +2693,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RecoveryProtocolMessage); i {
+			switch v := v.(*Broadcast); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1389,7 +2705,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScalableProtocolMessage); i {
+			switch v := v.(*Ack); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1401,7 +2717,7 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BroadcastInstanceMessage); i {
+			switch v := v.(*BroadcastInstance); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1413,6 +2729,162 @@ func file_messages_proto_init() {
 			}
 		}
 		file_messages_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BrachaProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsistentProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReliableProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecoveryProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScalableProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImbsRaynalProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GossipTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GossipProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnowballProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticatedProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenericProtocolMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastInstanceMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messages_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Message); i {
 			case 0:
 				return &v.state
@@ -1425,27 +2897,34 @@ func file_messages_proto_init() {
 			}
 		}
 	}
-	file_messages_proto_msgTypes[10].OneofWrappers = []interface{}{
+	file_messages_proto_msgTypes[23].OneofWrappers = []interface{}{
 		(*BroadcastInstanceMessage_BrachaProtocolMessage)(nil),
 		(*BroadcastInstanceMessage_ConsistentProtocolMessage)(nil),
 		(*BroadcastInstanceMessage_ReliableProtocolMessage)(nil),
 		(*BroadcastInstanceMessage_RecoveryProtocolMessage)(nil),
 		(*BroadcastInstanceMessage_ScalableProtocolMessage)(nil),
+		(*BroadcastInstanceMessage_GenericProtocolMessage)(nil),
 	}
-	file_messages_proto_msgTypes[11].OneofWrappers = []interface{}{
+	file_messages_proto_msgTypes[24].OneofWrappers = []interface{}{
 		(*Message_Started)(nil),
 		(*Message_Simulate)(nil),
 		(*Message_BroadcastInstanceMessage)(nil),
 		(*Message_Ack)(nil),
 		(*Message_Broadcast)(nil),
+		(*Message_MembershipRequest)(nil),
+		(*Message_Membership)(nil),
+		(*Message_Finished)(nil),
+		(*Message_Shutdown)(nil),
+		(*Message_HistoryReport)(nil),
+		(*Message_Checkpoint)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_messages_proto_rawDesc,
-			NumEnums:      5,
-			NumMessages:   12,
+			NumEnums:      9,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,55 @@
+package parameters
+
+import "encoding/json"
+
+// FlatParameters is the format used before protocols declared their own parameters:
+// parameters of all protocols are listed in a single object next to n and f.
+// It is kept so that existing input files keep loading.
+type FlatParameters struct {
+	// Broadcast
+	ProcessCount    int `json:"n"`
+	FaultyProcesses int `json:"f"`
+
+	// Accountability
+	MinOwnWitnessSetSize int `json:"w"`
+	MinPotWitnessSetSize int `json:"v"`
+
+	OwnWitnessSetRadius float64 `json:"wr"`
+	PotWitnessSetRadius float64 `json:"vr"`
+
+	WitnessThreshold        int `json:"u"`
+	RecoverySwitchTimeoutNs int `json:"recovery_timeout"`
+	NodeIdSize              int `json:"node_id_size"`
+	NumberOfBins            int `json:"number_of_bins"`
+
+	// Scalable reliable broadcast
+	GossipSampleSize int `json:"g_size"`
+
+	EchoSampleSize int `json:"e_size"`
+	EchoThreshold  int `json:"e_threshold"`
+
+	ReadySampleSize int `json:"r_size"`
+	ReadyThreshold  int `json:"r_threshold"`
+
+	DeliverySampleSize int `json:"d_size"`
+	DeliveryThreshold  int `json:"d_threshold"`
+
+	CleanUpTimeout int `json:"clean_up_timeout"`
+}
+
+// parseFlatParameters fills the given parameters from an object in the flat format.
+// Protocols declare their parameters with the same json names as the flat format used,
+// so the fields belonging to other protocols are simply skipped.
+func parseFlatParameters(data []byte, params *Parameters) error {
+	e := decodeStrict(data, &FlatParameters{})
+	if e != nil {
+		return e
+	}
+
+	e = json.Unmarshal(data, params)
+	if e != nil {
+		return e
+	}
+
+	return json.Unmarshal(data, params.Protocol)
+}
@@ -0,0 +1,119 @@
+package parameters
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Input represents the content of an input file.
+type Input struct {
+	Protocol   string
+	Parameters *Parameters
+	// Scenario is nil if the input file does not describe faults to inject
+	Scenario *Scenario
+}
+
+type rawInput struct {
+	Protocol   string          `json:"protocol"`
+	Parameters json.RawMessage `json:"parameters"`
+	Scenario   json.RawMessage `json:"scenario"`
+}
+
+// ReadInput reads and parses the input file at the given path.
+func ReadInput(path string) (*Input, error) {
+	data, e := os.ReadFile(path)
+	if e != nil {
+		return nil, fmt.Errorf("can't read from file %s: %w", path, e)
+	}
+	return ParseInput(data)
+}
+
+// ParseInput parses an input file in json format.
+// Parameters specific to the protocol are nested under a key named after the protocol:
+//
+//	{"protocol": "bracha", "parameters": {"n": 4, "f": 1, "bracha": {}}}
+//
+// Parameters which are not listed take the default values declared by the protocol.
+// Input files in the flat format, where parameters of all protocols are listed next to n and f,
+// are loaded as well.
+func ParseInput(data []byte) (*Input, error) {
+	raw := &rawInput{}
+	e := decodeStrict(data, raw)
+	if e != nil {
+		return nil, fmt.Errorf("could not parse json from the input file: %w", e)
+	}
+
+	if raw.Protocol == "" {
+		return nil, errors.New("parameter protocol is mandatory")
+	}
+
+	newParameters, registered := registeredProtocols[raw.Protocol]
+	if !registered {
+		return nil, fmt.Errorf("invalid protocol: %s, available protocols: %v", raw.Protocol, Protocols())
+	}
+
+	if len(raw.Parameters) == 0 {
+		raw.Parameters = []byte("{}")
+	}
+
+	fields := make(map[string]json.RawMessage)
+	e = json.Unmarshal(raw.Parameters, &fields)
+	if e != nil {
+		return nil, fmt.Errorf("could not parse parameters: %w", e)
+	}
+
+	params := &Parameters{Protocol: newParameters()}
+
+	protocolFields, nested := fields[raw.Protocol]
+	if nested {
+		delete(fields, raw.Protocol)
+		e = parseNestedParameters(fields, protocolFields, params)
+	} else {
+		e = parseFlatParameters(raw.Parameters, params)
+	}
+	if e != nil {
+		return nil, fmt.Errorf("could not parse parameters of protocol %s: %w", raw.Protocol, e)
+	}
+
+	var scenario *Scenario
+	if len(raw.Scenario) != 0 {
+		scenario = &Scenario{}
+		e = decodeStrict(raw.Scenario, scenario)
+		if e != nil {
+			return nil, fmt.Errorf("could not parse the scenario: %w", e)
+		}
+	}
+
+	return &Input{
+		Protocol:   raw.Protocol,
+		Parameters: params,
+		Scenario:   scenario,
+	}, nil
+}
+
+func parseNestedParameters(
+	generalFields map[string]json.RawMessage,
+	protocolFields json.RawMessage,
+	params *Parameters,
+) error {
+	general, e := json.Marshal(generalFields)
+	if e != nil {
+		return e
+	}
+
+	e = decodeStrict(general, params)
+	if e != nil {
+		return e
+	}
+
+	return decodeStrict(protocolFields, params.Protocol)
+}
+
+func decodeStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,113 @@
+package parameters
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testProtocol = "test_protocol"
+
+type testParameters struct {
+	SampleSize int     `json:"e_size"`
+	Radius     float64 `json:"wr"`
+}
+
+func (tp *testParameters) Validate(p *Parameters, v *Validator) {
+	if tp.SampleSize > p.ProcessCount {
+		v.Errorf("e_size must not be greater than n")
+	}
+}
+
+func (tp *testParameters) Thresholds(*Parameters) []Threshold {
+	return []Threshold{{Name: "sampleSize", Value: tp.SampleSize}}
+}
+
+func init() {
+	Register(testProtocol, func() ProtocolParameters {
+		return &testParameters{SampleSize: 3, Radius: 1.5}
+	})
+}
+
+func TestParseInput_nestedFormat(t *testing.T) {
+	data := []byte(`{
+		"protocol": "test_protocol",
+		"parameters": {"n": 4, "f": 1, "test_protocol": {"e_size": 2}}
+	}`)
+
+	input, e := ParseInput(data)
+
+	assert.Nil(t, e)
+	assert.Equal(t, testProtocol, input.Protocol)
+	assert.Equal(t, 4, input.Parameters.ProcessCount)
+	assert.Equal(t, 1, input.Parameters.FaultyProcesses)
+	assert.Exactly(t, &testParameters{SampleSize: 2, Radius: 1.5}, input.Parameters.Protocol)
+}
+
+func TestParseInput_flatFormat(t *testing.T) {
+	data := []byte(`{
+		"protocol": "test_protocol",
+		"parameters": {"n": 4, "f": 1, "e_size": 2, "w": 3, "d_threshold": 1}
+	}`)
+
+	input, e := ParseInput(data)
+
+	assert.Nil(t, e)
+	assert.Equal(t, 4, input.Parameters.ProcessCount)
+	assert.Exactly(t, &testParameters{SampleSize: 2, Radius: 1.5}, input.Parameters.Protocol)
+}
+
+func TestParseInput_unknownFieldInFlatFormat(t *testing.T) {
+	data := []byte(`{"protocol": "test_protocol", "parameters": {"n": 4, "unknown": 1}}`)
+
+	_, e := ParseInput(data)
+
+	assert.ErrorContains(t, e, "unknown field \"unknown\"")
+}
+
+func TestParseInput_unknownFieldInProtocolSection(t *testing.T) {
+	data := []byte(`{"protocol": "test_protocol", "parameters": {"n": 4, "test_protocol": {"w": 1}}}`)
+
+	_, e := ParseInput(data)
+
+	assert.ErrorContains(t, e, "unknown field \"w\"")
+}
+
+func TestParseInput_unknownTopLevelField(t *testing.T) {
+	data := []byte(`{"protocol": "test_protocol", "params": {}}`)
+
+	_, e := ParseInput(data)
+
+	assert.ErrorContains(t, e, "unknown field \"params\"")
+}
+
+func TestParseInput_unknownProtocol(t *testing.T) {
+	data := []byte(`{"protocol": "unknown", "parameters": {"n": 4}}`)
+
+	_, e := ParseInput(data)
+
+	assert.ErrorContains(t, e, "invalid protocol: unknown")
+}
+
+func TestParseInput_missingProtocol(t *testing.T) {
+	data := []byte(`{"parameters": {"n": 4}}`)
+
+	_, e := ParseInput(data)
+
+	assert.ErrorContains(t, e, "parameter protocol is mandatory")
+}
+
+func TestValidate_protocolParametersValidated(t *testing.T) {
+	p := &Parameters{ProcessCount: 1, Protocol: &testParameters{SampleSize: 2}}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "e_size must not be greater than n")
+}
+
+func TestValidate_negativeFaultyProcesses(t *testing.T) {
+	p := &Parameters{ProcessCount: 4, FaultyProcesses: -1, Protocol: &testParameters{}}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "f must not be negative")
+}
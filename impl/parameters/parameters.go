@@ -1,33 +1,21 @@
 package parameters
 
+// Parameters holds the parameters shared by all protocols
+// together with the parameters specific to the selected protocol.
 type Parameters struct {
 	// Broadcast
 	ProcessCount    int `json:"n"`
 	FaultyProcesses int `json:"f"`
 
-	// Accountability
-	MinOwnWitnessSetSize int `json:"w"`
-	MinPotWitnessSetSize int `json:"v"`
-
-	OwnWitnessSetRadius float64 `json:"wr"`
-	PotWitnessSetRadius float64 `json:"vr"`
-
-	WitnessThreshold        int `json:"u"`
-	RecoverySwitchTimeoutNs int `json:"recovery_timeout"`
-	NodeIdSize              int `json:"node_id_size"`
-	NumberOfBins            int `json:"number_of_bins"`
-
-	// Scalable reliable broadcast
-	GossipSampleSize int `json:"g_size"`
-
-	EchoSampleSize int `json:"e_size"`
-	EchoThreshold  int `json:"e_threshold"`
-
-	ReadySampleSize int `json:"r_size"`
-	ReadyThreshold  int `json:"r_threshold"`
-
-	DeliverySampleSize int `json:"d_size"`
-	DeliveryThreshold  int `json:"d_threshold"`
+	// Protocol holds the parameters declared by the selected protocol.
+	Protocol ProtocolParameters `json:"-"`
+}
 
-	CleanUpTimeout int `json:"clean_up_timeout"`
+// ProtocolParameters interface represents the parameters declared by a protocol.
+// It exports two methods:
+// Validate reports the parameter combinations the protocol cannot run with to the given validator;
+// Thresholds returns the values the protocol derives from the parameters.
+type ProtocolParameters interface {
+	Validate(parameters *Parameters, v *Validator)
+	Thresholds(parameters *Parameters) []Threshold
 }
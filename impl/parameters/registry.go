@@ -0,0 +1,28 @@
+package parameters
+
+import (
+	"fmt"
+	"sort"
+)
+
+var registeredProtocols = make(map[string]func() ProtocolParameters)
+
+// Register makes the parameters of the given protocol known to ParseInput.
+// newParameters must return a new instance of the parameters filled with their default values.
+// It is meant to be called from init functions of protocol packages.
+func Register(protocol string, newParameters func() ProtocolParameters) {
+	if _, registered := registeredProtocols[protocol]; registered {
+		panic(fmt.Sprintf("parameters of protocol %s are already registered", protocol))
+	}
+	registeredProtocols[protocol] = newParameters
+}
+
+// Protocols returns names of all protocols with registered parameters in sorted order.
+func Protocols() []string {
+	protocols := make([]string, 0, len(registeredProtocols))
+	for protocol := range registeredProtocols {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+	return protocols
+}
@@ -0,0 +1,158 @@
+package parameters
+
+// Crash describes when a process crashes. A crashed process stops sending and receiving messages
+// and never recovers. Exactly one of the triggers must be set:
+// AtNs - time since the start of the simulation (ns) at which the process crashes;
+// AfterDeliveries - the process crashes right after delivering the given number of transactions;
+// AfterMessagesSent - the process crashes right after sending the given number of messages.
+type Crash struct {
+	Process           int   `json:"process"`
+	AtNs              int64 `json:"at_ns"`
+	AfterDeliveries   int   `json:"after_deliveries"`
+	AfterMessagesSent int   `json:"after_messages_sent"`
+}
+
+// MembershipChange describes a process requesting to join or leave the system
+// at the given time since the start of the simulation (ns).
+type MembershipChange struct {
+	Process int   `json:"process"`
+	AtNs    int64 `json:"at_ns"`
+	Leave   bool  `json:"leave"`
+}
+
+// Scenario describes the faults and membership changes injected into the simulation.
+// InitialMembers lists the processes which are members of the system when the simulation starts,
+// all n processes are members if it is empty.
+type Scenario struct {
+	Crashes []Crash `json:"crashes"`
+
+	InitialMembers    []int              `json:"initial_members"`
+	MembershipChanges []MembershipChange `json:"membership_changes"`
+}
+
+// ChangesMembership returns whether the scenario requires processes to support reconfiguration.
+func (s *Scenario) ChangesMembership() bool {
+	return s != nil && (len(s.InitialMembers) != 0 || len(s.MembershipChanges) != 0)
+}
+
+// CrashesProcesses returns whether any process crashes during the simulation.
+func (s *Scenario) CrashesProcesses() bool {
+	return s != nil && len(s.Crashes) != 0
+}
+
+// IsInitialMember returns whether the given process is a member of the system when the simulation starts.
+func (s *Scenario) IsInitialMember(processIndex int) bool {
+	if s == nil || len(s.InitialMembers) == 0 {
+		return true
+	}
+	for _, member := range s.InitialMembers {
+		if member == processIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// MembershipChangesOf returns the membership changes requested by the given process.
+func (s *Scenario) MembershipChangesOf(processIndex int) []MembershipChange {
+	if s == nil {
+		return nil
+	}
+	var changes []MembershipChange
+	for _, change := range s.MembershipChanges {
+		if change.Process == processIndex {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// CrashOf returns the crash scheduled for the given process, or nil if the process does not crash.
+func (s *Scenario) CrashOf(processIndex int) *Crash {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Crashes {
+		if s.Crashes[i].Process == processIndex {
+			return &s.Crashes[i]
+		}
+	}
+	return nil
+}
+
+// Finishes returns whether the given process is expected to deliver the transactions of all processes which finish,
+// and to report to the main server that it has finished, i.e. whether it is a member of every membership epoch
+// and is not scheduled to crash. Transactions of processes which crash might not be delivered, and processes
+// which are not members miss the messages of the epoch, so neither their transactions nor their deliveries are counted.
+func (s *Scenario) Finishes(processIndex int) bool {
+	return s.CrashOf(processIndex) == nil && s.IsInitialMember(processIndex) &&
+		len(s.MembershipChangesOf(processIndex)) == 0
+}
+
+// Validate reports crashes the runtime cannot enforce to the given validator.
+func (s *Scenario) Validate(p *Parameters, v *Validator) {
+	if s == nil {
+		return
+	}
+
+	crashed := make(map[int]bool)
+	for _, crash := range s.Crashes {
+		if crash.Process < 0 || crash.Process >= p.ProcessCount {
+			v.Errorf("crashed process %d must be in [0, n), got n = %d", crash.Process, p.ProcessCount)
+		}
+		if crashed[crash.Process] {
+			v.Errorf("process %d is crashed more than once", crash.Process)
+		}
+		crashed[crash.Process] = true
+
+		if crash.AtNs < 0 || crash.AfterDeliveries < 0 || crash.AfterMessagesSent < 0 {
+			v.Errorf("crash triggers of process %d must not be negative", crash.Process)
+		}
+		triggers := 0
+		for _, set := range []bool{crash.AtNs > 0, crash.AfterDeliveries > 0, crash.AfterMessagesSent > 0} {
+			if set {
+				triggers++
+			}
+		}
+		if triggers != 1 {
+			v.Errorf("exactly one of at_ns, after_deliveries and after_messages_sent must be set "+
+				"for the crash of process %d", crash.Process)
+		}
+	}
+
+	initialMembers := make(map[int]bool)
+	for _, member := range s.InitialMembers {
+		if member < 0 || member >= p.ProcessCount {
+			v.Errorf("initial member %d must be in [0, n), got n = %d", member, p.ProcessCount)
+		}
+		if initialMembers[member] {
+			v.Errorf("initial member %d is listed more than once", member)
+		}
+		initialMembers[member] = true
+	}
+	for _, change := range s.MembershipChanges {
+		if change.Process < 0 || change.Process >= p.ProcessCount {
+			v.Errorf("process %d changing the membership must be in [0, n), got n = %d",
+				change.Process, p.ProcessCount)
+		}
+		if change.AtNs < 0 {
+			v.Errorf("time of the membership change of process %d must not be negative", change.Process)
+		}
+	}
+	if len(s.InitialMembers) != 0 && len(s.InitialMembers) <= 3*p.FaultyProcesses {
+		v.Warnf("%d initial members do not tolerate f = %d faulty processes", len(s.InitialMembers), p.FaultyProcesses)
+	}
+
+	finishing := false
+	for i := 0; i < p.ProcessCount; i++ {
+		finishing = finishing || s.Finishes(i)
+	}
+	if !finishing {
+		v.Warnf("every process crashes or changes the membership, so the run does not finish before the deadline")
+	}
+
+	if len(crashed) > p.FaultyProcesses {
+		v.Warnf("%d processes crash, which is more than f = %d, guarantees of the protocol do not hold",
+			len(crashed), p.FaultyProcesses)
+	}
+}
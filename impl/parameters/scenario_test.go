@@ -0,0 +1,174 @@
+package parameters
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseInput_scenario(t *testing.T) {
+	data := []byte(`{
+		"protocol": "test_protocol",
+		"parameters": {"n": 4, "f": 1},
+		"scenario": {"crashes": [{"process": 2, "after_deliveries": 3}]}
+	}`)
+
+	input, e := ParseInput(data)
+
+	assert.Nil(t, e)
+	assert.Equal(t, &Crash{Process: 2, AfterDeliveries: 3}, input.Scenario.CrashOf(2))
+	assert.Nil(t, input.Scenario.CrashOf(1))
+}
+
+func TestParseInput_withoutScenario(t *testing.T) {
+	data := []byte(`{"protocol": "test_protocol", "parameters": {"n": 4, "f": 1}}`)
+
+	input, e := ParseInput(data)
+
+	assert.Nil(t, e)
+	assert.Nil(t, input.Scenario)
+	assert.Nil(t, input.Scenario.CrashOf(0))
+}
+
+func TestFinishes_crashedProcessesDoNotFinish(t *testing.T) {
+	scenario := &Scenario{Crashes: []Crash{{Process: 1, AtNs: 10}}}
+
+	assert.True(t, scenario.Finishes(0))
+	assert.False(t, scenario.Finishes(1))
+	assert.True(t, (*Scenario)(nil).Finishes(1))
+	assert.True(t, scenario.CrashesProcesses())
+	assert.False(t, (*Scenario)(nil).CrashesProcesses())
+}
+
+func TestFinishes_onlyMembersOfAllEpochsFinish(t *testing.T) {
+	scenario := &Scenario{
+		InitialMembers:    []int{0, 1, 2},
+		MembershipChanges: []MembershipChange{{Process: 3, AtNs: 10}, {Process: 2, AtNs: 20, Leave: true}},
+	}
+
+	assert.True(t, scenario.Finishes(0))
+	assert.True(t, scenario.Finishes(1))
+	assert.False(t, scenario.Finishes(2))
+	assert.False(t, scenario.Finishes(3))
+}
+
+func TestValidate_noProcessFinishesIsWarning(t *testing.T) {
+	warnings, e := validateScenario(1, Crash{Process: 0, AtNs: 10})
+	assert.Nil(t, e)
+	assert.Empty(t, warnings)
+
+	input := &Input{
+		Protocol:   testProtocol,
+		Parameters: &Parameters{ProcessCount: 2, FaultyProcesses: 0, Protocol: &testParameters{}},
+		Scenario: &Scenario{
+			Crashes:           []Crash{{Process: 0, AtNs: 10}},
+			MembershipChanges: []MembershipChange{{Process: 1, AtNs: 20, Leave: true}},
+		},
+	}
+	warnings, e = input.Validate()
+
+	assert.Nil(t, e)
+	assert.Contains(t, warnings, "every process crashes or changes the membership, so the run does not finish before the deadline")
+}
+
+func TestParseInput_unknownFieldInScenario(t *testing.T) {
+	data := []byte(`{"protocol": "test_protocol", "scenario": {"crashes": [{"process": 0, "at": 1}]}}`)
+
+	_, e := ParseInput(data)
+
+	assert.ErrorContains(t, e, "unknown field \"at\"")
+}
+
+func validateScenario(f int, crashes ...Crash) ([]string, error) {
+	input := &Input{
+		Protocol:   testProtocol,
+		Parameters: &Parameters{ProcessCount: 4, FaultyProcesses: f, Protocol: &testParameters{}},
+		Scenario:   &Scenario{Crashes: crashes},
+	}
+	return input.Validate()
+}
+
+func TestValidate_crashWithoutTrigger(t *testing.T) {
+	_, e := validateScenario(1, Crash{Process: 0})
+
+	assert.ErrorContains(t, e, "exactly one of at_ns, after_deliveries and after_messages_sent")
+}
+
+func TestValidate_crashWithSeveralTriggers(t *testing.T) {
+	_, e := validateScenario(1, Crash{Process: 0, AtNs: 10, AfterMessagesSent: 5})
+
+	assert.ErrorContains(t, e, "exactly one of at_ns, after_deliveries and after_messages_sent")
+}
+
+func TestValidate_crashedProcessOutOfRange(t *testing.T) {
+	_, e := validateScenario(1, Crash{Process: 4, AtNs: 10})
+
+	assert.ErrorContains(t, e, "crashed process 4 must be in [0, n)")
+}
+
+func TestValidate_processCrashedTwice(t *testing.T) {
+	_, e := validateScenario(2, Crash{Process: 1, AtNs: 10}, Crash{Process: 1, AfterDeliveries: 1})
+
+	assert.ErrorContains(t, e, "process 1 is crashed more than once")
+}
+
+func TestValidate_moreCrashesThanFaultyProcesses(t *testing.T) {
+	warnings, e := validateScenario(1, Crash{Process: 0, AtNs: 10}, Crash{Process: 1, AtNs: 10})
+
+	assert.Nil(t, e)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "more than f = 1")
+}
+
+func TestScenario_initialMembers(t *testing.T) {
+	scenario := &Scenario{InitialMembers: []int{0, 2}}
+
+	assert.True(t, scenario.ChangesMembership())
+	assert.True(t, scenario.IsInitialMember(2))
+	assert.False(t, scenario.IsInitialMember(1))
+}
+
+func TestScenario_allProcessesAreInitialMembersByDefault(t *testing.T) {
+	var scenario *Scenario
+
+	assert.False(t, scenario.ChangesMembership())
+	assert.True(t, scenario.IsInitialMember(1))
+	assert.True(t, (&Scenario{}).IsInitialMember(1))
+}
+
+func TestScenario_MembershipChangesOf(t *testing.T) {
+	scenario := &Scenario{MembershipChanges: []MembershipChange{
+		{Process: 1, AtNs: 10},
+		{Process: 2, AtNs: 20},
+		{Process: 1, AtNs: 30, Leave: true},
+	}}
+
+	assert.Equal(t,
+		[]MembershipChange{{Process: 1, AtNs: 10}, {Process: 1, AtNs: 30, Leave: true}},
+		scenario.MembershipChangesOf(1))
+}
+
+func TestValidate_initialMemberOutOfRange(t *testing.T) {
+	input := &Input{
+		Protocol:   testProtocol,
+		Parameters: &Parameters{ProcessCount: 4, FaultyProcesses: 0, Protocol: &testParameters{}},
+		Scenario:   &Scenario{InitialMembers: []int{0, 4}},
+	}
+
+	_, e := input.Validate()
+
+	assert.ErrorContains(t, e, "initial member 4 must be in [0, n)")
+}
+
+func TestValidate_tooFewInitialMembers(t *testing.T) {
+	input := &Input{
+		Protocol:   testProtocol,
+		Parameters: &Parameters{ProcessCount: 5, FaultyProcesses: 1, Protocol: &testParameters{}},
+		Scenario:   &Scenario{InitialMembers: []int{0, 1, 2}},
+	}
+
+	warnings, e := input.Validate()
+
+	assert.Nil(t, e)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "3 initial members do not tolerate f = 1")
+}
@@ -0,0 +1,94 @@
+package parameters
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MaxBitsPerBin is the largest bin size (in bits) a history hash bin can have
+// without overflowing the multi ring arithmetic.
+const MaxBitsPerBin = 62
+
+// Threshold is a named value derived from the input parameters.
+type Threshold struct {
+	Name  string
+	Value int
+}
+
+// QuorumThreshold returns the number of matching messages required to form a quorum
+// of processes in a system of n processes with at most f faulty ones.
+func QuorumThreshold(n int, f int) int {
+	return int(math.Ceil(float64(n+f+1) / float64(2)))
+}
+
+// ReadyMessagesThreshold returns the number of ready messages which guarantees
+// that at least one of them was sent by a correct process.
+func ReadyMessagesThreshold(f int) int {
+	return f + 1
+}
+
+// BinCapacity returns the capacity of a single bin of a history hash.
+func BinCapacity(nodeIdSize int, numberOfBins int) uint {
+	return uint(math.Pow(2, float64(nodeIdSize/numberOfBins)))
+}
+
+// Validate checks that the parameters are consistent with each other.
+// It returns a list of warnings about suspicious, but still runnable, combinations
+// and an error describing every combination the protocol cannot run with.
+func (p *Parameters) Validate() ([]string, error) {
+	v := &Validator{}
+
+	if p.ProcessCount <= 0 {
+		v.Errorf("n must be positive, got %d", p.ProcessCount)
+	}
+	if p.FaultyProcesses < 0 {
+		v.Errorf("f must not be negative, got %d", p.FaultyProcesses)
+	}
+
+	if p.Protocol == nil {
+		v.Errorf("parameters of the protocol are missing")
+	} else {
+		p.Protocol.Validate(p, v)
+	}
+
+	return v.warnings, errors.Join(v.errors...)
+}
+
+// Validate checks the parameters and the scenario of the input file.
+// It returns warnings and errors in the same way as Parameters.Validate.
+func (input *Input) Validate() ([]string, error) {
+	warnings, e := input.Parameters.Validate()
+
+	v := &Validator{warnings: warnings}
+	if e != nil {
+		v.errors = append(v.errors, e)
+	}
+	input.Scenario.Validate(input.Parameters, v)
+
+	return v.warnings, errors.Join(v.errors...)
+}
+
+// Thresholds returns the values the selected protocol derives from the parameters.
+func (p *Parameters) Thresholds() []Threshold {
+	if p.Protocol == nil {
+		return nil
+	}
+	return p.Protocol.Thresholds(p)
+}
+
+// Validator collects errors and warnings found while validating parameters.
+type Validator struct {
+	warnings []string
+	errors   []error
+}
+
+// Errorf reports a parameter combination the protocol cannot run with.
+func (v *Validator) Errorf(format string, args ...any) {
+	v.errors = append(v.errors, fmt.Errorf(format, args...))
+}
+
+// Warnf reports a suspicious parameter combination the protocol can still run with.
+func (v *Validator) Warnf(format string, args ...any) {
+	v.warnings = append(v.warnings, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,38 @@
+package consistent
+
+import (
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+)
+
+// Parameters of the consistent accountability protocol.
+type Parameters struct {
+	accountability.Parameters
+
+	// FallbackTimeoutNs is the time a process waits for a verified transaction to be delivered
+	// before switching to the recovery to reliable broadcast. The recovery is disabled if it is 0.
+	FallbackTimeoutNs int `json:"fallback_timeout"`
+}
+
+func (cp *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	cp.Parameters.Validate(p, v)
+
+	if cp.FallbackTimeoutNs < 0 {
+		v.Errorf("fallback_timeout must not be negative, got %d", cp.FallbackTimeoutNs)
+	}
+}
+
+func (cp *Parameters) Thresholds(p *parameters.Parameters) []parameters.Threshold {
+	thresholds := cp.Parameters.Thresholds(p)
+	if cp.FallbackTimeoutNs == 0 {
+		return thresholds
+	}
+
+	f := p.FaultyProcesses
+	return append(
+		thresholds,
+		parameters.Threshold{Name: "quorumThreshold", Value: parameters.QuorumThreshold(p.ProcessCount, f)},
+		parameters.Threshold{Name: "readyMessagesThreshold", Value: parameters.ReadyMessagesThreshold(f)},
+		parameters.Threshold{Name: "readyMessagesForDelivery", Value: 2*f + 1},
+	)
+}
@@ -0,0 +1,37 @@
+package consistent
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+	"testing"
+)
+
+func TestValidate_negativeFallbackTimeout(t *testing.T) {
+	p := &parameters.Parameters{
+		ProcessCount: 4,
+		Protocol: &Parameters{
+			Parameters:        accountability.DefaultParameters(),
+			FallbackTimeoutNs: -1,
+		},
+	}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "fallback_timeout must not be negative")
+}
+
+func TestThresholds_recoveryThresholdsOnlyIfEnabled(t *testing.T) {
+	protocolParams := &Parameters{Parameters: accountability.DefaultParameters()}
+	p := &parameters.Parameters{ProcessCount: 4, FaultyProcesses: 1, Protocol: protocolParams}
+
+	assert.Len(t, p.Thresholds(), 2)
+
+	protocolParams.FallbackTimeoutNs = 1000000
+
+	assert.Equal(t, []parameters.Threshold{
+		{Name: "quorumThreshold", Value: 3},
+		{Name: "readyMessagesThreshold", Value: 2},
+		{Name: "readyMessagesForDelivery", Value: 3},
+	}, p.Thresholds()[2:])
+}
@@ -2,25 +2,57 @@ package consistent
 
 import (
 	"fmt"
-	"math"
 	"stochastic-checking-simulation/context"
 	"stochastic-checking-simulation/impl/eventlogger"
 	"stochastic-checking-simulation/impl/hashing"
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+	"stochastic-checking-simulation/impl/signatures"
 	"stochastic-checking-simulation/impl/utils"
+	"stochastic-checking-simulation/impl/wal"
+	"sync"
+	"time"
 )
 
 type ProcessId int32
 
+const (
+	FastPath     = "fast"
+	RecoveryPath = "recovery"
+)
+
+// Stages persisted in the write-ahead log
+const (
+	verifiedStage      = "verified"
+	recoveryEchoStage  = "recovery_echo"
+	recoveryReadyStage = "recovery_ready"
+)
+
 type messageState struct {
 	receivedEcho map[ProcessId]bool
 	echoCount    map[int32]int
 	witnessSet   map[string]bool
 
+	// The value the process has verified
+	value int32
+	// Marshaled history hash witnesses are selected with, attached to the messages of the transaction
+	historyHash []byte
+	// Epoch of the checkpoint witnesses are selected with, attached to the messages of the transaction
+	historyEpoch int32
+	// Proof that the process is a witness of the transaction if witnesses select themselves, attached to its echoes
+	witnessProof []byte
+
 	receivedMessagesCnt int
 }
 
+// count counts the echo of the sender, which is a witness of the transaction.
+func (ms *messageState) count(sender ProcessId, value int32) {
+	ms.receivedEcho[sender] = true
+	ms.echoCount[value]++
+}
+
 func newMessageState() *messageState {
 	ms := new(messageState)
 
@@ -32,6 +64,72 @@ func newMessageState() *messageState {
 	return ms
 }
 
+type recoveryMessageState struct {
+	receivedEcho  map[ProcessId]bool
+	receivedReady map[ProcessId]bool
+	receivedReply map[ProcessId]bool
+
+	echoMessagesStat  map[int32]int
+	readyMessagesStat map[int32]int
+	replyMessagesStat map[int32]int
+
+	sentEcho  bool
+	sentReady bool
+
+	receivedMessagesCnt int
+}
+
+// count counts the recovery message of the sender, and returns false if a message of the same stage of the sender
+// was counted before. A reply also counts as the echo and the ready of the sender, which has delivered the value
+// and would have sent them if it had kept the recovery state.
+func (rs *recoveryMessageState) count(
+	sender ProcessId,
+	stage messages.ConsistentProtocolMessage_Stage,
+	value int32,
+) bool {
+	received, stat := rs.receivedEcho, rs.echoMessagesStat
+	switch stage {
+	case messages.ConsistentProtocolMessage_RECOVERY_READY:
+		received, stat = rs.receivedReady, rs.readyMessagesStat
+	case messages.ConsistentProtocolMessage_RECOVERY_REPLY:
+		received, stat = rs.receivedReply, rs.replyMessagesStat
+	}
+	if received[sender] {
+		return false
+	}
+	received[sender] = true
+	stat[value]++
+
+	if stage == messages.ConsistentProtocolMessage_RECOVERY_REPLY {
+		rs.count(sender, messages.ConsistentProtocolMessage_RECOVERY_ECHO, value)
+		rs.count(sender, messages.ConsistentProtocolMessage_RECOVERY_READY, value)
+	}
+	return true
+}
+
+func newRecoveryMessageState() *recoveryMessageState {
+	ms := new(recoveryMessageState)
+
+	ms.receivedEcho = make(map[ProcessId]bool)
+	ms.receivedReady = make(map[ProcessId]bool)
+	ms.receivedReply = make(map[ProcessId]bool)
+
+	ms.echoMessagesStat = make(map[int32]int)
+	ms.readyMessagesStat = make(map[int32]int)
+	ms.replyMessagesStat = make(map[int32]int)
+
+	ms.receivedMessagesCnt = 0
+
+	return ms
+}
+
+// Process executes the consistent accountability protocol.
+// If the fallback timeout is set, a process which has verified a transaction but did not deliver it
+// within the timeout switches to a Bracha-style recovery: it broadcasts a recovery echo with the verified value,
+// which makes other processes that verified or delivered the transaction join the recovery.
+// A value is delivered upon 2f+1 recovery ready messages, or upon f+1 replies from processes which have delivered it.
+// Processes drop the recovery state of a transaction once they deliver it, and from then on answer recovery echoes
+// with replies, which count as the echo and the ready the delivered process would have sent.
 type Process struct {
 	processIndex int32
 	actorPids    map[string]ProcessId
@@ -39,28 +137,46 @@ type Process struct {
 
 	transactionCounter int32
 
-	deliveredMessages map[ProcessId]map[int32]int32
-	messagesLog       map[ProcessId]map[int32]*messageState
+	deliveredMessages   map[ProcessId]map[int32]int32
+	messagesLog         map[ProcessId]map[int32]*messageState
+	recoveryMessagesLog map[ProcessId]map[int32]*recoveryMessageState
 
 	witnessThreshold int
 
-	wSelector   *hashing.WitnessesSelector
-	historyHash *hashing.HistoryHash
+	f          int
+	membership *protocols.Membership
+	// Addresses of the members of the current epoch, among which witnesses are selected
+	members []string
+
+	fallbackTimeout          time.Duration
+	quorumThreshold          int
+	readyMessagesThreshold   int
+	readyMessagesForDelivery int
+
+	mutex *sync.Mutex
+
+	wSelector          *hashing.WitnessesSelector
+	historyHash        *hashing.HistoryHash
+	selectionHistory   *accountability.SelectionHistory
+	checkpointInterval int
+	// Selects witnesses with a verifiable random function, nil if they are selected with wSelector
+	vrfSelector *accountability.VrfSelector
 
-	context                      *context.ReliableContext
-	logger                       *eventlogger.EventLogger
-	ownDeliveredTransactions     chan bool
-	sendOwnDeliveredTransactions bool
+	context         *context.ReliableContext
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
+
+	// Write-ahead log, set if the process is recoverable after a crash
+	wal *wal.Log
 }
 
 func (p *Process) InitProcess(
 	processIndex int32,
 	actorPids []string,
-	parameters *parameters.Parameters,
+	params *parameters.Parameters,
 	context *context.ReliableContext,
 	logger *eventlogger.EventLogger,
-	ownDeliveredTransactions chan bool,
-	sendOwnDeliveredTransactions bool,
+	deliveryHandler protocols.DeliveryHandler,
 ) {
 	p.processIndex = processIndex
 	p.pids = actorPids
@@ -70,58 +186,348 @@ func (p *Process) InitProcess(
 	p.actorPids = make(map[string]ProcessId)
 	p.deliveredMessages = make(map[ProcessId]map[int32]int32)
 	p.messagesLog = make(map[ProcessId]map[int32]*messageState)
+	p.recoveryMessagesLog = make(map[ProcessId]map[int32]*recoveryMessageState)
+
+	protocolParams := params.Protocol.(*Parameters)
+
+	p.witnessThreshold = protocolParams.WitnessThreshold
+
+	p.f = params.FaultyProcesses
+	p.fallbackTimeout = time.Duration(protocolParams.FallbackTimeoutNs)
+	p.reconfigure(protocols.FullMembership(len(actorPids)))
 
-	p.witnessThreshold = parameters.WitnessThreshold
+	p.mutex = &sync.Mutex{}
 
 	for i, pid := range actorPids {
 		p.actorPids[pid] = ProcessId(i)
 		p.deliveredMessages[ProcessId(i)] = make(map[int32]int32)
 		p.messagesLog[ProcessId(i)] = make(map[int32]*messageState)
+		p.recoveryMessagesLog[ProcessId(i)] = make(map[int32]*recoveryMessageState)
 	}
 
-	var hasher hashing.Hasher
-	if parameters.NodeIdSize == 256 {
-		hasher = hashing.HashSHA256{}
-	} else {
-		hasher = hashing.HashSHA512{}
-	}
+	hasher := protocolParams.Hasher()
 
 	p.wSelector = &hashing.WitnessesSelector{
 		Hasher:               hasher,
-		MinPotWitnessSetSize: parameters.MinPotWitnessSetSize,
-		MinOwnWitnessSetSize: parameters.MinOwnWitnessSetSize,
-		PotWitnessSetRadius:  parameters.PotWitnessSetRadius,
-		OwnWitnessSetRadius:  parameters.OwnWitnessSetRadius,
+		Metric:               protocolParams.DistanceMetric(),
+		MinPotWitnessSetSize: protocolParams.MinPotWitnessSetSize,
+		MinOwnWitnessSetSize: protocolParams.MinOwnWitnessSetSize,
+		PotWitnessSetRadius:  protocolParams.PotWitnessSetRadius,
+		OwnWitnessSetRadius:  protocolParams.OwnWitnessSetRadius,
 	}
-	binCapacity := uint(math.Pow(2, float64(parameters.NodeIdSize/parameters.NumberOfBins)))
-	p.historyHash = hashing.NewHistoryHash(uint(parameters.NumberOfBins), binCapacity, hasher)
+	p.historyHash = protocolParams.NewHistoryHash()
+	p.selectionHistory = accountability.NewSelectionHistory(&protocolParams.Parameters, p.historyHash, actorPids)
+	p.checkpointInterval = protocolParams.CheckpointInterval
+	p.vrfSelector = protocolParams.VrfSelector(processIndex, actorPids)
 
 	p.context = context
 	p.logger = logger
-	p.ownDeliveredTransactions = ownDeliveredTransactions
-	p.sendOwnDeliveredTransactions = sendOwnDeliveredTransactions
+	p.deliveryHandler = deliveryHandler
+}
+
+// Reconfigure makes the process select witnesses and send messages among the members of the new epoch,
+// and derive the recovery thresholds from their number. Witness sets of transactions
+// the process has already verified are not changed.
+func (p *Process) Reconfigure(membership *protocols.Membership) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.reconfigure(membership)
+}
+
+func (p *Process) reconfigure(membership *protocols.Membership) {
+	p.membership = membership
+	p.members = membership.Pids(p.pids)
+
+	p.quorumThreshold = parameters.QuorumThreshold(membership.Size(), p.f)
+	p.readyMessagesThreshold = parameters.ReadyMessagesThreshold(p.f)
+	p.readyMessagesForDelivery = 2*p.f + 1
+}
+
+// UseKeys makes the process prove that it is a witness with its secret key if witnesses select themselves.
+func (p *Process) UseKeys(keys *signatures.KeyRing) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.vrfSelector != nil {
+		p.vrfSelector.UseKeys(keys)
+	}
+}
+
+func (p *Process) CheckpointInterval() int {
+	return p.checkpointInterval
+}
+
+// Checkpoint makes the history hash of the checkpoint available for witness selection,
+// and handles the messages which waited for it.
+func (p *Process) Checkpoint(epoch int32, cut []int32) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, handle := range p.selectionHistory.Checkpoint(epoch, cut) {
+		handle()
+	}
+}
+
+// Recover restores delivered transactions, the history hash, the transaction counter and the verified values,
+// recovery stages and counted messages of undelivered transactions from the write-ahead log. Records are replayed
+// in the order they were appended, so witness sets of restored transactions are selected with the same history
+// as before the crash. Records are appended before the messages they describe are sent, so the process sends
+// the messages of its verified undelivered transactions and of the restored recovery stages again, which other
+// processes ignore if they have received them before the crash.
+func (p *Process) Recover(log *wal.Log) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var resent []wal.Record
+	for _, record := range log.Records() {
+		bInstance := record.BroadcastInstance()
+		author := ProcessId(bInstance.Author)
+
+		switch record.Type {
+		case wal.BroadcastRecord:
+			p.transactionCounter = bInstance.SeqNumber + 1
+			resent = append(resent, record)
+		case wal.StageRecord:
+			switch record.Stage {
+			case verifiedStage:
+				p.restoreMessageState(bInstance, record.Value, record.HistoryHash, 0)
+			case recoveryEchoStage:
+				p.initRecoveryMessageState(bInstance).sentEcho = true
+			case recoveryReadyStage:
+				p.initRecoveryMessageState(bInstance).sentReady = true
+			}
+			resent = append(resent, record)
+		case wal.ReceivedRecord:
+			stage := messages.ConsistentProtocolMessage_Stage(
+				messages.ConsistentProtocolMessage_Stage_value[record.Stage])
+			if stage != messages.ConsistentProtocolMessage_ECHO {
+				p.initRecoveryMessageState(bInstance).count(ProcessId(record.Sender), stage, record.Value)
+			} else if msgState := p.messagesLog[author][bInstance.SeqNumber]; msgState != nil {
+				msgState.count(ProcessId(record.Sender), record.Value)
+				// Proofs of witnesses which select themselves were verified before the echo was counted
+				if p.vrfSelector != nil {
+					msgState.witnessSet[p.pids[record.Sender]] = true
+				}
+			}
+		case wal.DeliveryRecord:
+			p.deliveredMessages[author][bInstance.SeqNumber] = record.Value
+			p.historyHash.Insert(
+				utils.TransactionToBytes(p.pids[bInstance.Author], int64(bInstance.SeqNumber)))
+			delete(p.messagesLog[author], bInstance.SeqNumber)
+			delete(p.recoveryMessagesLog[author], bInstance.SeqNumber)
+		}
+	}
+
+	p.wal = log
+	p.logger.OnRecovered(len(log.Records()), p.transactionCounter)
+
+	for _, record := range resent {
+		p.resend(record)
+	}
+
+	// Thresholds might have been reached by messages counted right before the crash
+	for author, transactions := range p.messagesLog {
+		for seqNumber, msgState := range transactions {
+			for value, count := range msgState.echoCount {
+				if _, delivered := p.deliveredMessages[author][seqNumber]; !delivered && count >= p.witnessThreshold {
+					p.deliver(&messages.BroadcastInstance{Author: int32(author), SeqNumber: seqNumber}, value, FastPath)
+				}
+			}
+		}
+	}
+	for author, transactions := range p.recoveryMessagesLog {
+		for seqNumber, recoveryState := range transactions {
+			bInstance := &messages.BroadcastInstance{Author: int32(author), SeqNumber: seqNumber}
+			for _, stat := range []map[int32]int{
+				recoveryState.echoMessagesStat, recoveryState.readyMessagesStat, recoveryState.replyMessagesStat,
+			} {
+				for value := range stat {
+					if _, delivered := p.deliveredMessages[author][seqNumber]; !delivered {
+						p.advanceRecovery(bInstance, value, recoveryState)
+					}
+				}
+			}
+		}
+	}
+}
+
+// resend sends the messages described by the record of an undelivered transaction again. An own transaction
+// the process crashed before verifying is verified again, with the history of the current epoch.
+func (p *Process) resend(record wal.Record) {
+	bInstance := record.BroadcastInstance()
+	author := ProcessId(bInstance.Author)
+	if _, delivered := p.deliveredMessages[author][bInstance.SeqNumber]; delivered {
+		return
+	}
+	msgState := p.messagesLog[author][bInstance.SeqNumber]
+
+	switch {
+	case record.Type == wal.BroadcastRecord && msgState == nil:
+		p.verify(
+			ProcessId(p.processIndex),
+			bInstance,
+			&messages.ConsistentProtocolMessage{
+				Stage:        messages.ConsistentProtocolMessage_VERIFY,
+				Value:        record.Value,
+				HistoryEpoch: p.selectionHistory.Epoch(),
+			})
+	case record.Stage == verifiedStage:
+		// If witnesses select themselves, only the author sends the verify message again, to all members
+		p.notifyWitnesses(author, bInstance, msgState)
+		if p.vrfSelector == nil && msgState.witnessSet[p.pids[p.processIndex]] {
+			p.broadcast(
+				bInstance,
+				&messages.ConsistentProtocolMessage{
+					Stage:        messages.ConsistentProtocolMessage_ECHO,
+					Value:        msgState.value,
+					HistoryHash:  msgState.historyHash,
+					HistoryEpoch: msgState.historyEpoch,
+				},
+			)
+		}
+	case record.Stage == recoveryEchoStage:
+		p.broadcast(
+			bInstance,
+			&messages.ConsistentProtocolMessage{
+				Stage: messages.ConsistentProtocolMessage_RECOVERY_ECHO,
+				Value: record.Value,
+			})
+	case record.Stage == recoveryReadyStage:
+		p.broadcast(
+			bInstance,
+			&messages.ConsistentProtocolMessage{
+				Stage: messages.ConsistentProtocolMessage_RECOVERY_READY,
+				Value: record.Value,
+			})
+	}
+}
+
+// persist appends the record to the write-ahead log if the process is recoverable.
+func (p *Process) persist(record wal.Record) {
+	if p.wal == nil {
+		return
+	}
+	if e := p.wal.Append(record); e != nil {
+		p.logger.Fatal(fmt.Sprintf("Could not write to the write-ahead log: %v", e))
+	}
 }
 
 func (p *Process) initMessageState(
 	bInstance *messages.BroadcastInstance,
+	value int32,
+	historyHash []byte,
+	historyEpoch int32,
+) *messageState {
+	msgState := p.restoreMessageState(bInstance, value, historyHash, historyEpoch)
+	if p.vrfSelector == nil {
+		p.logger.OnWitnessSetSelected("own", bInstance, msgState.witnessSet, msgState.historyEpoch)
+	} else if msgState.witnessProof != nil {
+		p.logger.OnWitnessSelfSelected("own", bInstance)
+	}
+	return msgState
+}
+
+// restoreMessageState saves the verified value and selects the witness set of the transaction
+// with the history hash chosen given the attached history hash and epoch.
+func (p *Process) restoreMessageState(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+	historyHash []byte,
+	historyEpoch int32,
 ) *messageState {
 	msgState := newMessageState()
+	msgState.value = value
 	p.messagesLog[ProcessId(bInstance.Author)][bInstance.SeqNumber] = msgState
 
+	if p.fallbackTimeout > 0 {
+		time.AfterFunc(p.fallbackTimeout, func() {
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+			p.onFallbackTimeout(bInstance)
+		})
+	}
+
 	//p.logger.OnHistoryUsedInWitnessSetSelection(
 	//	bInstance,
 	//	p.historyHash,
 	//	p.deliveredMessagesHistory,
 	//)
 
-	msgState.witnessSet, _ =
-		p.wSelector.GetWitnessSet(p.pids, bInstance.Author, bInstance.SeqNumber, p.historyHash)
+	if p.vrfSelector != nil {
+		// The witness set only holds the witnesses whose proofs the process has verified, and itself
+		msgState.witnessSet = make(map[string]bool)
+		if proof, own, _ := p.vrfSelector.Prove(
+			bInstance.Author, bInstance.SeqNumber, p.membership.Size()); own {
+			msgState.witnessProof = proof
+			msgState.witnessSet[p.pids[p.processIndex]] = true
+		}
+		return msgState
+	}
 
-	p.logger.OnWitnessSetSelected("own", bInstance, msgState.witnessSet)
+	selectionHistoryHash, attachedHistoryHash, attachedHistoryEpoch :=
+		p.selectionHistory.Select(historyHash, historyEpoch)
+	msgState.historyHash = attachedHistoryHash
+	msgState.historyEpoch = attachedHistoryEpoch
+	msgState.witnessSet, _ =
+		p.wSelector.GetWitnessSetOf(p.members, p.pids[bInstance.Author], bInstance.SeqNumber, selectionHistoryHash)
 
 	return msgState
 }
 
+// isWitness returns whether the sender of a message of the transaction is its witness. If witnesses select
+// themselves, the proof attached to the message is verified, and the sender is added to the witness set if it is valid.
+func (p *Process) isWitness(
+	msgState *messageState,
+	senderId ProcessId,
+	bInstance *messages.BroadcastInstance,
+	proof []byte,
+) bool {
+	senderPid := p.pids[senderId]
+	if msgState.witnessSet[senderPid] || p.vrfSelector == nil {
+		return msgState.witnessSet[senderPid]
+	}
+	own, _ := p.vrfSelector.Verify(int32(senderId), bInstance.Author, bInstance.SeqNumber, p.membership.Size(), proof)
+	if own {
+		msgState.witnessSet[senderPid] = true
+	}
+	return own
+}
+
+// notifyWitnesses sends the verify message to the witnesses of the transaction. If witnesses select themselves,
+// the author sends it to all members, and a process which has selected itself echoes the transaction at once.
+func (p *Process) notifyWitnesses(
+	senderId ProcessId,
+	bInstance *messages.BroadcastInstance,
+	msgState *messageState,
+) {
+	message := &messages.ConsistentProtocolMessage{
+		Stage:        messages.ConsistentProtocolMessage_VERIFY,
+		Value:        msgState.value,
+		HistoryHash:  msgState.historyHash,
+		HistoryEpoch: msgState.historyEpoch,
+	}
+	if p.vrfSelector == nil {
+		for pid := range msgState.witnessSet {
+			p.sendMessage(p.actorPids[pid], bInstance, message)
+		}
+		return
+	}
+
+	if senderId == ProcessId(p.processIndex) && bInstance.Author == p.processIndex {
+		p.broadcast(bInstance, message)
+	}
+	if msgState.witnessProof != nil {
+		p.broadcast(
+			bInstance,
+			&messages.ConsistentProtocolMessage{
+				Stage:        messages.ConsistentProtocolMessage_ECHO,
+				Value:        msgState.value,
+				WitnessProof: msgState.witnessProof,
+			})
+	}
+}
+
 func (p *Process) sendMessage(
 	to ProcessId,
 	bInstance *messages.BroadcastInstance,
@@ -146,34 +552,55 @@ func (p *Process) broadcast(
 	bInstance *messages.BroadcastInstance,
 	message *messages.ConsistentProtocolMessage,
 ) {
-	for _, pid := range p.actorPids {
-		p.sendMessage(pid, bInstance, message)
+	for _, pid := range p.membership.Members() {
+		p.sendMessage(ProcessId(pid), bInstance, message)
 	}
 }
 
-func (p *Process) deliver(bInstance *messages.BroadcastInstance, value int32) {
+func (p *Process) initRecoveryMessageState(
+	bInstance *messages.BroadcastInstance,
+) *recoveryMessageState {
+	author := ProcessId(bInstance.Author)
+	recoveryState := p.recoveryMessagesLog[author][bInstance.SeqNumber]
+
+	if recoveryState == nil {
+		recoveryState = newRecoveryMessageState()
+		p.recoveryMessagesLog[author][bInstance.SeqNumber] = recoveryState
+	}
+
+	return recoveryState
+}
+
+func (p *Process) deliver(bInstance *messages.BroadcastInstance, value int32, path string) {
+	p.persist(wal.Delivery(bInstance, value))
+
 	author := ProcessId(bInstance.Author)
 
 	p.deliveredMessages[author][bInstance.SeqNumber] = value
 	p.historyHash.Insert(
 		utils.TransactionToBytes(p.pids[bInstance.Author], int64(bInstance.SeqNumber)))
 
-	if p.sendOwnDeliveredTransactions && bInstance.Author == p.processIndex {
-		p.ownDeliveredTransactions <- true
+	messagesReceived := 0
+	if msgState := p.messagesLog[author][bInstance.SeqNumber]; msgState != nil {
+		messagesReceived += msgState.receivedMessagesCnt
+	}
+	if recoveryState := p.recoveryMessagesLog[author][bInstance.SeqNumber]; recoveryState != nil {
+		messagesReceived += recoveryState.receivedMessagesCnt
 	}
-
-	messagesReceived :=
-		p.messagesLog[author][bInstance.SeqNumber].receivedMessagesCnt
 
 	delete(p.messagesLog[author], bInstance.SeqNumber)
+	delete(p.recoveryMessagesLog[author], bInstance.SeqNumber)
 	p.logger.OnDeliver(bInstance, value, messagesReceived)
+	p.logger.OnDeliveryPath(bInstance, path)
+	p.deliveryHandler.Deliver(bInstance, value)
 }
 
 func (p *Process) verify(
 	senderId ProcessId,
 	bInstance *messages.BroadcastInstance,
-	value int32,
+	message *messages.ConsistentProtocolMessage,
 ) bool {
+	value := message.Value
 	author := ProcessId(bInstance.Author)
 	msgState := p.messagesLog[author][bInstance.SeqNumber]
 
@@ -184,67 +611,238 @@ func (p *Process) verify(
 			p.logger.OnAttack(bInstance, value, deliveredValue)
 			return false
 		}
-	} else if msgState != nil {
-		msgState.receivedMessagesCnt++
-		if msgState.witnessSet[p.pids[senderId]] && !msgState.receivedEcho[senderId] {
-			msgState.receivedEcho[senderId] = true
-			msgState.echoCount[value]++
-			if msgState.echoCount[value] >= p.witnessThreshold {
-				p.deliver(bInstance, value)
-			}
-		}
-	} else {
-		msgState = p.initMessageState(bInstance)
-		msgState.receivedMessagesCnt++
+		return true
+	}
 
-		message := &messages.ConsistentProtocolMessage{
-			Stage: messages.ConsistentProtocolMessage_VERIFY,
-			Value: value,
+	if msgState == nil {
+		msgState = p.initMessageState(bInstance, value, message.HistoryHash, message.HistoryEpoch)
+		record := wal.Stage(bInstance, verifiedStage, value)
+		record.HistoryHash = msgState.historyHash
+		p.persist(record)
+
+		p.notifyWitnesses(senderId, bInstance, msgState)
+		// Witnesses which select themselves echo only once, so the message is counted as well
+		if p.vrfSelector == nil {
+			msgState.receivedMessagesCnt++
+			return true
 		}
-		for pid := range msgState.witnessSet {
-			p.sendMessage(p.actorPids[pid], bInstance, message)
+	}
+
+	msgState.receivedMessagesCnt++
+	if !msgState.receivedEcho[senderId] && p.isWitness(msgState, senderId, bInstance, message.WitnessProof) {
+		msgState.count(senderId, value)
+		p.persist(wal.Received(int32(senderId), bInstance, messages.ConsistentProtocolMessage_ECHO.String(), value))
+		if msgState.echoCount[value] >= p.witnessThreshold {
+			p.deliver(bInstance, value, FastPath)
 		}
 	}
 	return true
 }
 
+func (p *Process) broadcastRecoveryEcho(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+	recoveryState *recoveryMessageState,
+) {
+	p.persist(wal.Stage(bInstance, recoveryEchoStage, value))
+	p.broadcast(
+		bInstance,
+		&messages.ConsistentProtocolMessage{
+			Stage: messages.ConsistentProtocolMessage_RECOVERY_ECHO,
+			Value: value,
+		})
+	recoveryState.sentEcho = true
+}
+
+func (p *Process) broadcastRecoveryReady(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+	recoveryState *recoveryMessageState,
+) {
+	p.persist(wal.Stage(bInstance, recoveryReadyStage, value))
+	p.broadcast(
+		bInstance,
+		&messages.ConsistentProtocolMessage{
+			Stage: messages.ConsistentProtocolMessage_RECOVERY_READY,
+			Value: value,
+		})
+	recoveryState.sentReady = true
+}
+
+func (p *Process) onFallbackTimeout(bInstance *messages.BroadcastInstance) {
+	author := ProcessId(bInstance.Author)
+	if _, delivered := p.deliveredMessages[author][bInstance.SeqNumber]; delivered {
+		return
+	}
+
+	recoveryState := p.initRecoveryMessageState(bInstance)
+	if recoveryState.sentEcho {
+		return
+	}
+
+	p.logger.OnRecoveryProtocolSwitch(bInstance)
+	p.broadcastRecoveryEcho(bInstance, p.messagesLog[author][bInstance.SeqNumber].value, recoveryState)
+}
+
+// joinRecovery makes a process which has verified the transaction but not delivered it
+// echo its value in the recovery started by another process.
+func (p *Process) joinRecovery(
+	bInstance *messages.BroadcastInstance,
+	recoveryState *recoveryMessageState,
+) {
+	if recoveryState.sentEcho {
+		return
+	}
+
+	if msgState := p.messagesLog[ProcessId(bInstance.Author)][bInstance.SeqNumber]; msgState != nil {
+		p.logger.OnRecoveryProtocolSwitch(bInstance)
+		p.broadcastRecoveryEcho(bInstance, msgState.value, recoveryState)
+	}
+}
+
+func (p *Process) processRecoveryMessage(
+	senderId ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.ConsistentProtocolMessage,
+) {
+	value := message.Value
+
+	// Processes drop the recovery state once they deliver, and answer recovery echoes with their delivered value
+	if deliveredValue, delivered := p.deliveredMessages[ProcessId(bInstance.Author)][bInstance.SeqNumber]; delivered {
+		if message.Stage == messages.ConsistentProtocolMessage_RECOVERY_ECHO {
+			p.sendMessage(
+				senderId,
+				bInstance,
+				&messages.ConsistentProtocolMessage{
+					Stage: messages.ConsistentProtocolMessage_RECOVERY_REPLY,
+					Value: deliveredValue,
+				})
+		}
+		return
+	}
+
+	recoveryState := p.initRecoveryMessageState(bInstance)
+	recoveryState.receivedMessagesCnt++
+
+	if message.Stage == messages.ConsistentProtocolMessage_RECOVERY_ECHO {
+		p.joinRecovery(bInstance, recoveryState)
+	}
+	if !recoveryState.count(senderId, message.Stage, value) {
+		return
+	}
+	p.persist(wal.Received(int32(senderId), bInstance, message.Stage.String(), value))
+	p.advanceRecovery(bInstance, value, recoveryState)
+}
+
+// advanceRecovery sends a recovery ready once a quorum echoed the value or f+1 processes sent a ready for it,
+// and delivers the value once 2f+1 processes sent a ready or f+1 processes replied with it.
+func (p *Process) advanceRecovery(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+	recoveryState *recoveryMessageState,
+) {
+	if recoveryState.replyMessagesStat[value] >= p.readyMessagesThreshold {
+		p.deliver(bInstance, value, RecoveryPath)
+		return
+	}
+
+	if !recoveryState.sentReady &&
+		(recoveryState.echoMessagesStat[value] >= p.quorumThreshold ||
+			recoveryState.readyMessagesStat[value] >= p.readyMessagesThreshold) {
+		p.broadcastRecoveryReady(bInstance, value, recoveryState)
+	}
+
+	if recoveryState.readyMessagesStat[value] >= p.readyMessagesForDelivery {
+		p.deliver(bInstance, value, RecoveryPath)
+	}
+}
+
 func (p *Process) HandleMessage(
 	sender int32,
 	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
 ) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.handleMessage(sender, broadcastInstanceMessage)
+}
+
+func (p *Process) handleMessage(
+	sender int32,
+	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
+) {
+	if e := protocols.ValidateMessage(sender, broadcastInstanceMessage, len(p.pids)); e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+	// Processes which are not members of the current epoch take no part in the protocol
+	if !p.membership.Contains(sender) {
+		return
+	}
+
 	bInstance := broadcastInstanceMessage.BroadcastInstance
 
 	switch protocolMessage := broadcastInstanceMessage.Message.(type) {
 	case *messages.BroadcastInstanceMessage_ConsistentProtocolMessage:
 		consistentMessage := protocolMessage.ConsistentProtocolMessage
 
-		doBroadcast := p.verify(
-			ProcessId(sender),
-			bInstance,
-			consistentMessage.Value,
-		)
+		if consistentMessage.Stage != messages.ConsistentProtocolMessage_ECHO &&
+			consistentMessage.Stage != messages.ConsistentProtocolMessage_VERIFY {
+			p.processRecoveryMessage(ProcessId(sender), bInstance, consistentMessage)
+			return
+		}
+
+		// Witnesses can not be selected before the checkpoint of the attached epoch is received
+		if !p.selectionHistory.Ready(consistentMessage.HistoryEpoch) {
+			e := p.selectionHistory.Wait(consistentMessage.HistoryEpoch, func() {
+				p.handleMessage(sender, broadcastInstanceMessage)
+			})
+			if e != nil {
+				p.logger.OnMessageRejected(sender, e.Error())
+			}
+			return
+		}
+
+		doBroadcast := p.verify(ProcessId(sender), bInstance, consistentMessage)
 
-		if consistentMessage.Stage == messages.ConsistentProtocolMessage_VERIFY && doBroadcast {
+		// Witnesses which select themselves echo once they learn about the transaction, in notifyWitnesses
+		if consistentMessage.Stage == messages.ConsistentProtocolMessage_VERIFY && doBroadcast &&
+			p.vrfSelector == nil {
+			// The attached history hash and epoch are relayed to the processes
+			// which learn about the transaction from the echo
 			p.broadcast(
 				bInstance,
 				&messages.ConsistentProtocolMessage{
-					Stage: messages.ConsistentProtocolMessage_ECHO,
-					Value: consistentMessage.Value,
+					Stage:        messages.ConsistentProtocolMessage_ECHO,
+					Value:        consistentMessage.Value,
+					HistoryHash:  consistentMessage.HistoryHash,
+					HistoryEpoch: consistentMessage.HistoryEpoch,
 				},
 			)
 		}
 	default:
-		p.logger.Fatal(fmt.Sprintf("Invalid protocol message type %t", protocolMessage))
+		p.logger.OnMessageRejected(sender, fmt.Sprintf("unexpected protocol message type %T", protocolMessage))
 	}
 }
 
 func (p *Process) Broadcast(value int32) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	broadcastInstance := &messages.BroadcastInstance{
 		Author:    p.processIndex,
 		SeqNumber: p.transactionCounter,
 	}
 
-	p.verify(ProcessId(p.processIndex), broadcastInstance, value)
+	p.persist(wal.Broadcast(broadcastInstance, value))
+	p.verify(
+		ProcessId(p.processIndex),
+		broadcastInstance,
+		&messages.ConsistentProtocolMessage{
+			Stage:        messages.ConsistentProtocolMessage_VERIFY,
+			Value:        value,
+			HistoryEpoch: p.selectionHistory.Epoch(),
+		})
 
 	p.logger.OnTransactionInit(broadcastInstance)
 
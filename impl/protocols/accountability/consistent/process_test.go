@@ -0,0 +1,146 @@
+package consistent
+
+import (
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+	"stochastic-checking-simulation/impl/protocols/protocoltest"
+	"testing"
+	"time"
+)
+
+// startNetwork starts 4 processes, all of which are own witnesses of every transaction, so that transactions
+// are delivered on the fast path only if all processes echo them.
+func startNetwork(fallbackTimeout time.Duration) *protocoltest.Network {
+	return protocoltest.StartNetwork(testParameters(fallbackTimeout), newProcess)
+}
+
+func testParameters(fallbackTimeout time.Duration) *parameters.Parameters {
+	ap := accountability.DefaultParameters()
+	ap.MinOwnWitnessSetSize = 4
+	ap.MinPotWitnessSetSize = 4
+	ap.WitnessThreshold = 4
+	protocolParams := &Parameters{Parameters: ap, FallbackTimeoutNs: int(fallbackTimeout)}
+
+	return &parameters.Parameters{ProcessCount: 4, FaultyProcesses: 1, Protocol: protocolParams}
+}
+
+func newProcess() protocols.Process {
+	return &Process{}
+}
+
+// assertDeliveredOnRecoveryPath checks that the correct processes delivered the value after the recovery
+// and dropped the state of the transaction.
+func assertDeliveredOnRecoveryPath(t *testing.T, nw *protocoltest.Network, value int32) {
+	assert.Equal(t, map[int32]int32{0: value, 1: value, 2: value}, nw.DeliveredValues(0))
+	for i, p := range nw.Processes {
+		if nw.Silent[int32(i)] {
+			continue
+		}
+		process := p.Process.(*Process)
+		process.mutex.Lock()
+		assert.Empty(t, process.messagesLog[0])
+		assert.Empty(t, process.recoveryMessagesLog[0])
+		process.mutex.Unlock()
+	}
+}
+
+func TestProcess_fallbackTimeoutDeliversWithRecovery(t *testing.T) {
+	nw := startNetwork(10 * time.Millisecond)
+	nw.Silent[3] = true
+
+	nw.Processes[0].Process.Broadcast(42)
+	nw.RouteUntilDelivered(t, 0, 10*time.Second)
+
+	assertDeliveredOnRecoveryPath(t, nw, 42)
+	for _, p := range nw.Processes[1:3] {
+		assert.Contains(t, p.Logs.String(), "path: "+RecoveryPath)
+	}
+}
+
+func TestProcess_deliveredProcessTakesPartInRecoveryWithReplies(t *testing.T) {
+	nw := startNetwork(100 * time.Millisecond)
+	nw.Silent[3] = true
+	bInstance := &messages.BroadcastInstance{Author: 0, SeqNumber: 0}
+	author := nw.Processes[0].Process.(*Process)
+
+	author.Broadcast(42)
+	nw.Route(t, func() bool { return false }, 50*time.Millisecond)
+	// The author delivers on the fast path before the fallback timeout, e.g. with the echo of the silent process,
+	// and drops the state of the transaction
+	author.mutex.Lock()
+	author.deliver(bInstance, 42, FastPath)
+	author.mutex.Unlock()
+
+	// Without the author, the recovery has only 2 echoes and readies, while 3 are required
+	nw.RouteUntilDelivered(t, 0, 10*time.Second)
+
+	assertDeliveredOnRecoveryPath(t, nw, 42)
+}
+
+// assertDeliveredOnce checks that the processes which are not silent delivered the transaction exactly once.
+func assertDeliveredOnce(
+	t *testing.T,
+	nw *protocoltest.CrashNetwork,
+	bInstance *messages.BroadcastInstance,
+	crashAfter int,
+	reverse bool,
+) {
+	for i, p := range nw.Processes {
+		if p == nil {
+			continue
+		}
+		// Delivered transactions might have been marshaled since, so they are compared with proto.Equal
+		delivered := p.Handler.Delivered()
+		if assert.Len(t, delivered, 1, "process %d, author %d, crash after %d messages, reverse order %t",
+			i, bInstance.Author, crashAfter, reverse) {
+			assert.True(t, proto.Equal(bInstance, delivered[0]))
+		}
+	}
+}
+
+func TestProcess_deliversOnFastPathAfterCrashAtAnyPoint(t *testing.T) {
+	for _, author := range []int32{0, 1} {
+		for crashAfter := 0; crashAfter <= 28; crashAfter++ {
+			for _, reverse := range []bool{false, true} {
+				// Transactions are delivered on the fast path only with the echoes of all processes,
+				// and the fallback timeout does not expire during the test
+				nw := protocoltest.StartCrashNetwork(t, testParameters(time.Hour), newProcess, nil, reverse)
+				bInstance := &messages.BroadcastInstance{Author: author, SeqNumber: 0}
+
+				nw.Processes[author].Process.Broadcast(7)
+				nw.Route(crashAfter)
+
+				assertDeliveredOnce(t, nw, bInstance, crashAfter, reverse)
+			}
+		}
+	}
+}
+
+func TestProcess_deliversOnRecoveryPathAfterCrashAtAnyPoint(t *testing.T) {
+	for _, author := range []int32{0, 1} {
+		for crashAfter := 0; crashAfter <= 16; crashAfter++ {
+			for _, reverse := range []bool{false, true} {
+				// Process 3 is silent, so the transaction is only delivered once the fallback timeouts expire
+				nw := protocoltest.StartCrashNetwork(
+					t, testParameters(time.Hour), newProcess, map[int32]bool{3: true}, reverse)
+				bInstance := &messages.BroadcastInstance{Author: author, SeqNumber: 0}
+
+				nw.Processes[author].Process.Broadcast(7)
+				nw.Route(-1)
+				for _, p := range nw.Processes[:3] {
+					process := p.Process.(*Process)
+					process.mutex.Lock()
+					process.onFallbackTimeout(&messages.BroadcastInstance{Author: author, SeqNumber: 0})
+					process.mutex.Unlock()
+				}
+				nw.Route(crashAfter)
+
+				assertDeliveredOnce(t, nw, bInstance, crashAfter, reverse)
+			}
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package consistent
+
+import (
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+)
+
+func init() {
+	protocols.Register(&protocols.Protocol{
+		Name:        "consistent_accountability",
+		Description: "byzantine consistent broadcast protocol based on stochastic accountability",
+		NewProcess: func() protocols.Process {
+			return &Process{}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{Parameters: accountability.DefaultParameters()}
+		},
+		Codec: protocols.NewOneofCodec(&messages.ConsistentProtocolMessage{}),
+	})
+}
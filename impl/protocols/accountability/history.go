@@ -0,0 +1,167 @@
+package accountability
+
+import (
+	"fmt"
+	"stochastic-checking-simulation/impl/hashing"
+	"stochastic-checking-simulation/impl/utils"
+)
+
+// EpochWindow is the number of epochs below the latest received checkpoint whose checkpoints a process keeps,
+// and the number of epochs above it whose checkpoints messages can wait for. Checkpoints reach all processes
+// at about the same time, so correct processes attach epochs within the window.
+const EpochWindow = 4
+
+// SelectionHistory chooses the history hash witnesses of a transaction are selected with.
+// Without author_history_hash and checkpoint_interval, it is the local history hash of the process.
+// With author_history_hash, it is the history hash attached to the message from which the process learns
+// about the transaction, which the author attaches to the messages of its transactions and other processes relay.
+// With checkpoint_interval, it is the history hash of the checkpoint whose epoch the author attaches:
+// the history hash of the transactions below the cut the main server has sent with the epoch.
+// The history hash of a checkpoint does not depend on the delivery order, so processes which know the epoch
+// select identical witness sets.
+type SelectionHistory struct {
+	local *hashing.HistoryHash
+	// Decodes attached history hashes, nil if they are not used
+	attached *hashing.HistoryHash
+
+	pids []string
+	// History hashes of the checkpoints the process has received within EpochWindow of the latest one,
+	// nil if checkpoints are not used. The empty checkpoint of epoch 0 precedes the first one the main server sends.
+	checkpoints map[int32]*checkpoint
+	// The empty checkpoint, from which checkpoints are derived if no checkpoint of a lower epoch is kept
+	empty *checkpoint
+	// The latest epoch of the received checkpoints
+	epoch int32
+	// Handlers of the messages which wait for the checkpoint of their epoch
+	waiting map[int32][]func()
+}
+
+type checkpoint struct {
+	cut         []int32
+	historyHash *hashing.HistoryHash
+}
+
+// NewSelectionHistory returns the selection history of a process with the given local history hash,
+// pids being the addresses of all processes, indexed as the cuts of checkpoints are.
+func NewSelectionHistory(ap *Parameters, local *hashing.HistoryHash, pids []string) *SelectionHistory {
+	sh := &SelectionHistory{local: local, pids: pids}
+	if ap.AuthorHistoryHash {
+		// Attached history hashes are only decoded into it, so its window does not matter
+		sh.attached = ap.NewHistoryHash()
+	}
+	if ap.CheckpointInterval > 0 {
+		empty := ap.NewHistoryHash()
+		empty.SetWindow(0, 0)
+		sh.empty = &checkpoint{cut: make([]int32, len(pids)), historyHash: empty}
+		sh.checkpoints = map[int32]*checkpoint{0: sh.empty}
+		sh.waiting = make(map[int32][]func())
+	}
+	return sh
+}
+
+// Select returns the history hash to select witnesses with, given the history hash and the epoch attached
+// to a message, the marshaled history hash to attach to the messages of the transaction, nil if none are attached,
+// and the epoch to attach, 0 if checkpoints are not used. The history hash is only valid until the next call.
+// A missing or malformed attached history hash, which only a byzantine process sends, is replaced with
+// the local one, and an epoch whose checkpoint the process has not received or has already dropped
+// with the latest received one.
+func (sh *SelectionHistory) Select(attached []byte, epoch int32) (*hashing.HistoryHash, []byte, int32) {
+	if sh.checkpoints != nil {
+		c, received := sh.checkpoints[epoch]
+		if !received {
+			epoch = sh.epoch
+			c = sh.checkpoints[epoch]
+		}
+		return c.historyHash, nil, epoch
+	}
+
+	sh.local.Expire()
+	if sh.attached == nil {
+		return sh.local, nil, 0
+	}
+	if len(attached) > 0 && sh.attached.UnmarshalBinary(attached) == nil {
+		return sh.attached, attached, 0
+	}
+	local, _ := sh.local.MarshalBinary()
+	return sh.local, local, 0
+}
+
+// Epoch returns the epoch the author of a new transaction attaches to its messages:
+// the latest epoch of the received checkpoints, 0 if checkpoints are not used.
+func (sh *SelectionHistory) Epoch() int32 {
+	return sh.epoch
+}
+
+// Ready returns whether witnesses of a transaction with the given attached epoch can be selected,
+// that is, unless the process has not received the checkpoint of the epoch yet.
+// Epochs below the window, whose checkpoints are dropped, and negative epochs, which only a byzantine process
+// attaches, are ready and replaced in Select.
+func (sh *SelectionHistory) Ready(epoch int32) bool {
+	if sh.checkpoints == nil || epoch < 0 || epoch < sh.epoch-EpochWindow {
+		return true
+	}
+	_, received := sh.checkpoints[epoch]
+	return received
+}
+
+// Wait makes the handler wait for the checkpoint of the epoch, it is returned by Checkpoint once it is received.
+// Epochs more than EpochWindow above the latest received one, which a byzantine process could attach to make
+// the process keep messages forever, are rejected.
+func (sh *SelectionHistory) Wait(epoch int32, handler func()) error {
+	if epoch > sh.epoch+EpochWindow {
+		return fmt.Errorf("epoch %d is more than %d epochs above the latest checkpoint of epoch %d",
+			epoch, EpochWindow, sh.epoch)
+	}
+	sh.waiting[epoch] = append(sh.waiting[epoch], handler)
+	return nil
+}
+
+// Checkpoint computes the history hash of a checkpoint received from the main server, which covers
+// the transactions of every process i with sequence numbers below cut[i], and returns the handlers
+// which waited for it. The history hash is derived from the checkpoint of the nearest lower epoch,
+// whose cut is not greater, since cuts of the main server only grow.
+// Checkpoints of epochs below the window are dropped together with the handlers waiting for them,
+// which are returned as well and select witnesses with the latest checkpoint.
+// Checkpoints received twice or below the window and cuts of an unexpected length are ignored.
+func (sh *SelectionHistory) Checkpoint(epoch int32, cut []int32) []func() {
+	if sh.checkpoints == nil || epoch <= 0 || epoch < sh.epoch-EpochWindow ||
+		len(cut) != len(sh.pids) || sh.checkpoints[epoch] != nil {
+		return nil
+	}
+
+	baseCheckpoint := sh.empty
+	base := int32(-1)
+	for e, c := range sh.checkpoints {
+		if e < epoch && e > base {
+			base = e
+			baseCheckpoint = c
+		}
+	}
+
+	historyHash := baseCheckpoint.historyHash.Copy()
+	for i, pid := range sh.pids {
+		for seqNumber := baseCheckpoint.cut[i]; seqNumber < cut[i]; seqNumber++ {
+			historyHash.Insert(utils.TransactionToBytes(pid, int64(seqNumber)))
+		}
+	}
+	sh.checkpoints[epoch] = &checkpoint{cut: append([]int32(nil), cut...), historyHash: historyHash}
+	if epoch > sh.epoch {
+		sh.epoch = epoch
+	}
+
+	handlers := sh.waiting[epoch]
+	delete(sh.waiting, epoch)
+
+	for e := range sh.checkpoints {
+		if e < sh.epoch-EpochWindow {
+			delete(sh.checkpoints, e)
+		}
+	}
+	for e, waiting := range sh.waiting {
+		if e < sh.epoch-EpochWindow {
+			handlers = append(handlers, waiting...)
+			delete(sh.waiting, e)
+		}
+	}
+	return handlers
+}
@@ -0,0 +1,181 @@
+package accountability
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/hashing"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/utils"
+	"testing"
+)
+
+var pids = []string{"p0", "p1", "p2"}
+
+func makeHistoryHash(ap *Parameters, transactions int) *hashing.HistoryHash {
+	binCapacity := parameters.BinCapacity(ap.NodeIdSize, ap.NumberOfBins)
+	historyHash := hashing.NewHistoryHash(uint(ap.NumberOfBins), binCapacity, ap.Hasher())
+	for i := 0; i < transactions; i++ {
+		historyHash.Insert(utils.TransactionToBytes("author", int64(i)))
+	}
+	return historyHash
+}
+
+func TestSelectionHistory_localWithoutAuthorHistoryHash(t *testing.T) {
+	ap := DefaultParameters()
+	local := makeHistoryHash(&ap, 3)
+	attached, _ := makeHistoryHash(&ap, 5).MarshalBinary()
+
+	historyHash, attachedHistoryHash, _ := NewSelectionHistory(&ap, local, pids).Select(attached, 0)
+
+	assert.Same(t, local, historyHash)
+	assert.Nil(t, attachedHistoryHash)
+}
+
+func TestSelectionHistory_attachedWithAuthorHistoryHash(t *testing.T) {
+	ap := DefaultParameters()
+	ap.AuthorHistoryHash = true
+	author := makeHistoryHash(&ap, 5)
+	attached, _ := author.MarshalBinary()
+
+	historyHash, attachedHistoryHash, _ := NewSelectionHistory(&ap, makeHistoryHash(&ap, 3), pids).Select(attached, 0)
+
+	assert.True(t, author.Equal(historyHash))
+	assert.Equal(t, attached, attachedHistoryHash)
+}
+
+func TestSelectionHistory_localAttachedIfMissingOrInvalid(t *testing.T) {
+	ap := DefaultParameters()
+	ap.AuthorHistoryHash = true
+	local := makeHistoryHash(&ap, 3)
+	marshaledLocal, _ := local.MarshalBinary()
+	sh := NewSelectionHistory(&ap, local, pids)
+
+	for _, attached := range [][]byte{nil, {1, 2, 3}} {
+		historyHash, attachedHistoryHash, _ := sh.Select(attached, 0)
+
+		assert.Same(t, local, historyHash)
+		assert.Equal(t, marshaledLocal, attachedHistoryHash)
+	}
+}
+
+// makeCut returns the history hash of the transactions of every process i with sequence numbers below cut[i],
+// inserted in the order of sequence numbers.
+func makeCut(ap *Parameters, cut []int32) *hashing.HistoryHash {
+	binCapacity := parameters.BinCapacity(ap.NodeIdSize, ap.NumberOfBins)
+	historyHash := hashing.NewHistoryHash(uint(ap.NumberOfBins), binCapacity, ap.Hasher())
+	for i, pid := range pids {
+		for seqNumber := int32(0); seqNumber < cut[i]; seqNumber++ {
+			historyHash.Insert(utils.TransactionToBytes(pid, int64(seqNumber)))
+		}
+	}
+	return historyHash
+}
+
+func TestSelectionHistory_checkpointOfAttachedEpoch(t *testing.T) {
+	ap := DefaultParameters()
+	ap.CheckpointInterval = 4
+	local := makeHistoryHash(&ap, 3)
+	sh := NewSelectionHistory(&ap, local, pids)
+
+	assert.Nil(t, sh.Checkpoint(2, []int32{1, 0, 2}))
+	assert.Nil(t, sh.Checkpoint(4, []int32{3, 1, 2}))
+
+	historyHash, attachedHistoryHash, epoch := sh.Select(nil, 2)
+	assert.True(t, makeCut(&ap, []int32{1, 0, 2}).Equal(historyHash))
+	assert.Nil(t, attachedHistoryHash)
+	assert.Equal(t, int32(2), epoch)
+
+	historyHash, _, epoch = sh.Select(nil, 4)
+	assert.True(t, makeCut(&ap, []int32{3, 1, 2}).Equal(historyHash))
+	assert.Equal(t, int32(4), epoch)
+
+	historyHash, _, epoch = sh.Select(nil, 0)
+	assert.True(t, makeCut(&ap, []int32{0, 0, 0}).Equal(historyHash))
+	assert.Equal(t, int32(0), epoch)
+}
+
+func TestSelectionHistory_checkpointsReceivedOutOfOrder(t *testing.T) {
+	ap := DefaultParameters()
+	ap.CheckpointInterval = 4
+	sh := NewSelectionHistory(&ap, makeHistoryHash(&ap, 0), pids)
+
+	sh.Checkpoint(3, []int32{4, 2, 2})
+	sh.Checkpoint(1, []int32{2, 0, 1})
+
+	historyHash, _, _ := sh.Select(nil, 1)
+	assert.True(t, makeCut(&ap, []int32{2, 0, 1}).Equal(historyHash))
+	historyHash, _, _ = sh.Select(nil, 3)
+	assert.True(t, makeCut(&ap, []int32{4, 2, 2}).Equal(historyHash))
+	assert.Equal(t, int32(3), sh.Epoch())
+}
+
+func TestSelectionHistory_waitsForCheckpointOfEpoch(t *testing.T) {
+	ap := DefaultParameters()
+	ap.CheckpointInterval = 4
+	sh := NewSelectionHistory(&ap, makeHistoryHash(&ap, 0), pids)
+
+	assert.True(t, sh.Ready(0))
+	assert.True(t, sh.Ready(-1))
+	assert.False(t, sh.Ready(2))
+
+	handled := 0
+	assert.Nil(t, sh.Wait(2, func() { handled++ }))
+	assert.Nil(t, sh.Wait(2, func() { handled++ }))
+	for _, handle := range sh.Checkpoint(2, []int32{1, 1, 1}) {
+		handle()
+	}
+
+	assert.Equal(t, 2, handled)
+	assert.True(t, sh.Ready(2))
+	assert.Nil(t, sh.Checkpoint(2, []int32{1, 1, 1}))
+}
+
+func TestSelectionHistory_unknownEpochReplacedWithLatest(t *testing.T) {
+	ap := DefaultParameters()
+	ap.CheckpointInterval = 4
+	sh := NewSelectionHistory(&ap, makeHistoryHash(&ap, 0), pids)
+	sh.Checkpoint(1, []int32{1, 2, 3})
+	// Cuts of an unexpected length are ignored
+	sh.Checkpoint(2, []int32{1, 2})
+
+	historyHash, _, epoch := sh.Select(nil, -3)
+
+	assert.True(t, makeCut(&ap, []int32{1, 2, 3}).Equal(historyHash))
+	assert.Equal(t, int32(1), epoch)
+}
+
+func TestSelectionHistory_epochsAboveWindowRejected(t *testing.T) {
+	ap := DefaultParameters()
+	ap.CheckpointInterval = 4
+	sh := NewSelectionHistory(&ap, makeHistoryHash(&ap, 0), pids)
+	sh.Checkpoint(3, []int32{1, 1, 1})
+
+	assert.Nil(t, sh.Wait(3+EpochWindow, func() {}))
+	assert.ErrorContains(t, sh.Wait(4+EpochWindow, func() {}), "more than 4 epochs above")
+}
+
+func TestSelectionHistory_checkpointsBelowWindowDropped(t *testing.T) {
+	ap := DefaultParameters()
+	ap.CheckpointInterval = 4
+	sh := NewSelectionHistory(&ap, makeHistoryHash(&ap, 0), pids)
+	sh.Checkpoint(1, []int32{1, 0, 0})
+
+	handled := 0
+	assert.Nil(t, sh.Wait(2, func() { handled++ }))
+	for _, handle := range sh.Checkpoint(3+EpochWindow, []int32{4, 4, 4}) {
+		handle()
+	}
+
+	// The handler waiting for a checkpoint below the window selects witnesses with the latest one
+	assert.Equal(t, 1, handled)
+	assert.True(t, sh.Ready(2))
+	assert.Len(t, sh.checkpoints, 1)
+	historyHash, _, epoch := sh.Select(nil, 1)
+	assert.True(t, makeCut(&ap, []int32{4, 4, 4}).Equal(historyHash))
+	assert.Equal(t, int32(3+EpochWindow), epoch)
+	assert.Nil(t, sh.Checkpoint(2, []int32{2, 2, 2}))
+
+	// Checkpoints received out of order within the window are derived from the empty checkpoint
+	sh.Checkpoint(4, []int32{3, 2, 1})
+	historyHash, _, _ = sh.Select(nil, 4)
+	assert.True(t, makeCut(&ap, []int32{3, 2, 1}).Equal(historyHash))
+}
@@ -0,0 +1,227 @@
+package accountability
+
+import (
+	"stochastic-checking-simulation/impl/hashing"
+	"stochastic-checking-simulation/impl/parameters"
+	"time"
+)
+
+// Parameters shared by the protocols based on stochastic accountability.
+type Parameters struct {
+	MinOwnWitnessSetSize int `json:"w"`
+	MinPotWitnessSetSize int `json:"v"`
+
+	OwnWitnessSetRadius float64 `json:"wr"`
+	PotWitnessSetRadius float64 `json:"vr"`
+
+	WitnessThreshold int `json:"u"`
+	NodeIdSize       int `json:"node_id_size"`
+	NumberOfBins     int `json:"number_of_bins"`
+	// Hash names the hash function used for history hashes and witness selection.
+	// If it is empty, sha256 is used for 256-bit node ids and sha512 for 512-bit ones.
+	Hash string `json:"hash"`
+	// Metric names the metric of distances in witness selection, the toroidal L1 distance if it is empty.
+	Metric string `json:"metric"`
+	// AuthorHistoryHash makes processes select witnesses of a transaction with the history hash of its author,
+	// which is attached to the messages of the transaction, instead of their own history hashes.
+	AuthorHistoryHash bool `json:"author_history_hash"`
+	// HistoryWindow and HistoryWindowNs limit the history hash to the last delivered transactions
+	// and to the transactions delivered during the last nanoseconds. Zero does not limit the history hash.
+	HistoryWindow   int `json:"history_window"`
+	HistoryWindowNs int `json:"history_window_ns"`
+	// CheckpointInterval makes processes select witnesses with the history hashes of checkpoints agreed via
+	// the main server, to which every process reports the transactions it has delivered every checkpoint_interval
+	// deliveries. Zero disables checkpoints.
+	CheckpointInterval int `json:"checkpoint_interval"`
+	// WitnessSelection is distance, the default if it is empty, to select the witnesses closest to the transaction,
+	// or vrf to let processes select themselves with a verifiable random function, see VrfSelector.
+	WitnessSelection string `json:"witness_selection"`
+	// VrfFailureProbability bounds the probability that a transaction has fewer than u own witnesses
+	// when witnesses are selected with vrf, whose witness sets have no minimum size.
+	VrfFailureProbability float64 `json:"vrf_failure_probability"`
+}
+
+// DefaultParameters returns the parameters used when the input file does not list them.
+func DefaultParameters() Parameters {
+	return Parameters{
+		MinOwnWitnessSetSize: 2,
+		MinPotWitnessSetSize: 2,
+		OwnWitnessSetRadius:  1900.0,
+		PotWitnessSetRadius:  1910.0,
+		WitnessThreshold:     2,
+		NodeIdSize:           256,
+		NumberOfBins:         32,
+		// Transactions with fewer than u own witnesses are not accepted on the fast path
+		VrfFailureProbability: 0.01,
+	}
+}
+
+func (ap *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	n := p.ProcessCount
+
+	if 3*p.FaultyProcesses >= n {
+		v.Warnf("n=%d and f=%d do not satisfy n > 3f, quorums of %d processes may not intersect in a correct one",
+			n, p.FaultyProcesses, parameters.QuorumThreshold(n, p.FaultyProcesses))
+	}
+
+	if ap.Hash == "" {
+		if ap.NodeIdSize != 256 && ap.NodeIdSize != 512 {
+			v.Errorf("node_id_size must be either 256 or 512, got %d", ap.NodeIdSize)
+		}
+	} else if hashFunction, e := hashing.GetHashFunction(ap.Hash); e != nil {
+		v.Errorf("%v", e)
+	} else {
+		if ap.NodeIdSize <= 0 || ap.NodeIdSize > hashFunction.DigestSize {
+			v.Errorf("node_id_size must be in [1, %d] for %s digests of %d bits, got %d",
+				hashFunction.DigestSize, ap.Hash, hashFunction.DigestSize, ap.NodeIdSize)
+		}
+		if !hashFunction.Cryptographic {
+			v.Warnf("hash %s is not cryptographic, byzantine processes could steer witness selection", ap.Hash)
+		}
+	}
+	if ap.Metric != "" {
+		if _, e := hashing.GetDistanceMetric(ap.Metric); e != nil {
+			v.Errorf("%v", e)
+		}
+	}
+	if ap.NumberOfBins <= 0 {
+		v.Errorf("number_of_bins must be positive, got %d", ap.NumberOfBins)
+	} else if ap.NodeIdSize%ap.NumberOfBins != 0 {
+		v.Errorf("number_of_bins (%d) must divide node_id_size (%d)", ap.NumberOfBins, ap.NodeIdSize)
+	} else if bits := ap.NodeIdSize / ap.NumberOfBins; bits > parameters.MaxBitsPerBin {
+		v.Errorf("node_id_size / number_of_bins gives %d bits per bin, at most %d are supported",
+			bits, parameters.MaxBitsPerBin)
+	}
+
+	if ap.HistoryWindow < 0 {
+		v.Errorf("history_window must not be negative, got %d", ap.HistoryWindow)
+	}
+	if ap.HistoryWindowNs < 0 {
+		v.Errorf("history_window_ns must not be negative, got %d", ap.HistoryWindowNs)
+	}
+
+	if ap.CheckpointInterval < 0 {
+		v.Errorf("checkpoint_interval must not be negative, got %d", ap.CheckpointInterval)
+	} else if ap.CheckpointInterval > 0 {
+		if ap.AuthorHistoryHash {
+			v.Errorf("author_history_hash and checkpoint_interval select the history hash differently, " +
+				"at most one of them can be set")
+		}
+		if ap.HistoryWindow > 0 || ap.HistoryWindowNs > 0 {
+			v.Warnf("history_window and history_window_ns do not limit the history hashes of checkpoints")
+		}
+	}
+
+	switch ap.WitnessSelection {
+	case "", DistanceWitnessSelection:
+	case VrfWitnessSelection:
+		if ap.AuthorHistoryHash || ap.CheckpointInterval > 0 {
+			v.Warnf("witnesses selected with %s do not depend on the history, "+
+				"author_history_hash and checkpoint_interval have no effect", VrfWitnessSelection)
+		}
+		if ap.VrfFailureProbability < 0 || ap.VrfFailureProbability >= 1 {
+			v.Errorf("vrf_failure_probability must be in [0, 1), got %v", ap.VrfFailureProbability)
+		} else {
+			failure := VrfFailureProbability(n, ap.MinOwnWitnessSetSize, ap.WitnessThreshold)
+			if failure > ap.VrfFailureProbability {
+				v.Errorf("witnesses selected with %s give a transaction fewer than u (%d) own witnesses "+
+					"with probability %.3g, above vrf_failure_probability (%v), w (%d) must be larger",
+					VrfWitnessSelection, ap.WitnessThreshold, failure, ap.VrfFailureProbability, ap.MinOwnWitnessSetSize)
+			}
+		}
+	default:
+		v.Errorf("invalid witness_selection: %s, expected one of %v",
+			ap.WitnessSelection, []string{DistanceWitnessSelection, VrfWitnessSelection})
+	}
+
+	if ap.MinOwnWitnessSetSize <= 0 {
+		v.Errorf("w must be positive, got %d", ap.MinOwnWitnessSetSize)
+	}
+	if ap.MinPotWitnessSetSize <= 0 {
+		v.Errorf("v must be positive, got %d", ap.MinPotWitnessSetSize)
+	}
+	if ap.WitnessThreshold <= 0 {
+		v.Errorf("u must be positive, got %d", ap.WitnessThreshold)
+	}
+	if ap.WitnessThreshold > ap.MinOwnWitnessSetSize {
+		v.Errorf("u (%d) must not be greater than w (%d), otherwise a transaction may never be accepted",
+			ap.WitnessThreshold, ap.MinOwnWitnessSetSize)
+	}
+	if ap.WitnessThreshold > n {
+		v.Errorf("u (%d) must not be greater than n (%d)", ap.WitnessThreshold, n)
+	}
+
+	if ap.MinOwnWitnessSetSize > ap.MinPotWitnessSetSize {
+		v.Warnf("w (%d) is greater than v (%d), own witness sets are drawn from pot witness sets",
+			ap.MinOwnWitnessSetSize, ap.MinPotWitnessSetSize)
+	}
+	if ap.MinOwnWitnessSetSize > n {
+		v.Warnf("w (%d) is greater than n (%d), all processes will be own witnesses", ap.MinOwnWitnessSetSize, n)
+	}
+	if ap.MinPotWitnessSetSize > n {
+		v.Warnf("v (%d) is greater than n (%d), all processes will be pot witnesses", ap.MinPotWitnessSetSize, n)
+	}
+	if ap.OwnWitnessSetRadius > ap.PotWitnessSetRadius {
+		v.Warnf("wr (%v) is greater than vr (%v), own witness sets are drawn from pot witness sets",
+			ap.OwnWitnessSetRadius, ap.PotWitnessSetRadius)
+	}
+}
+
+// Hasher returns the hash function selected by the parameters, which must be valid.
+func (ap *Parameters) Hasher() hashing.Hasher {
+	if ap.Hash == "" {
+		if ap.NodeIdSize == 256 {
+			return hashing.HashSHA256{}
+		}
+		return hashing.HashSHA512{}
+	}
+	hashFunction, e := hashing.GetHashFunction(ap.Hash)
+	if e != nil {
+		panic(e)
+	}
+	return hashFunction.Hasher
+}
+
+// NewHistoryHash returns an empty history hash with the hash function and the window selected by the parameters,
+// which must be valid.
+func (ap *Parameters) NewHistoryHash() *hashing.HistoryHash {
+	binCapacity := parameters.BinCapacity(ap.NodeIdSize, ap.NumberOfBins)
+	historyHash := hashing.NewHistoryHash(uint(ap.NumberOfBins), binCapacity, ap.Hasher())
+	historyHash.SetWindow(ap.HistoryWindow, time.Duration(ap.HistoryWindowNs))
+	return historyHash
+}
+
+// VrfSelector returns the selector of witnesses of the process with the given index if the parameters
+// select witnesses with a verifiable random function, and nil otherwise.
+func (ap *Parameters) VrfSelector(processIndex int32, pids []string) *VrfSelector {
+	if ap.WitnessSelection != VrfWitnessSelection {
+		return nil
+	}
+	return NewVrfSelector(ap, processIndex, pids)
+}
+
+// DistanceMetric returns the metric selected by the parameters, which must be valid.
+func (ap *Parameters) DistanceMetric() hashing.Metric {
+	if ap.Metric == "" {
+		return hashing.L1Metric{}
+	}
+	distanceMetric, e := hashing.GetDistanceMetric(ap.Metric)
+	if e != nil {
+		panic(e)
+	}
+	return distanceMetric.Metric
+}
+
+func (ap *Parameters) Thresholds(*parameters.Parameters) []parameters.Threshold {
+	thresholds := []parameters.Threshold{
+		{Name: "witnessThreshold", Value: ap.WitnessThreshold},
+	}
+	if ap.NumberOfBins > 0 {
+		thresholds = append(thresholds,
+			parameters.Threshold{
+				Name:  "binCapacity",
+				Value: int(parameters.BinCapacity(ap.NodeIdSize, ap.NumberOfBins)),
+			})
+	}
+	return thresholds
+}
@@ -0,0 +1,211 @@
+package accountability
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/hashing"
+	"stochastic-checking-simulation/impl/parameters"
+	"testing"
+)
+
+func makeParameters() (*parameters.Parameters, *Parameters) {
+	ap := &Parameters{
+		MinOwnWitnessSetSize: 2,
+		MinPotWitnessSetSize: 3,
+		OwnWitnessSetRadius:  10,
+		PotWitnessSetRadius:  20,
+		WitnessThreshold:     2,
+		NodeIdSize:           256,
+		NumberOfBins:         32,
+	}
+	return &parameters.Parameters{ProcessCount: 4, FaultyProcesses: 1, Protocol: ap}, ap
+}
+
+func TestValidate_validParameters(t *testing.T) {
+	p, _ := makeParameters()
+
+	warnings, e := p.Validate()
+
+	assert.Nil(t, e)
+	assert.Empty(t, warnings)
+}
+
+func TestValidate_witnessThresholdGreaterThanOwnWitnessSet(t *testing.T) {
+	p, ap := makeParameters()
+	ap.WitnessThreshold = 3
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "u (3) must not be greater than w (2)")
+}
+
+func TestValidate_binsNotDividingNodeIdSize(t *testing.T) {
+	p, ap := makeParameters()
+	ap.NumberOfBins = 7
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "must divide node_id_size")
+}
+
+func TestValidate_unsupportedNodeIdSize(t *testing.T) {
+	p, ap := makeParameters()
+	ap.NodeIdSize = 128
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "node_id_size must be either 256 or 512")
+}
+
+func TestValidate_ownRadiusGreaterThanPotRadiusIsWarning(t *testing.T) {
+	p, ap := makeParameters()
+	ap.OwnWitnessSetRadius = 30
+
+	warnings, e := p.Validate()
+
+	assert.Nil(t, e)
+	assert.Len(t, warnings, 1)
+}
+
+func TestThresholds(t *testing.T) {
+	p, _ := makeParameters()
+
+	thresholds := p.Thresholds()
+
+	assert.Exactly(t, []parameters.Threshold{
+		{Name: "witnessThreshold", Value: 2},
+		{Name: "binCapacity", Value: 256},
+	}, thresholds)
+}
+
+func TestValidate_unknownHash(t *testing.T) {
+	p, ap := makeParameters()
+	ap.Hash = "md5"
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "invalid hash function: md5")
+}
+
+func TestValidate_nodeIdSizeGreaterThanDigest(t *testing.T) {
+	p, ap := makeParameters()
+	ap.Hash = "sha3-256"
+	ap.NodeIdSize = 512
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "node_id_size must be in [1, 256] for sha3-256 digests of 256 bits, got 512")
+}
+
+func TestValidate_nonCryptographicHashIsWarning(t *testing.T) {
+	p, ap := makeParameters()
+	ap.Hash = "fnv"
+	ap.NodeIdSize = 128
+
+	warnings, e := p.Validate()
+
+	assert.Nil(t, e)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "hash fnv is not cryptographic")
+}
+
+func TestHasher_defaultsToNodeIdSize(t *testing.T) {
+	_, ap := makeParameters()
+
+	assert.Equal(t, hashing.HashSHA256{}, ap.Hasher())
+
+	ap.NodeIdSize = 512
+	assert.Equal(t, hashing.HashSHA512{}, ap.Hasher())
+
+	ap.Hash = "blake2b"
+	assert.Equal(t, hashing.HashBLAKE2b{}, ap.Hasher())
+}
+
+func TestValidate_unknownMetric(t *testing.T) {
+	p, ap := makeParameters()
+	ap.Metric = "cosine"
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "invalid metric: cosine")
+}
+
+func TestDistanceMetric_defaultsToL1(t *testing.T) {
+	_, ap := makeParameters()
+
+	assert.Equal(t, hashing.L1Metric{}, ap.DistanceMetric())
+
+	ap.Metric = "ring"
+	assert.Equal(t, hashing.RingMetric{}, ap.DistanceMetric())
+}
+
+func TestValidate_negativeHistoryWindow(t *testing.T) {
+	p, ap := makeParameters()
+	ap.HistoryWindow = -1
+	ap.HistoryWindowNs = -1
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "history_window must not be negative, got -1")
+	assert.ErrorContains(t, e, "history_window_ns must not be negative, got -1")
+}
+
+func TestValidate_checkpointsWithAuthorHistoryHash(t *testing.T) {
+	p, ap := makeParameters()
+	ap.CheckpointInterval = 10
+	ap.AuthorHistoryHash = true
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "at most one of them can be set")
+}
+
+func TestValidate_checkpointsWithHistoryWindowIsWarning(t *testing.T) {
+	p, ap := makeParameters()
+	ap.CheckpointInterval = 10
+	ap.HistoryWindow = 100
+
+	warnings, e := p.Validate()
+
+	assert.Nil(t, e)
+	assert.Contains(t, warnings, "history_window and history_window_ns do not limit the history hashes of checkpoints")
+}
+
+func TestValidate_invalidWitnessSelection(t *testing.T) {
+	p, ap := makeParameters()
+	ap.WitnessSelection = "random"
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "invalid witness_selection: random, expected one of [distance vrf]")
+}
+
+func TestValidate_vrfWitnessSelectionWithCheckpointsIsWarning(t *testing.T) {
+	p, ap := makeParameters()
+	ap.WitnessSelection = VrfWitnessSelection
+	ap.VrfFailureProbability = 0.5
+	ap.CheckpointInterval = 10
+
+	warnings, e := p.Validate()
+
+	assert.Nil(t, e)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "do not depend on the history")
+}
+
+func TestValidate_vrfWitnessSelectionLikelyBelowWitnessThreshold(t *testing.T) {
+	p, ap := makeParameters()
+	ap.WitnessSelection = VrfWitnessSelection
+	ap.VrfFailureProbability = 0.01
+
+	_, e := p.Validate()
+
+	// Fewer than 2 of 4 processes select themselves with probability 1/2 each in 5 cases out of 16
+	assert.ErrorContains(t, e, "fewer than u (2) own witnesses with probability 0.313, "+
+		"above vrf_failure_probability (0.01), w (2) must be larger")
+
+	ap.MinOwnWitnessSetSize = 4
+	ap.MinPotWitnessSetSize = 4
+	_, e = p.Validate()
+
+	assert.Nil(t, e)
+}
@@ -0,0 +1,30 @@
+package reliable
+
+import (
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+)
+
+// Parameters of the reliable accountability protocol.
+type Parameters struct {
+	accountability.Parameters
+
+	RecoverySwitchTimeoutNs int `json:"recovery_timeout"`
+}
+
+func (rp *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	rp.Parameters.Validate(p, v)
+
+	if rp.RecoverySwitchTimeoutNs <= 0 {
+		v.Errorf("recovery_timeout must be positive, got %d", rp.RecoverySwitchTimeoutNs)
+	}
+}
+
+func (rp *Parameters) Thresholds(p *parameters.Parameters) []parameters.Threshold {
+	f := p.FaultyProcesses
+	return append(
+		rp.Parameters.Thresholds(p),
+		parameters.Threshold{Name: "quorumThreshold", Value: parameters.QuorumThreshold(p.ProcessCount, f)},
+		parameters.Threshold{Name: "readyMessagesThreshold", Value: parameters.ReadyMessagesThreshold(f)},
+	)
+}
@@ -2,12 +2,14 @@ package reliable
 
 import (
 	"fmt"
-	"math"
 	"stochastic-checking-simulation/context"
 	"stochastic-checking-simulation/impl/eventlogger"
 	"stochastic-checking-simulation/impl/hashing"
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+	"stochastic-checking-simulation/impl/signatures"
 	"stochastic-checking-simulation/impl/utils"
 	"time"
 )
@@ -56,6 +58,13 @@ type messageState struct {
 
 	ownWitnessSet map[string]bool
 	potWitnessSet map[string]bool
+	// Marshaled history hash witnesses are selected with, attached to the messages of the transaction
+	historyHash []byte
+	// Epoch of the checkpoint witnesses are selected with, attached to the messages of the transaction
+	historyEpoch int32
+	// Proof that the process is a witness of the transaction if witnesses select themselves,
+	// attached to its messages as a witness
+	witnessProof []byte
 
 	receivedMessagesCnt int
 }
@@ -133,38 +142,47 @@ type Process struct {
 	lastSentPMessages   map[ProcessId]map[int32]*messages.ReliableProtocolMessage
 	recoveryMessagesLog map[ProcessId]map[int32]*recoveryMessageState
 
+	f          int
+	membership *protocols.Membership
+	// Addresses of the members of the current epoch, among which witnesses are selected
+	members []string
+
 	quorumThreshold         int
 	readyMessagesThreshold  int
 	recoverySwitchTimeoutNs time.Duration
 	witnessThreshold        int
 
-	wSelector   *hashing.WitnessesSelector
-	historyHash *hashing.HistoryHash
+	wSelector          *hashing.WitnessesSelector
+	historyHash        *hashing.HistoryHash
+	selectionHistory   *accountability.SelectionHistory
+	checkpointInterval int
+	// Selects witnesses with a verifiable random function, nil if they are selected with wSelector
+	vrfSelector *accountability.VrfSelector
 
-	context                      *context.ReliableContext
-	logger                       *eventlogger.EventLogger
-	ownDeliveredTransactions     chan bool
-	sendOwnDeliveredTransactions bool
+	context         *context.ReliableContext
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
 }
 
 func (p *Process) InitProcess(
 	processIndex int32,
 	actorPids []string,
-	parameters *parameters.Parameters,
+	params *parameters.Parameters,
 	context *context.ReliableContext,
 	logger *eventlogger.EventLogger,
-	ownDeliveredTransactions chan bool,
-	sendOwnDeliveredTransactions bool,
+	deliveryHandler protocols.DeliveryHandler,
 ) {
 	p.processIndex = processIndex
 	p.pids = actorPids
 
 	p.transactionCounter = 0
 
-	p.quorumThreshold = int(math.Ceil(float64(len(actorPids)+parameters.FaultyProcesses+1) / float64(2)))
-	p.readyMessagesThreshold = parameters.FaultyProcesses + 1
-	p.recoverySwitchTimeoutNs = time.Duration(parameters.RecoverySwitchTimeoutNs)
-	p.witnessThreshold = parameters.WitnessThreshold
+	protocolParams := params.Protocol.(*Parameters)
+
+	p.f = params.FaultyProcesses
+	p.Reconfigure(protocols.FullMembership(len(actorPids)))
+	p.recoverySwitchTimeoutNs = time.Duration(protocolParams.RecoverySwitchTimeoutNs)
+	p.witnessThreshold = protocolParams.WitnessThreshold
 
 	p.actorPids = make(map[string]ProcessId)
 	p.deliveredMessages = make(map[ProcessId]map[int32]int32)
@@ -180,60 +198,142 @@ func (p *Process) InitProcess(
 		//p.recoveryMessagesLog[ProcessId(i)] = make(map[int32]*recoveryMessageState)
 	}
 
-	var hasher hashing.Hasher
-	if parameters.NodeIdSize == 256 {
-		hasher = hashing.HashSHA256{}
-	} else {
-		hasher = hashing.HashSHA512{}
-	}
+	hasher := protocolParams.Hasher()
 
 	p.wSelector = &hashing.WitnessesSelector{
 		Hasher:               hasher,
-		MinPotWitnessSetSize: parameters.MinPotWitnessSetSize,
-		MinOwnWitnessSetSize: parameters.MinOwnWitnessSetSize,
-		PotWitnessSetRadius:  parameters.PotWitnessSetRadius,
-		OwnWitnessSetRadius:  parameters.OwnWitnessSetRadius,
+		Metric:               protocolParams.DistanceMetric(),
+		MinPotWitnessSetSize: protocolParams.MinPotWitnessSetSize,
+		MinOwnWitnessSetSize: protocolParams.MinOwnWitnessSetSize,
+		PotWitnessSetRadius:  protocolParams.PotWitnessSetRadius,
+		OwnWitnessSetRadius:  protocolParams.OwnWitnessSetRadius,
 	}
-	binCapacity := uint(math.Pow(2, float64(parameters.NodeIdSize/parameters.NumberOfBins)))
-	p.historyHash = hashing.NewHistoryHash(uint(parameters.NumberOfBins), binCapacity, hasher)
+	p.historyHash = protocolParams.NewHistoryHash()
+	p.selectionHistory = accountability.NewSelectionHistory(&protocolParams.Parameters, p.historyHash, actorPids)
+	p.checkpointInterval = protocolParams.CheckpointInterval
+	p.vrfSelector = protocolParams.VrfSelector(processIndex, actorPids)
 
 	p.context = context
 	p.logger = logger
-	p.ownDeliveredTransactions = ownDeliveredTransactions
-	p.sendOwnDeliveredTransactions = sendOwnDeliveredTransactions
+	p.deliveryHandler = deliveryHandler
+}
+
+// Reconfigure makes the process select witnesses and send messages among the members of the new epoch,
+// and derive the thresholds from their number. Witness sets of transactions
+// the process already knows about are not changed.
+func (p *Process) Reconfigure(membership *protocols.Membership) {
+	p.membership = membership
+	p.members = membership.Pids(p.pids)
+
+	p.quorumThreshold = parameters.QuorumThreshold(membership.Size(), p.f)
+	p.readyMessagesThreshold = parameters.ReadyMessagesThreshold(p.f)
+}
+
+// UseKeys makes the process prove that it is a witness with its secret key if witnesses select themselves.
+func (p *Process) UseKeys(keys *signatures.KeyRing) {
+	if p.vrfSelector != nil {
+		p.vrfSelector.UseKeys(keys)
+	}
+}
+
+func (p *Process) CheckpointInterval() int {
+	return p.checkpointInterval
+}
+
+// Checkpoint makes the history hash of the checkpoint available for witness selection,
+// and handles the messages which waited for it.
+func (p *Process) Checkpoint(epoch int32, cut []int32) {
+	for _, handle := range p.selectionHistory.Checkpoint(epoch, cut) {
+		handle()
+	}
 }
 
 func (p *Process) initMessageState(
 	bInstance *messages.BroadcastInstance,
 	value int32,
+	historyHash []byte,
+	historyEpoch int32,
 ) *messageState {
 	msgState := newMessageState()
 	p.messagesLog[ProcessId(bInstance.Author)][bInstance.SeqNumber] = msgState
 
-	msgState.ownWitnessSet, msgState.potWitnessSet =
-		p.wSelector.GetWitnessSet(p.pids, bInstance.Author, bInstance.SeqNumber, p.historyHash)
-
-	p.logger.OnWitnessSetSelected("own", bInstance, msgState.ownWitnessSet)
-	p.logger.OnWitnessSetSelected("pot", bInstance, msgState.potWitnessSet)
+	if p.vrfSelector != nil {
+		p.selectWitness(bInstance, msgState)
+	} else {
+		selectionHistoryHash, attachedHistoryHash, attachedHistoryEpoch :=
+			p.selectionHistory.Select(historyHash, historyEpoch)
+		msgState.historyHash = attachedHistoryHash
+		msgState.historyEpoch = attachedHistoryEpoch
+		msgState.ownWitnessSet, msgState.potWitnessSet = p.wSelector.GetWitnessSetOf(
+			p.members, p.pids[bInstance.Author], bInstance.SeqNumber, selectionHistoryHash)
+
+		p.logger.OnWitnessSetSelected("own", bInstance, msgState.ownWitnessSet, msgState.historyEpoch)
+		p.logger.OnWitnessSetSelected("pot", bInstance, msgState.potWitnessSet, msgState.historyEpoch)
+	}
 
 	p.broadcastToWitnesses(
 		bInstance,
 		&messages.ReliableProtocolMessage{
-			Stage: messages.ReliableProtocolMessage_NOTIFY,
-			Value: value,
+			Stage:        messages.ReliableProtocolMessage_NOTIFY,
+			Value:        value,
+			HistoryHash:  msgState.historyHash,
+			HistoryEpoch: msgState.historyEpoch,
 		},
 		msgState)
 
 	return msgState
 }
 
+// selectWitness makes the process select itself as an own and a pot witness of the transaction
+// with the verifiable random function. The witness sets only hold the own witnesses whose proofs the process
+// has verified, and itself.
+func (p *Process) selectWitness(bInstance *messages.BroadcastInstance, msgState *messageState) {
+	msgState.ownWitnessSet = make(map[string]bool)
+	msgState.potWitnessSet = make(map[string]bool)
+
+	proof, own, pot := p.vrfSelector.Prove(bInstance.Author, bInstance.SeqNumber, p.membership.Size())
+	if own || pot {
+		msgState.witnessProof = proof
+	}
+	if own {
+		msgState.ownWitnessSet[p.pids[p.processIndex]] = true
+		p.logger.OnWitnessSelfSelected("own", bInstance)
+	}
+	if pot {
+		msgState.potWitnessSet[p.pids[p.processIndex]] = true
+		p.logger.OnWitnessSelfSelected("pot", bInstance)
+	}
+}
+
+// isOwnWitness returns whether the sender of a message of the transaction is its own witness. If witnesses select
+// themselves, the proof attached to the message is verified, and the sender is added to the own witness set
+// if it is valid.
+func (p *Process) isOwnWitness(
+	msgState *messageState,
+	senderId ProcessId,
+	bInstance *messages.BroadcastInstance,
+	proof []byte,
+) bool {
+	senderPid := p.pids[senderId]
+	if msgState.ownWitnessSet[senderPid] || p.vrfSelector == nil {
+		return msgState.ownWitnessSet[senderPid]
+	}
+	own, _ := p.vrfSelector.Verify(int32(senderId), bInstance.Author, bInstance.SeqNumber, p.membership.Size(), proof)
+	if own {
+		msgState.ownWitnessSet[senderPid] = true
+	}
+	return own
+}
+
 func (p *Process) registerMessage(
 	bInstance *messages.BroadcastInstance,
 	value int32,
+	historyHash []byte,
+	historyEpoch int32,
 ) *messageState {
 	msgState := p.messagesLog[ProcessId(bInstance.Author)][bInstance.SeqNumber]
 	if msgState == nil {
-		msgState = p.initMessageState(bInstance, value)
+		msgState = p.initMessageState(bInstance, value, historyHash, historyEpoch)
 		//actorContext.ReenterAfter(
 		//	actor.NewFuture(actorContext.ActorSystem(), p.recoverySwitchTimeoutNs),
 		//	func(res interface{}, err error) {
@@ -305,8 +405,8 @@ func (p *Process) broadcastProtocolMessage(
 	bInstance *messages.BroadcastInstance,
 	message *messages.ReliableProtocolMessage,
 ) {
-	for i := range p.pids {
-		p.sendProtocolMessage(ProcessId(i), bInstance, message)
+	for _, pid := range p.membership.Members() {
+		p.sendProtocolMessage(ProcessId(pid), bInstance, message)
 	}
 }
 
@@ -314,8 +414,8 @@ func (p *Process) broadcastRecoveryMessage(
 	bInstance *messages.BroadcastInstance,
 	message *messages.RecoveryProtocolMessage,
 ) {
-	for i := range p.pids {
-		p.sendRecoveryMessage(ProcessId(i), bInstance, message)
+	for _, pid := range p.membership.Members() {
+		p.sendRecoveryMessage(ProcessId(pid), bInstance, message)
 	}
 }
 
@@ -324,6 +424,11 @@ func (p *Process) broadcastToWitnesses(
 	message *messages.ReliableProtocolMessage,
 	msgState *messageState,
 ) {
+	// Witnesses which select themselves are not known in advance
+	if p.vrfSelector != nil {
+		p.broadcastProtocolMessage(bInstance, message)
+		return
+	}
 	for pid := range msgState.potWitnessSet {
 		p.sendProtocolMessage(p.actorPids[pid], bInstance, message)
 	}
@@ -339,8 +444,11 @@ func (p *Process) broadcastReadyFromWitness(
 	p.broadcastProtocolMessage(
 		bInstance,
 		&messages.ReliableProtocolMessage{
-			Stage: messages.ReliableProtocolMessage_READY_FROM_WITNESS,
-			Value: value,
+			Stage:        messages.ReliableProtocolMessage_READY_FROM_WITNESS,
+			Value:        value,
+			HistoryHash:  msgState.historyHash,
+			HistoryEpoch: msgState.historyEpoch,
+			WitnessProof: msgState.witnessProof,
 		})
 	msgState.witnessStage = SentReadyFromWitness
 }
@@ -418,15 +526,12 @@ func (p *Process) deliver(
 	p.historyHash.Insert(
 		utils.TransactionToBytes(p.pids[bInstance.Author], int64(bInstance.SeqNumber)))
 
-	if p.sendOwnDeliveredTransactions && bInstance.Author == p.processIndex {
-		p.ownDeliveredTransactions <- true
-	}
-
 	msgState := p.messagesLog[author][bInstance.SeqNumber]
 	messagesReceived := msgState.receivedMessagesCnt
 	delete(p.messagesLog[author], bInstance.SeqNumber)
 
 	p.logger.OnDeliver(bInstance, value, messagesReceived)
+	p.deliveryHandler.Deliver(bInstance, value)
 }
 
 func (p *Process) processReliableProtocolMessage(
@@ -440,11 +545,9 @@ func (p *Process) processReliableProtocolMessage(
 		return
 	}
 
-	msgState := p.registerMessage(bInstance, value)
+	msgState := p.registerMessage(bInstance, value, reliableMessage.HistoryHash, reliableMessage.HistoryEpoch)
 	msgState.receivedMessagesCnt++
 
-	senderPid := p.pids[senderId]
-
 	switch reliableMessage.Stage {
 	case messages.ReliableProtocolMessage_NOTIFY:
 		if !p.isWitness(msgState) || msgState.witnessStage >= SentEchoFromWitness {
@@ -453,19 +556,25 @@ func (p *Process) processReliableProtocolMessage(
 		p.broadcastProtocolMessage(
 			bInstance,
 			&messages.ReliableProtocolMessage{
-				Stage: messages.ReliableProtocolMessage_ECHO_FROM_WITNESS,
-				Value: value,
+				Stage:        messages.ReliableProtocolMessage_ECHO_FROM_WITNESS,
+				Value:        value,
+				HistoryHash:  msgState.historyHash,
+				HistoryEpoch: msgState.historyEpoch,
+				WitnessProof: msgState.witnessProof,
 			})
 		msgState.witnessStage = SentEchoFromWitness
 	case messages.ReliableProtocolMessage_ECHO_FROM_WITNESS:
-		if !msgState.ownWitnessSet[senderPid] || msgState.stage >= SentEchoFromProcess {
+		if msgState.stage >= SentEchoFromProcess ||
+			!p.isOwnWitness(msgState, senderId, bInstance, reliableMessage.WitnessProof) {
 			return
 		}
 		p.broadcastToWitnesses(
 			bInstance,
 			&messages.ReliableProtocolMessage{
-				Stage: messages.ReliableProtocolMessage_ECHO_FROM_PROCESS,
-				Value: value,
+				Stage:        messages.ReliableProtocolMessage_ECHO_FROM_PROCESS,
+				Value:        value,
+				HistoryHash:  msgState.historyHash,
+				HistoryEpoch: msgState.historyEpoch,
 			},
 			msgState)
 		msgState.stage = SentEchoFromProcess
@@ -487,9 +596,9 @@ func (p *Process) processReliableProtocolMessage(
 			)
 		}
 	case messages.ReliableProtocolMessage_READY_FROM_WITNESS:
-		if !msgState.ownWitnessSet[senderPid] ||
-			msgState.stage >= SentReadyFromProcess ||
-			msgState.readyFromWitnesses[senderId] {
+		if msgState.stage >= SentReadyFromProcess ||
+			msgState.readyFromWitnesses[senderId] ||
+			!p.isOwnWitness(msgState, senderId, bInstance, reliableMessage.WitnessProof) {
 			return
 		}
 
@@ -500,8 +609,10 @@ func (p *Process) processReliableProtocolMessage(
 			p.broadcastToWitnesses(
 				bInstance,
 				&messages.ReliableProtocolMessage{
-					Stage: messages.ReliableProtocolMessage_READY_FROM_PROCESS,
-					Value: value,
+					Stage:        messages.ReliableProtocolMessage_READY_FROM_PROCESS,
+					Value:        value,
+					HistoryHash:  msgState.historyHash,
+					HistoryEpoch: msgState.historyEpoch,
 				},
 				msgState,
 			)
@@ -529,14 +640,18 @@ func (p *Process) processReliableProtocolMessage(
 			p.broadcastProtocolMessage(
 				bInstance,
 				&messages.ReliableProtocolMessage{
-					Stage: messages.ReliableProtocolMessage_VALIDATE,
-					Value: value,
+					Stage:        messages.ReliableProtocolMessage_VALIDATE,
+					Value:        value,
+					HistoryHash:  msgState.historyHash,
+					HistoryEpoch: msgState.historyEpoch,
+					WitnessProof: msgState.witnessProof,
 				},
 			)
 			msgState.witnessStage = SentValidate
 		}
 	case messages.ReliableProtocolMessage_VALIDATE:
-		if !msgState.ownWitnessSet[senderPid] || msgState.validateFromWitnesses[senderId] {
+		if msgState.validateFromWitnesses[senderId] ||
+			!p.isOwnWitness(msgState, senderId, bInstance, reliableMessage.WitnessProof) {
 			return
 		}
 
@@ -691,25 +806,54 @@ func (p *Process) HandleMessage(
 	sender int32,
 	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
 ) {
+	if e := protocols.ValidateMessage(sender, broadcastInstanceMessage, len(p.pids)); e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+	// Processes which are not members of the current epoch take no part in the protocol
+	if !p.membership.Contains(sender) {
+		return
+	}
+
 	bInstance := broadcastInstanceMessage.BroadcastInstance
 
 	senderId := ProcessId(sender)
 
 	switch protocolMessage := broadcastInstanceMessage.Message.(type) {
 	case *messages.BroadcastInstanceMessage_ReliableProtocolMessage:
+		// Witnesses can not be selected before the checkpoint of the attached epoch is received
+		historyEpoch := protocolMessage.ReliableProtocolMessage.HistoryEpoch
+		if !p.selectionHistory.Ready(historyEpoch) {
+			e := p.selectionHistory.Wait(historyEpoch, func() {
+				p.HandleMessage(sender, broadcastInstanceMessage)
+			})
+			if e != nil {
+				p.logger.OnMessageRejected(sender, e.Error())
+			}
+			return
+		}
 		p.processReliableProtocolMessage(
 			senderId,
 			bInstance,
 			protocolMessage.ReliableProtocolMessage,
 		)
 	case *messages.BroadcastInstanceMessage_RecoveryProtocolMessage:
+		// The recovery protocol is disabled, processes keep no state of its broadcast instances
+		if p.recoveryMessagesLog == nil {
+			p.logger.OnMessageRejected(sender, "recovery protocol is disabled")
+			return
+		}
+		if protocolMessage.RecoveryProtocolMessage.ReliableProtocolMessage == nil {
+			p.logger.OnMessageRejected(sender, "recovery message carries no reliable protocol message")
+			return
+		}
 		p.processRecoveryProtocolMessage(
 			senderId,
 			bInstance,
 			protocolMessage.RecoveryProtocolMessage,
 		)
 	default:
-		p.logger.Fatal(fmt.Sprintf("Invalid protocol message type %t", protocolMessage))
+		p.logger.OnMessageRejected(sender, fmt.Sprintf("unexpected protocol message type %T", protocolMessage))
 	}
 }
 
@@ -725,8 +869,9 @@ func (p *Process) Broadcast(
 		p.actorPids[p.pids[p.processIndex]],
 		broadcastInstance,
 		&messages.ReliableProtocolMessage{
-			Stage: messages.ReliableProtocolMessage_NOTIFY,
-			Value: value,
+			Stage:        messages.ReliableProtocolMessage_NOTIFY,
+			Value:        value,
+			HistoryEpoch: p.selectionHistory.Epoch(),
 		})
 
 	p.logger.OnTransactionInit(broadcastInstance)
@@ -0,0 +1,28 @@
+package reliable
+
+import (
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+)
+
+func init() {
+	protocols.Register(&protocols.Protocol{
+		Name:        "reliable_accountability",
+		Description: "byzantine reliable broadcast protocol based on stochastic accountability",
+		NewProcess: func() protocols.Process {
+			return &Process{}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{
+				Parameters:              accountability.DefaultParameters(),
+				RecoverySwitchTimeoutNs: 1000000,
+			}
+		},
+		Codec: protocols.NewOneofCodec(
+			&messages.ReliableProtocolMessage{},
+			&messages.RecoveryProtocolMessage{},
+		),
+	})
+}
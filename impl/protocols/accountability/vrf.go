@@ -0,0 +1,99 @@
+package accountability
+
+import (
+	"encoding/binary"
+	"math"
+	"stochastic-checking-simulation/impl/signatures"
+	"stochastic-checking-simulation/impl/utils"
+)
+
+// Witness selection modes of the witness_selection parameter
+const (
+	DistanceWitnessSelection = "distance"
+	VrfWitnessSelection      = "vrf"
+)
+
+// VrfSelector selects witnesses with a verifiable random function instead of the distances of public node ids.
+// Every process evaluates the function on a transaction with its own key, and is an own witness
+// if the output, read as a number in [0, 1), is below w divided by the number of members, and a pot witness
+// if it is below v divided by it, so that the expected sizes of the witness sets are w and v,
+// and own witnesses are pot witnesses if w <= v. Processes can not compute who the witnesses are:
+// witnesses attach the proof to their messages, which others verify before counting them.
+// Unlike with distances, the author can not pick the witnesses by choosing the transaction,
+// since it does not know the outputs of other processes, provided their keys are secret, see UseKeys. The sizes of the witness sets are binomially distributed,
+// so w and v are not minimum sizes, see VrfFailureProbability.
+type VrfSelector struct {
+	keys *signatures.KeyRing
+	pids []string
+
+	minOwnWitnessSetSize int
+	minPotWitnessSetSize int
+}
+
+// NewVrfSelector returns the selector of the process with the given index, pids being the addresses of all processes.
+// It evaluates the function with keys derived from pids until UseKeys is called.
+func NewVrfSelector(ap *Parameters, processIndex int32, pids []string) *VrfSelector {
+	return &VrfSelector{
+		keys:                 signatures.NewKeyRing(processIndex, pids),
+		pids:                 pids,
+		minOwnWitnessSetSize: ap.MinOwnWitnessSetSize,
+		minPotWitnessSetSize: ap.MinPotWitnessSetSize,
+	}
+}
+
+// UseKeys makes the selector evaluate the function with the secret key of the process,
+// and verify the proofs of other processes with their exchanged public keys.
+func (s *VrfSelector) UseKeys(keys *signatures.KeyRing) {
+	s.keys = keys
+}
+
+// Prove returns the proof of the current process for the transaction and whether it is its own and pot witness
+// among the given number of members.
+func (s *VrfSelector) Prove(author int32, seqNumber int32, members int) ([]byte, bool, bool) {
+	output, proof := s.keys.Evaluate(s.input(author, seqNumber))
+	own, pot := s.witness(output[:], members)
+	return proof, own, pot
+}
+
+// Verify returns whether the proof shows that the process with the given index is an own and a pot witness
+// of the transaction among the given number of members. Invalid proofs show neither.
+func (s *VrfSelector) Verify(witness int32, author int32, seqNumber int32, members int, proof []byte) (bool, bool) {
+	output, valid := s.keys.VerifyOutput(witness, s.input(author, seqNumber), proof)
+	if !valid {
+		return false, false
+	}
+	return s.witness(output[:], members)
+}
+
+func (s *VrfSelector) input(author int32, seqNumber int32) []byte {
+	return utils.TransactionToBytes(s.pids[author], int64(seqNumber))
+}
+
+func (s *VrfSelector) witness(output []byte, members int) (bool, bool) {
+	// The first 8 bytes of the output are read as a fraction of 2^64
+	value := float64(binary.BigEndian.Uint64(output)) / (1 << 64) * float64(members)
+	return value < float64(s.minOwnWitnessSetSize), value < float64(s.minPotWitnessSetSize)
+}
+
+// VrfFailureProbability returns the probability that fewer than u of n members select themselves as own witnesses
+// of a transaction with the expected own witness set size w, i.e. that the transaction is not accepted on the fast path
+// even if all witnesses are correct.
+func VrfFailureProbability(n int, w int, u int) float64 {
+	if w >= n {
+		if u > n {
+			return 1
+		}
+		return 0
+	}
+	p := float64(w) / float64(n)
+	failure := 0.0
+	for k := 0; k < u && k <= n; k++ {
+		// The binomial coefficients overflow for large n, so the terms are computed from logarithms
+		logCoefficient, _ := math.Lgamma(float64(n + 1))
+		logKFactorial, _ := math.Lgamma(float64(k + 1))
+		logRestFactorial, _ := math.Lgamma(float64(n - k + 1))
+		failure += math.Exp(logCoefficient - logKFactorial - logRestFactorial +
+			float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+	}
+	return math.Min(failure, 1)
+}
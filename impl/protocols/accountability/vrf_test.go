@@ -0,0 +1,125 @@
+package accountability
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/signatures"
+	"testing"
+)
+
+func makeVrfSelectors(ap *Parameters, n int) []*VrfSelector {
+	pids := make([]string, n)
+	for i := range pids {
+		pids[i] = fmt.Sprintf("10.0.0.%d:5001", i+2)
+	}
+	selectors := make([]*VrfSelector, n)
+	for i := range selectors {
+		selectors[i] = NewVrfSelector(ap, int32(i), pids)
+	}
+	return selectors
+}
+
+func TestVrfSelector_proofsAreVerifiedByOtherProcesses(t *testing.T) {
+	ap := DefaultParameters()
+	ap.MinOwnWitnessSetSize = 4
+	ap.MinPotWitnessSetSize = 8
+	selectors := makeVrfSelectors(&ap, 16)
+
+	for seqNumber := int32(0); seqNumber < 20; seqNumber++ {
+		for i, selector := range selectors {
+			proof, own, pot := selector.Prove(0, seqNumber, len(selectors))
+			verifiedOwn, verifiedPot := selectors[(i+1)%len(selectors)].Verify(
+				int32(i), 0, seqNumber, len(selectors), proof)
+
+			assert.Equal(t, own, verifiedOwn)
+			assert.Equal(t, pot, verifiedPot)
+			// Own witnesses are pot witnesses, since w <= v
+			assert.True(t, !own || pot)
+		}
+	}
+}
+
+func TestVrfSelector_secretKeys(t *testing.T) {
+	ap := DefaultParameters()
+	ap.MinOwnWitnessSetSize = 16
+	ap.MinPotWitnessSetSize = 16
+	selectors := makeVrfSelectors(&ap, 2)
+	derived := makeVrfSelectors(&ap, 2)
+
+	var publicKeys [][]byte
+	var keys []*signatures.KeyRing
+	for i := range selectors {
+		seed := make([]byte, 32)
+		seed[0] = byte(i + 1)
+		keys = append(keys, signatures.NewSecretKeyRing(int32(i), seed))
+		publicKeys = append(publicKeys, keys[i].PublicKey())
+	}
+	for i, selector := range selectors {
+		assert.Nil(t, keys[i].SetPublicKeys(publicKeys))
+		selector.UseKeys(keys[i])
+	}
+
+	proof, own, _ := selectors[1].Prove(0, 3, 2)
+	assert.True(t, own)
+	own, _ = selectors[0].Verify(1, 0, 3, 2, proof)
+	assert.True(t, own)
+
+	// Proofs computed with the keys derived from pids, as any process could, are rejected
+	derivedProof, _, _ := derived[1].Prove(0, 3, 2)
+	assert.NotEqual(t, proof, derivedProof)
+	own, _ = selectors[0].Verify(1, 0, 3, 2, derivedProof)
+	assert.False(t, own)
+}
+
+func TestVrfSelector_rejectsProofsOfOtherProcessesAndTransactions(t *testing.T) {
+	ap := DefaultParameters()
+	ap.MinOwnWitnessSetSize = 16
+	ap.MinPotWitnessSetSize = 16
+	selectors := makeVrfSelectors(&ap, 16)
+
+	// All processes are witnesses, so only the proof decides
+	proof, own, _ := selectors[1].Prove(0, 3, 16)
+	assert.True(t, own)
+
+	own, pot := selectors[0].Verify(2, 0, 3, 16, proof)
+	assert.False(t, own || pot)
+	own, pot = selectors[0].Verify(1, 0, 4, 16, proof)
+	assert.False(t, own || pot)
+	own, pot = selectors[0].Verify(1, 0, 3, 16, nil)
+	assert.False(t, own || pot)
+}
+
+func TestVrfSelector_expectedWitnessSetSizes(t *testing.T) {
+	ap := DefaultParameters()
+	ap.MinOwnWitnessSetSize = 4
+	ap.MinPotWitnessSetSize = 8
+	n := 32
+	transactions := 200
+	selectors := makeVrfSelectors(&ap, n)
+
+	ownCount, potCount := 0, 0
+	for seqNumber := int32(0); seqNumber < int32(transactions); seqNumber++ {
+		for _, selector := range selectors {
+			_, own, pot := selector.Prove(1, seqNumber, n)
+			if own {
+				ownCount++
+			}
+			if pot {
+				potCount++
+			}
+		}
+	}
+
+	assert.InDelta(t, 4, float64(ownCount)/float64(transactions), 0.5)
+	assert.InDelta(t, 8, float64(potCount)/float64(transactions), 0.75)
+}
+
+func TestVrfFailureProbability(t *testing.T) {
+	assert.InDelta(t, 5.0/16, VrfFailureProbability(4, 2, 2), 1e-9)
+	assert.Equal(t, 0.0, VrfFailureProbability(4, 4, 2))
+	assert.Equal(t, 1.0, VrfFailureProbability(4, 4, 5))
+	// With w = u about half of the transactions have fewer than u own witnesses
+	assert.InDelta(t, 0.45, VrfFailureProbability(1000, 20, 20), 0.03)
+	assert.Less(t, VrfFailureProbability(1000, 40, 20), 0.001)
+	assert.Less(t, VrfFailureProbability(100000, 40, 20), 0.001)
+}
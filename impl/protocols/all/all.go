@@ -0,0 +1,14 @@
+// Package all registers every protocol implemented in the simulation.
+// Binaries and tests import it for its side effects to discover protocols through the registry.
+package all
+
+import (
+	_ "stochastic-checking-simulation/impl/protocols/accountability/consistent"
+	_ "stochastic-checking-simulation/impl/protocols/accountability/reliable"
+	_ "stochastic-checking-simulation/impl/protocols/authenticated"
+	_ "stochastic-checking-simulation/impl/protocols/bracha"
+	_ "stochastic-checking-simulation/impl/protocols/gossip"
+	_ "stochastic-checking-simulation/impl/protocols/imbsraynal"
+	_ "stochastic-checking-simulation/impl/protocols/scalable"
+	_ "stochastic-checking-simulation/impl/protocols/snowball"
+)
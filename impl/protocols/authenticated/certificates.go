@@ -0,0 +1,42 @@
+package authenticated
+
+import (
+	"encoding/binary"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/signatures"
+)
+
+// signedData returns the bytes a process signs to support the value
+// of the given broadcast instance at the given stage.
+func signedData(
+	stage messages.AuthenticatedProtocolMessage_Stage,
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) []byte {
+	data := make([]byte, 13)
+	data[0] = byte(stage)
+	binary.BigEndian.PutUint32(data[1:], uint32(bInstance.Author))
+	binary.BigEndian.PutUint32(data[5:], uint32(bInstance.SeqNumber))
+	binary.BigEndian.PutUint32(data[9:], uint32(value))
+	return data
+}
+
+// validCertificate checks that the certificate contains valid signatures
+// of the given data from at least threshold distinct processes.
+func validCertificate(
+	keys *signatures.KeyRing,
+	certificate []*messages.Signature,
+	data []byte,
+	threshold int,
+) bool {
+	signers := make(map[int32]bool)
+	for _, signature := range certificate {
+		if signers[signature.Signer] {
+			continue
+		}
+		if keys.Verify(signature.Signer, data, signature.Signature) {
+			signers[signature.Signer] = true
+		}
+	}
+	return len(signers) >= threshold
+}
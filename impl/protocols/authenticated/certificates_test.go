@@ -0,0 +1,47 @@
+package authenticated
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/signatures"
+	"testing"
+)
+
+var pids = []string{"10.0.0.2:5001", "10.0.0.3:5001", "10.0.0.4:5001", "10.0.0.5:5001"}
+
+var bInstance = &messages.BroadcastInstance{Author: 0, SeqNumber: 3}
+
+func sign(signer int32, data []byte) *messages.Signature {
+	return &messages.Signature{
+		Signer:    signer,
+		Signature: signatures.NewKeyRing(signer, pids).Sign(data),
+	}
+}
+
+func TestValidCertificate_enoughSignatures(t *testing.T) {
+	keys := signatures.NewKeyRing(3, pids)
+	data := signedData(messages.AuthenticatedProtocolMessage_ECHO, bInstance, 42)
+	certificate := []*messages.Signature{sign(0, data), sign(1, data), sign(2, data)}
+
+	assert.True(t, validCertificate(keys, certificate, data, 3))
+	assert.False(t, validCertificate(keys, certificate, data, 4))
+}
+
+func TestValidCertificate_duplicateSignersCountOnce(t *testing.T) {
+	keys := signatures.NewKeyRing(3, pids)
+	data := signedData(messages.AuthenticatedProtocolMessage_ECHO, bInstance, 42)
+	certificate := []*messages.Signature{sign(0, data), sign(0, data), sign(1, data)}
+
+	assert.False(t, validCertificate(keys, certificate, data, 3))
+}
+
+func TestValidCertificate_signaturesOfOtherData(t *testing.T) {
+	keys := signatures.NewKeyRing(3, pids)
+	data := signedData(messages.AuthenticatedProtocolMessage_ECHO, bInstance, 42)
+	otherValue := signedData(messages.AuthenticatedProtocolMessage_ECHO, bInstance, 43)
+	otherStage := signedData(messages.AuthenticatedProtocolMessage_READY, bInstance, 42)
+	certificate := []*messages.Signature{sign(0, data), sign(1, otherValue), sign(2, otherStage)}
+
+	assert.False(t, validCertificate(keys, certificate, data, 2))
+	assert.True(t, validCertificate(keys, certificate, data, 1))
+}
@@ -0,0 +1,26 @@
+package authenticated
+
+import "stochastic-checking-simulation/impl/parameters"
+
+// Parameters of the authenticated protocols. The protocols are fully defined by n and f,
+// so they do not declare any parameters of their own.
+type Parameters struct {
+	doubleEcho bool
+}
+
+func (ap *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	if 3*p.FaultyProcesses >= p.ProcessCount {
+		v.Errorf("authenticated broadcast requires n > 3f, got n=%d and f=%d", p.ProcessCount, p.FaultyProcesses)
+	}
+}
+
+func (ap *Parameters) Thresholds(p *parameters.Parameters) []parameters.Threshold {
+	f := p.FaultyProcesses
+	thresholds := []parameters.Threshold{
+		{Name: "echoCertificateSize", Value: parameters.QuorumThreshold(p.ProcessCount, f)},
+	}
+	if ap.doubleEcho {
+		thresholds = append(thresholds, parameters.Threshold{Name: "readyCertificateSize", Value: 2*f + 1})
+	}
+	return thresholds
+}
@@ -0,0 +1,24 @@
+package authenticated
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/parameters"
+	"testing"
+)
+
+func TestValidate_tooManyFaultyProcesses(t *testing.T) {
+	p := &parameters.Parameters{ProcessCount: 3, FaultyProcesses: 1, Protocol: &Parameters{}}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "n > 3f")
+}
+
+func TestThresholds(t *testing.T) {
+	p := &parameters.Parameters{ProcessCount: 4, FaultyProcesses: 1, Protocol: &Parameters{doubleEcho: true}}
+
+	assert.Equal(t, []parameters.Threshold{
+		{Name: "echoCertificateSize", Value: 3},
+		{Name: "readyCertificateSize", Value: 3},
+	}, p.Thresholds())
+}
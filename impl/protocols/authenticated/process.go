@@ -0,0 +1,364 @@
+package authenticated
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/signatures"
+	"strings"
+)
+
+type ProcessId int32
+
+type messageState struct {
+	sentEcho  bool
+	sentReady bool
+
+	// Signatures collected by the author of the broadcast instance
+	echoSignatures  map[ProcessId][]byte
+	readySignatures map[ProcessId][]byte
+
+	sentEchoCertificate  bool
+	sentReadyCertificate bool
+
+	receivedMessagesCnt int
+}
+
+func newMessageState() *messageState {
+	ms := new(messageState)
+	ms.echoSignatures = make(map[ProcessId][]byte)
+	ms.readySignatures = make(map[ProcessId][]byte)
+
+	ms.receivedMessagesCnt = 0
+
+	return ms
+}
+
+// Process executes one of the signature based broadcast protocols.
+// In the signed echo broadcast the author collects signed echoes from a quorum of processes
+// and sends the resulting echo certificate to everyone, a process delivers a value once it receives
+// a valid echo certificate for it. In the authenticated double-echo broadcast processes reply
+// to the echo certificate with signed ready messages, and the author sends out a certificate
+// of 2f+1 ready signatures. A process delivers the value once it receives a valid ready certificate
+// and relays the certificate, so that all correct processes deliver it.
+// Processes only send signatures to the author, which keeps the number of messages sent by a process
+// per broadcast instance linear in n. Ed25519 signatures can not be aggregated,
+// so certificates contain the list of collected signatures.
+type Process struct {
+	processIndex int32
+
+	transactionCounter int32
+
+	codec      protocols.MessageCodec
+	doubleEcho bool
+	keys       *signatures.KeyRing
+
+	deliveredTransactions map[ProcessId]map[int32]int32
+	transactionsLog       map[ProcessId]map[int32]*messageState
+	ownValues             map[int32]int32
+
+	n                    int
+	echoCertificateSize  int
+	readyCertificateSize int
+
+	context         *context.ReliableContext
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
+}
+
+func (p *Process) InitProcess(
+	processIndex int32,
+	actorPids []string,
+	params *parameters.Parameters,
+	context *context.ReliableContext,
+	logger *eventlogger.EventLogger,
+	deliveryHandler protocols.DeliveryHandler,
+) {
+	p.processIndex = processIndex
+	p.n = len(actorPids)
+	f := params.FaultyProcesses
+
+	p.transactionCounter = 0
+	p.keys = signatures.NewKeyRing(processIndex, actorPids)
+
+	p.echoCertificateSize = parameters.QuorumThreshold(p.n, f)
+	p.readyCertificateSize = 2*f + 1
+
+	p.deliveredTransactions = make(map[ProcessId]map[int32]int32)
+	p.transactionsLog = make(map[ProcessId]map[int32]*messageState)
+	for index := 0; index < p.n; index++ {
+		p.deliveredTransactions[ProcessId(index)] = make(map[int32]int32)
+		p.transactionsLog[ProcessId(index)] = make(map[int32]*messageState)
+	}
+	p.ownValues = make(map[int32]int32)
+
+	p.context = context
+	p.logger = logger
+	p.deliveryHandler = deliveryHandler
+}
+
+// UseKeys makes the process sign its messages with its secret key, and verify signatures with the exchanged public keys.
+func (p *Process) UseKeys(keys *signatures.KeyRing) {
+	p.keys = keys
+}
+
+func (p *Process) initMessageState(bInstance *messages.BroadcastInstance) *messageState {
+	author := ProcessId(bInstance.Author)
+	msgState := p.transactionsLog[author][bInstance.SeqNumber]
+	if msgState == nil {
+		msgState = newMessageState()
+		p.transactionsLog[author][bInstance.SeqNumber] = msgState
+	}
+	return msgState
+}
+
+// sendProtocolMessage sends the message and returns the size of the sent message.
+func (p *Process) sendProtocolMessage(
+	to ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.AuthenticatedProtocolMessage,
+) int {
+	bMessage, e := p.codec.Pack(bInstance, message)
+	if e != nil {
+		p.logger.Fatal(fmt.Sprintf("Could not pack protocol message: %v", e))
+	}
+
+	msg := p.context.MakeNewMessage()
+	msg.Content = &messages.Message_BroadcastInstanceMessage{
+		BroadcastInstanceMessage: bMessage,
+	}
+
+	p.context.Send(int32(to), msg)
+	return proto.Size(msg)
+}
+
+func (p *Process) broadcast(
+	bInstance *messages.BroadcastInstance,
+	message *messages.AuthenticatedProtocolMessage,
+) {
+	messageSize := 0
+	for i := 0; i < p.n; i++ {
+		messageSize = p.sendProtocolMessage(ProcessId(i), bInstance, message)
+	}
+	if len(message.Certificate) > 0 {
+		p.logger.OnCertificateSent(bInstance, strings.ToLower(message.Stage.String()), len(message.Certificate), messageSize)
+	}
+}
+
+func (p *Process) sign(
+	stage messages.AuthenticatedProtocolMessage_Stage,
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) *messages.Signature {
+	return &messages.Signature{
+		Signer:    p.processIndex,
+		Signature: p.keys.Sign(signedData(stage, bInstance, value)),
+	}
+}
+
+func makeCertificate(signatures map[ProcessId][]byte) []*messages.Signature {
+	certificate := make([]*messages.Signature, 0, len(signatures))
+	for signer, signature := range signatures {
+		certificate = append(certificate, &messages.Signature{
+			Signer:    int32(signer),
+			Signature: signature,
+		})
+	}
+	return certificate
+}
+
+func (p *Process) delivered(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) bool {
+	deliveredValue, delivered :=
+		p.deliveredTransactions[ProcessId(bInstance.Author)][bInstance.SeqNumber]
+
+	if delivered && deliveredValue != value {
+		p.logger.OnAttack(bInstance, value, deliveredValue)
+	}
+
+	return delivered
+}
+
+func (p *Process) deliver(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) {
+	author := ProcessId(bInstance.Author)
+	p.deliveredTransactions[author][bInstance.SeqNumber] = value
+	messagesReceived :=
+		p.transactionsLog[author][bInstance.SeqNumber].receivedMessagesCnt
+
+	if bInstance.Author == p.processIndex {
+		delete(p.ownValues, bInstance.SeqNumber)
+	}
+
+	delete(p.transactionsLog[author], bInstance.SeqNumber)
+	p.logger.OnDeliver(bInstance, value, messagesReceived)
+	p.deliveryHandler.Deliver(bInstance, value)
+}
+
+// collectSignature saves a signature sent to the author of the broadcast instance.
+// It returns false if the signature is not valid or does not support the value the author has broadcast.
+func (p *Process) collectSignature(
+	senderPid ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.AuthenticatedProtocolMessage,
+	signatures map[ProcessId][]byte,
+) bool {
+	if bInstance.Author != p.processIndex || message.Signature == nil {
+		return false
+	}
+	ownValue, broadcast := p.ownValues[bInstance.SeqNumber]
+	if !broadcast || ownValue != message.Value || message.Signature.Signer != int32(senderPid) {
+		return false
+	}
+	if signatures[senderPid] != nil {
+		return false
+	}
+	data := signedData(message.Stage, bInstance, message.Value)
+	if !p.keys.Verify(message.Signature.Signer, data, message.Signature.Signature) {
+		return false
+	}
+
+	signatures[senderPid] = message.Signature.Signature
+	return true
+}
+
+func (p *Process) processProtocolMessage(
+	senderPid ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.AuthenticatedProtocolMessage,
+) {
+	value := message.Value
+
+	if p.delivered(bInstance, value) {
+		return
+	}
+
+	msgState := p.initMessageState(bInstance)
+	msgState.receivedMessagesCnt++
+
+	switch message.Stage {
+	case messages.AuthenticatedProtocolMessage_SEND:
+		if int32(senderPid) != bInstance.Author || msgState.sentEcho {
+			return
+		}
+		msgState.sentEcho = true
+		p.sendProtocolMessage(
+			ProcessId(bInstance.Author),
+			bInstance,
+			&messages.AuthenticatedProtocolMessage{
+				Stage:     messages.AuthenticatedProtocolMessage_ECHO,
+				Value:     value,
+				Signature: p.sign(messages.AuthenticatedProtocolMessage_ECHO, bInstance, value),
+			})
+	case messages.AuthenticatedProtocolMessage_ECHO:
+		if msgState.sentEchoCertificate ||
+			!p.collectSignature(senderPid, bInstance, message, msgState.echoSignatures) {
+			return
+		}
+		if len(msgState.echoSignatures) >= p.echoCertificateSize {
+			msgState.sentEchoCertificate = true
+			p.broadcast(
+				bInstance,
+				&messages.AuthenticatedProtocolMessage{
+					Stage:       messages.AuthenticatedProtocolMessage_ECHO_CERTIFICATE,
+					Value:       value,
+					Certificate: makeCertificate(msgState.echoSignatures),
+				})
+		}
+	case messages.AuthenticatedProtocolMessage_ECHO_CERTIFICATE:
+		if msgState.sentReady {
+			return
+		}
+		data := signedData(messages.AuthenticatedProtocolMessage_ECHO, bInstance, value)
+		if !validCertificate(p.keys, message.Certificate, data, p.echoCertificateSize) {
+			return
+		}
+		if !p.doubleEcho {
+			p.deliver(bInstance, value)
+			return
+		}
+		msgState.sentReady = true
+		p.sendProtocolMessage(
+			ProcessId(bInstance.Author),
+			bInstance,
+			&messages.AuthenticatedProtocolMessage{
+				Stage:     messages.AuthenticatedProtocolMessage_READY,
+				Value:     value,
+				Signature: p.sign(messages.AuthenticatedProtocolMessage_READY, bInstance, value),
+			})
+	case messages.AuthenticatedProtocolMessage_READY:
+		if !p.doubleEcho || msgState.sentReadyCertificate ||
+			!p.collectSignature(senderPid, bInstance, message, msgState.readySignatures) {
+			return
+		}
+		if len(msgState.readySignatures) >= p.readyCertificateSize {
+			msgState.sentReadyCertificate = true
+			p.broadcast(
+				bInstance,
+				&messages.AuthenticatedProtocolMessage{
+					Stage:       messages.AuthenticatedProtocolMessage_READY_CERTIFICATE,
+					Value:       value,
+					Certificate: makeCertificate(msgState.readySignatures),
+				})
+		}
+	case messages.AuthenticatedProtocolMessage_READY_CERTIFICATE:
+		if !p.doubleEcho {
+			return
+		}
+		data := signedData(messages.AuthenticatedProtocolMessage_READY, bInstance, value)
+		if !validCertificate(p.keys, message.Certificate, data, p.readyCertificateSize) {
+			return
+		}
+		p.deliver(bInstance, value)
+		// The author might be faulty and send the certificate only to some processes
+		p.broadcast(bInstance, message)
+	}
+}
+
+func (p *Process) HandleMessage(
+	sender int32,
+	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
+) {
+	if e := protocols.ValidateMessage(sender, broadcastInstanceMessage, p.n); e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+	protocolMessage, e := p.codec.Unpack(broadcastInstanceMessage)
+	if e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+
+	p.processProtocolMessage(
+		ProcessId(sender),
+		broadcastInstanceMessage.BroadcastInstance,
+		protocolMessage.(*messages.AuthenticatedProtocolMessage),
+	)
+}
+
+func (p *Process) Broadcast(value int32) {
+	broadcastInstance := &messages.BroadcastInstance{
+		Author:    p.processIndex,
+		SeqNumber: p.transactionCounter,
+	}
+	p.ownValues[p.transactionCounter] = value
+
+	p.broadcast(
+		broadcastInstance,
+		&messages.AuthenticatedProtocolMessage{
+			Stage: messages.AuthenticatedProtocolMessage_SEND,
+			Value: value,
+		})
+
+	p.logger.OnTransactionInit(broadcastInstance)
+
+	p.transactionCounter++
+}
@@ -0,0 +1,40 @@
+package authenticated
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/protocoltest"
+	"testing"
+	"time"
+)
+
+func TestProcess_deliversWithSilentProcess(t *testing.T) {
+	for _, protocolName := range []string{SignedEcho, DoubleEcho} {
+		protocol, e := protocols.Get(protocolName)
+		assert.Nil(t, e)
+		nw := protocoltest.StartNetwork(
+			&parameters.Parameters{ProcessCount: 4, FaultyProcesses: 1, Protocol: protocol.NewParameters()},
+			protocol.NewProcess,
+		)
+		nw.Silent[3] = true
+
+		largestCertificate := 0
+		nw.Observe = func(packet protocoltest.Packet) {
+			protocolMessage, e := protocol.Codec.Unpack(packet.Message.GetBroadcastInstanceMessage())
+			assert.Nil(t, e)
+			certificate := protocolMessage.(*messages.AuthenticatedProtocolMessage).Certificate
+			if len(certificate) > largestCertificate {
+				largestCertificate = len(certificate)
+			}
+		}
+
+		nw.Processes[0].Process.Broadcast(42)
+		nw.RouteUntilDelivered(t, 0, 10*time.Second)
+
+		assert.Equal(t, map[int32]int32{0: 42, 1: 42, 2: 42}, nw.DeliveredValues(0), protocolName)
+		// Certificates list the signatures of a quorum of 3 out of 4 processes, which are not aggregated
+		assert.Equal(t, 3, largestCertificate, protocolName)
+	}
+}
@@ -0,0 +1,44 @@
+package authenticated
+
+import (
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+)
+
+const (
+	SignedEcho = "signed_echo"
+	DoubleEcho = "authenticated_double_echo"
+)
+
+func newCodec(protocol string) protocols.MessageCodec {
+	return protocols.NewPayloadCodec(protocol, func() proto.Message {
+		return &messages.AuthenticatedProtocolMessage{}
+	})
+}
+
+func init() {
+	protocols.Register(&protocols.Protocol{
+		Name:        SignedEcho,
+		Description: "byzantine consistent broadcast protocol based on signed echo certificates",
+		NewProcess: func() protocols.Process {
+			return &Process{codec: newCodec(SignedEcho)}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{}
+		},
+		Codec: newCodec(SignedEcho),
+	})
+	protocols.Register(&protocols.Protocol{
+		Name:        DoubleEcho,
+		Description: "byzantine reliable broadcast protocol based on signed echo and ready certificates",
+		NewProcess: func() protocols.Process {
+			return &Process{codec: newCodec(DoubleEcho), doubleEcho: true}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{doubleEcho: true}
+		},
+		Codec: newCodec(DoubleEcho),
+	})
+}
@@ -0,0 +1,22 @@
+package bracha
+
+import "stochastic-checking-simulation/impl/parameters"
+
+// Parameters of the Bracha protocol. The protocol is fully defined by n and f,
+// so it does not declare any parameters of its own.
+type Parameters struct{}
+
+func (bp *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	if 3*p.FaultyProcesses >= p.ProcessCount {
+		v.Errorf("bracha requires n > 3f, got n=%d and f=%d", p.ProcessCount, p.FaultyProcesses)
+	}
+}
+
+func (bp *Parameters) Thresholds(p *parameters.Parameters) []parameters.Threshold {
+	f := p.FaultyProcesses
+	return []parameters.Threshold{
+		{Name: "messagesForEcho", Value: parameters.QuorumThreshold(p.ProcessCount, f)},
+		{Name: "messagesForReady", Value: parameters.ReadyMessagesThreshold(f)},
+		{Name: "messagesForDelivery", Value: 2*f + 1},
+	}
+}
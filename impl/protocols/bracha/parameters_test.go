@@ -0,0 +1,15 @@
+package bracha
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/parameters"
+	"testing"
+)
+
+func TestValidate_tooManyFaultyProcesses(t *testing.T) {
+	p := &parameters.Parameters{ProcessCount: 3, FaultyProcesses: 1, Protocol: &Parameters{}}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "n > 3f")
+}
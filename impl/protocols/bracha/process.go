@@ -2,11 +2,12 @@ package bracha
 
 import (
 	"fmt"
-	"math"
 	"stochastic-checking-simulation/context"
 	"stochastic-checking-simulation/impl/eventlogger"
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/wal"
 )
 
 type Stage int
@@ -17,6 +18,28 @@ const (
 	SentReady
 )
 
+func (s Stage) String() string {
+	switch s {
+	case SentEcho:
+		return "sent_echo"
+	case SentReady:
+		return "sent_ready"
+	default:
+		return "init"
+	}
+}
+
+func parseStage(stage string) Stage {
+	switch stage {
+	case SentEcho.String():
+		return SentEcho
+	case SentReady.String():
+		return SentReady
+	default:
+		return Init
+	}
+}
+
 type ProcessId int32
 
 type messageState struct {
@@ -31,6 +54,21 @@ type messageState struct {
 	receivedMessagesCnt int
 }
 
+// count counts the echo or ready message of the sender, and returns false if a message of the same stage
+// of the sender was counted before.
+func (ms *messageState) count(sender ProcessId, stage messages.BrachaProtocolMessage_Stage, value int32) bool {
+	received, count := ms.receivedEcho, ms.echoCount
+	if stage == messages.BrachaProtocolMessage_READY {
+		received, count = ms.receivedReady, ms.readyCount
+	}
+	if received[sender] {
+		return false
+	}
+	received[sender] = true
+	count[value]++
+	return true
+}
+
 func newMessageState() *messageState {
 	ms := new(messageState)
 	ms.receivedEcho = make(map[ProcessId]bool)
@@ -54,34 +92,35 @@ type Process struct {
 	transactionsLog       map[ProcessId]map[int32]*messageState
 
 	n                   int
+	f                   int
+	membership          *protocols.Membership
 	messagesForEcho     int
 	messagesForReady    int
 	messagesForDelivery int
 
-	context                      *context.ReliableContext
-	logger                       *eventlogger.EventLogger
-	ownDeliveredTransactions     chan bool
-	sendOwnDeliveredTransactions bool
+	context         *context.ReliableContext
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
+
+	// Write-ahead log, set if the process is recoverable after a crash
+	wal *wal.Log
 }
 
 func (p *Process) InitProcess(
 	processIndex int32,
 	actorPids []string,
-	parameters *parameters.Parameters,
+	params *parameters.Parameters,
 	context *context.ReliableContext,
 	logger *eventlogger.EventLogger,
-	ownDeliveredTransactions chan bool,
-	sendOwnDeliveredTransactions bool,
+	deliveryHandler protocols.DeliveryHandler,
 ) {
 	p.processIndex = processIndex
 	p.n = len(actorPids)
-	f := parameters.FaultyProcesses
+	p.f = params.FaultyProcesses
 
 	p.transactionCounter = 0
 
-	p.messagesForEcho = int(math.Ceil(float64(p.n+f+1) / float64(2)))
-	p.messagesForReady = f + 1
-	p.messagesForDelivery = 2*f + 1
+	p.Reconfigure(protocols.FullMembership(p.n))
 
 	p.deliveredTransactions = make(map[ProcessId]map[int32]int32)
 	p.transactionsLog = make(map[ProcessId]map[int32]*messageState)
@@ -92,8 +131,93 @@ func (p *Process) InitProcess(
 
 	p.context = context
 	p.logger = logger
-	p.ownDeliveredTransactions = ownDeliveredTransactions
-	p.sendOwnDeliveredTransactions = sendOwnDeliveredTransactions
+	p.deliveryHandler = deliveryHandler
+}
+
+// Reconfigure makes the process exchange messages only with the members of the new epoch
+// and derive the thresholds from their number.
+func (p *Process) Reconfigure(membership *protocols.Membership) {
+	p.membership = membership
+
+	p.messagesForEcho = parameters.QuorumThreshold(membership.Size(), p.f)
+	p.messagesForReady = parameters.ReadyMessagesThreshold(p.f)
+	p.messagesForDelivery = 2*p.f + 1
+}
+
+// Recover restores delivered transactions, the transaction counter, and the stages and counted echo and ready
+// messages of undelivered transactions from the write-ahead log. Records are appended before the messages they
+// describe are sent, so the process sends the messages of its undelivered broadcasts and of the restored stages again,
+// which other processes ignore if they have received them before the crash. Transactions which were not delivered
+// before the crash are delivered once enough of the messages retransmitted by other processes are received.
+func (p *Process) Recover(log *wal.Log) {
+	var resent []wal.Record
+	for _, record := range log.Records() {
+		bInstance := record.BroadcastInstance()
+		author := ProcessId(bInstance.Author)
+
+		switch record.Type {
+		case wal.BroadcastRecord:
+			p.transactionCounter = bInstance.SeqNumber + 1
+			resent = append(resent, record)
+		case wal.StageRecord:
+			msgState := p.initMessageState(bInstance)
+			if stage := parseStage(record.Stage); stage > msgState.stage {
+				msgState.stage = stage
+				resent = append(resent, record)
+			}
+		case wal.ReceivedRecord:
+			p.initMessageState(bInstance).count(
+				ProcessId(record.Sender),
+				messages.BrachaProtocolMessage_Stage(messages.BrachaProtocolMessage_Stage_value[record.Stage]),
+				record.Value)
+		case wal.DeliveryRecord:
+			p.deliveredTransactions[author][bInstance.SeqNumber] = record.Value
+			delete(p.transactionsLog[author], bInstance.SeqNumber)
+		}
+	}
+
+	p.wal = log
+	p.logger.OnRecovered(len(log.Records()), p.transactionCounter)
+
+	// Delivered transactions are not sent again, since 2f+1 processes have already sent ready messages for them
+	for _, record := range resent {
+		bInstance := record.BroadcastInstance()
+		if _, delivered := p.deliveredTransactions[ProcessId(bInstance.Author)][bInstance.SeqNumber]; delivered {
+			continue
+		}
+		stage := messages.BrachaProtocolMessage_INITIAL
+		if record.Type == wal.StageRecord {
+			stage = messages.BrachaProtocolMessage_ECHO
+			if parseStage(record.Stage) == SentReady {
+				stage = messages.BrachaProtocolMessage_READY
+			}
+		}
+		p.broadcast(bInstance, &messages.BrachaProtocolMessage{Stage: stage, Value: record.Value})
+	}
+
+	// Thresholds might have been reached by messages counted right before the crash
+	for author, transactions := range p.transactionsLog {
+		for seqNumber, msgState := range transactions {
+			bInstance := &messages.BroadcastInstance{Author: int32(author), SeqNumber: seqNumber}
+			for _, counts := range []map[int32]int{msgState.echoCount, msgState.readyCount} {
+				for value := range counts {
+					if _, delivered := p.deliveredTransactions[author][seqNumber]; !delivered {
+						p.advance(bInstance, value, msgState)
+					}
+				}
+			}
+		}
+	}
+}
+
+// persist appends the record to the write-ahead log if the process is recoverable.
+func (p *Process) persist(record wal.Record) {
+	if p.wal == nil {
+		return
+	}
+	if e := p.wal.Append(record); e != nil {
+		p.logger.Fatal(fmt.Sprintf("Could not write to the write-ahead log: %v", e))
+	}
 }
 
 func (p *Process) initMessageState(bInstance *messages.BroadcastInstance) *messageState {
@@ -130,8 +254,8 @@ func (p *Process) broadcast(
 	bInstance *messages.BroadcastInstance,
 	message *messages.BrachaProtocolMessage,
 ) {
-	for i := 0; i < p.n; i++ {
-		p.sendProtocolMessage(ProcessId(i), bInstance, message)
+	for _, pid := range p.membership.Members() {
+		p.sendProtocolMessage(ProcessId(pid), bInstance, message)
 	}
 }
 
@@ -140,6 +264,7 @@ func (p *Process) broadcastEcho(
 	value int32,
 	msgState *messageState,
 ) {
+	p.persist(wal.Stage(bInstance, SentEcho.String(), value))
 	p.broadcast(
 		bInstance,
 		&messages.BrachaProtocolMessage{
@@ -154,6 +279,7 @@ func (p *Process) broadcastReady(
 	value int32,
 	msgState *messageState,
 ) {
+	p.persist(wal.Stage(bInstance, SentReady.String(), value))
 	p.broadcast(
 		bInstance,
 		&messages.BrachaProtocolMessage{
@@ -181,17 +307,16 @@ func (p *Process) deliver(
 	bInstance *messages.BroadcastInstance,
 	value int32,
 ) {
+	p.persist(wal.Delivery(bInstance, value))
+
 	author := ProcessId(bInstance.Author)
 	p.deliveredTransactions[author][bInstance.SeqNumber] = value
 	messagesReceived :=
 		p.transactionsLog[author][bInstance.SeqNumber].receivedMessagesCnt
 
-	if p.sendOwnDeliveredTransactions && bInstance.Author == p.processIndex {
-		p.ownDeliveredTransactions <- true
-	}
-
 	delete(p.transactionsLog[author], bInstance.SeqNumber)
 	p.logger.OnDeliver(bInstance, value, messagesReceived)
+	p.deliveryHandler.Deliver(bInstance, value)
 }
 
 func (p *Process) processProtocolMessage(
@@ -201,7 +326,8 @@ func (p *Process) processProtocolMessage(
 ) {
 	value := message.Value
 
-	if p.delivered(bInstance, value) {
+	// Processes which are not members of the current epoch take no part in the protocol
+	if !p.membership.Contains(int32(senderPid)) || p.delivered(bInstance, value) {
 		return
 	}
 
@@ -213,46 +339,45 @@ func (p *Process) processProtocolMessage(
 		if msgState.stage == Init {
 			p.broadcastEcho(bInstance, value, msgState)
 		}
-	case messages.BrachaProtocolMessage_ECHO:
-		if msgState.stage == SentReady || msgState.receivedEcho[senderPid] {
+	case messages.BrachaProtocolMessage_ECHO, messages.BrachaProtocolMessage_READY:
+		if message.Stage == messages.BrachaProtocolMessage_ECHO && msgState.stage == SentReady {
 			return
 		}
-		msgState.receivedEcho[senderPid] = true
-		msgState.echoCount[value]++
-
-		if msgState.echoCount[value] >= p.messagesForEcho {
-			if msgState.stage == Init {
-				p.broadcastEcho(bInstance, value, msgState)
-			}
-			if msgState.stage == SentEcho {
-				p.broadcastReady(bInstance, value, msgState)
-			}
-		}
-	case messages.BrachaProtocolMessage_READY:
-		if msgState.receivedReady[senderPid] {
+		if !msgState.count(senderPid, message.Stage, value) {
 			return
 		}
-		msgState.receivedReady[senderPid] = true
-		msgState.readyCount[value]++
+		p.persist(wal.Received(int32(senderPid), bInstance, message.Stage.String(), value))
+		p.advance(bInstance, value, msgState)
+	}
+}
 
-		if msgState.readyCount[value] >= p.messagesForReady {
-			if msgState.stage == Init {
-				p.broadcastEcho(bInstance, value, msgState)
-			}
-			if msgState.stage == SentEcho {
-				p.broadcastReady(bInstance, value, msgState)
-			}
+// advance sends the echo and ready messages of the value and delivers it once enough messages are counted.
+func (p *Process) advance(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+	msgState *messageState,
+) {
+	if msgState.echoCount[value] >= p.messagesForEcho || msgState.readyCount[value] >= p.messagesForReady {
+		if msgState.stage == Init {
+			p.broadcastEcho(bInstance, value, msgState)
 		}
-		if msgState.readyCount[value] >= p.messagesForDelivery {
-			p.deliver(bInstance, value)
+		if msgState.stage == SentEcho {
+			p.broadcastReady(bInstance, value, msgState)
 		}
 	}
+	if msgState.readyCount[value] >= p.messagesForDelivery {
+		p.deliver(bInstance, value)
+	}
 }
 
 func (p *Process) HandleMessage(
 	sender int32,
 	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
 ) {
+	if e := protocols.ValidateMessage(sender, broadcastInstanceMessage, p.n); e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
 	bInstance := broadcastInstanceMessage.BroadcastInstance
 
 	switch protocolMessage := broadcastInstanceMessage.Message.(type) {
@@ -263,7 +388,7 @@ func (p *Process) HandleMessage(
 			protocolMessage.BrachaProtocolMessage,
 		)
 	default:
-		p.logger.Fatal(fmt.Sprintf("Invalid protocol message type %t", protocolMessage))
+		p.logger.OnMessageRejected(sender, fmt.Sprintf("unexpected protocol message type %T", protocolMessage))
 	}
 }
 
@@ -273,6 +398,7 @@ func (p *Process) Broadcast(value int32) {
 		SeqNumber: p.transactionCounter,
 	}
 
+	p.persist(wal.Broadcast(broadcastInstance, value))
 	p.broadcast(
 		broadcastInstance,
 		&messages.BrachaProtocolMessage{
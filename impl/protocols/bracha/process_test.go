@@ -0,0 +1,139 @@
+package bracha
+
+import (
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/protocoltest"
+	"testing"
+)
+
+type testProcess struct {
+	*protocoltest.Process
+	process *Process
+}
+
+func testParameters() *parameters.Parameters {
+	return &parameters.Parameters{ProcessCount: 4, FaultyProcesses: 1, Protocol: &Parameters{}}
+}
+
+// startProcess starts the process with the given index out of 4 recovering from the write-ahead log at the given path,
+// which delivers transactions to the given handler.
+func startProcess(t *testing.T, index int32, path string, handler *protocoltest.Handler) *testProcess {
+	process := &Process{}
+	return &testProcess{
+		Process: protocoltest.StartRecoverable(t, process, index, testParameters(), path, handler),
+		process: process,
+	}
+}
+
+func (p *testProcess) receive(
+	sender int32,
+	bInstance *messages.BroadcastInstance,
+	stage messages.BrachaProtocolMessage_Stage,
+	value int32,
+) {
+	p.process.HandleMessage(sender, &messages.BroadcastInstanceMessage{
+		BroadcastInstance: bInstance,
+		Message: &messages.BroadcastInstanceMessage_BrachaProtocolMessage{
+			BrachaProtocolMessage: &messages.BrachaProtocolMessage{Stage: stage, Value: value},
+		},
+	})
+}
+
+func TestProcess_recoversAfterCrash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "process0.wal")
+	delivered := &messages.BroadcastInstance{Author: 1, SeqNumber: 0}
+	echoed := &messages.BroadcastInstance{Author: 2, SeqNumber: 0}
+
+	p := startProcess(t, 0, path, &protocoltest.Handler{})
+	p.process.Broadcast(10)
+	for sender := int32(1); sender < 4; sender++ {
+		p.receive(sender, delivered, messages.BrachaProtocolMessage_ECHO, 11)
+	}
+	for sender := int32(1); sender < 4; sender++ {
+		p.receive(sender, delivered, messages.BrachaProtocolMessage_READY, 11)
+	}
+	p.receive(2, echoed, messages.BrachaProtocolMessage_INITIAL, 12)
+	assert.Equal(t, []*messages.BroadcastInstance{delivered}, p.Handler.Delivered())
+
+	sentBeforeCrash := p.Sent(t)
+	lastStamp := sentBeforeCrash[len(sentBeforeCrash)-1].Stamp
+	assert.Nil(t, p.Log.Close())
+
+	p = startProcess(t, 0, path, &protocoltest.Handler{})
+
+	// The initial message of the undelivered own transaction and the echo are sent again
+	resent := p.Sent(t)
+	assert.Len(t, resent, 8)
+	for _, msg := range resent {
+		assert.Greater(t, msg.Stamp, lastStamp)
+		lastStamp = msg.Stamp
+	}
+
+	// Transactions delivered before the crash are not delivered again
+	for sender := int32(1); sender < 4; sender++ {
+		p.receive(sender, delivered, messages.BrachaProtocolMessage_READY, 11)
+	}
+	assert.Empty(t, p.Handler.Delivered())
+
+	// The process does not echo a transaction it has already echoed
+	p.receive(2, echoed, messages.BrachaProtocolMessage_INITIAL, 12)
+	assert.Empty(t, p.Sent(t))
+
+	// Neither seq numbers nor message stamps are reused
+	p.process.Broadcast(13)
+	sent := p.Sent(t)
+	assert.Len(t, sent, 4)
+	for _, msg := range sent {
+		assert.Greater(t, msg.Stamp, lastStamp)
+		assert.Equal(t, int32(1), msg.GetBroadcastInstanceMessage().BroadcastInstance.SeqNumber)
+	}
+}
+
+func TestProcess_reconfigure(t *testing.T) {
+	p := startProcess(t, 0, filepath.Join(t.TempDir(), "process0.wal"), &protocoltest.Handler{})
+	p.process.Reconfigure(protocols.NewMembership(1, []int32{0, 1, 2}))
+
+	// Process 3 is not a member, so its messages are ignored, and it does not receive messages
+	bInstance := &messages.BroadcastInstance{Author: 3, SeqNumber: 0}
+	p.receive(3, bInstance, messages.BrachaProtocolMessage_INITIAL, 5)
+	assert.Empty(t, p.Sent(t))
+
+	bInstance = &messages.BroadcastInstance{Author: 1, SeqNumber: 0}
+	p.receive(1, bInstance, messages.BrachaProtocolMessage_INITIAL, 5)
+	sent := p.Sent(t)
+	assert.Len(t, sent, 3)
+
+	// With 3 members and f = 1 two ready messages are not enough for delivery
+	p.receive(1, bInstance, messages.BrachaProtocolMessage_READY, 5)
+	p.receive(2, bInstance, messages.BrachaProtocolMessage_READY, 5)
+	p.receive(3, bInstance, messages.BrachaProtocolMessage_READY, 5)
+	assert.Empty(t, p.Handler.Delivered())
+
+	p.receive(0, bInstance, messages.BrachaProtocolMessage_READY, 5)
+	assert.Equal(t, []*messages.BroadcastInstance{bInstance}, p.Handler.Delivered())
+}
+
+func TestProcess_deliversAfterCrashAtAnyPoint(t *testing.T) {
+	for _, author := range []int32{0, 1} {
+		for crashAfter := 0; crashAfter <= 8; crashAfter++ {
+			for _, reverse := range []bool{false, true} {
+				// Process 3 is silent, so transactions are only delivered with the messages of all other processes
+				nw := protocoltest.StartCrashNetwork(
+					t, testParameters(), func() protocols.Process { return &Process{} }, map[int32]bool{3: true}, reverse)
+				bInstance := &messages.BroadcastInstance{Author: author, SeqNumber: 0}
+
+				nw.Processes[author].Process.Broadcast(7)
+				nw.Route(crashAfter)
+
+				for i, p := range nw.Processes[:3] {
+					assert.Equal(t, []*messages.BroadcastInstance{bInstance}, p.Handler.Delivered(),
+						"process %d, author %d, crash after %d messages, reverse order %t", i, author, crashAfter, reverse)
+				}
+			}
+		}
+	}
+}
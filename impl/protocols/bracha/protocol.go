@@ -0,0 +1,21 @@
+package bracha
+
+import (
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+)
+
+func init() {
+	protocols.Register(&protocols.Protocol{
+		Name:        "bracha",
+		Description: "Bracha protocol, a classical implementation of byzantine reliable broadcast",
+		NewProcess: func() protocols.Process {
+			return &Process{}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{}
+		},
+		Codec: protocols.NewOneofCodec(&messages.BrachaProtocolMessage{}),
+	})
+}
@@ -0,0 +1,138 @@
+package protocols
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"stochastic-checking-simulation/impl/messages"
+)
+
+// MessageCodec interface converts messages of a protocol to BroadcastInstanceMessage and back.
+// It exports two methods:
+// Pack wraps a message of the protocol sent within the given broadcast instance;
+// Unpack extracts a message of the protocol, failing if the message belongs to another protocol.
+type MessageCodec interface {
+	Pack(
+		bInstance *messages.BroadcastInstance,
+		message proto.Message,
+	) (*messages.BroadcastInstanceMessage, error)
+
+	Unpack(message *messages.BroadcastInstanceMessage) (proto.Message, error)
+}
+
+var broadcastInstanceMessageOneof = (&messages.BroadcastInstanceMessage{}).
+	ProtoReflect().Descriptor().Oneofs().ByName("message")
+
+// OneofCodec is a codec for protocols whose messages have their own cases
+// in the oneof of BroadcastInstanceMessage.
+type OneofCodec struct {
+	fields map[protoreflect.FullName]protoreflect.FieldDescriptor
+}
+
+// NewOneofCodec creates a codec for the given types of messages,
+// each of them must be a case of the oneof of BroadcastInstanceMessage.
+func NewOneofCodec(messageTypes ...proto.Message) *OneofCodec {
+	c := &OneofCodec{fields: make(map[protoreflect.FullName]protoreflect.FieldDescriptor)}
+
+	oneofFields := broadcastInstanceMessageOneof.Fields()
+	for _, messageType := range messageTypes {
+		name := messageType.ProtoReflect().Descriptor().FullName()
+		for i := 0; i < oneofFields.Len(); i++ {
+			if field := oneofFields.Get(i); field.Message() != nil && field.Message().FullName() == name {
+				c.fields[name] = field
+			}
+		}
+		if c.fields[name] == nil {
+			panic(fmt.Sprintf("%s is not a case of BroadcastInstanceMessage", name))
+		}
+	}
+
+	return c
+}
+
+func (c *OneofCodec) Pack(
+	bInstance *messages.BroadcastInstance,
+	message proto.Message,
+) (*messages.BroadcastInstanceMessage, error) {
+	field := c.fields[message.ProtoReflect().Descriptor().FullName()]
+	if field == nil {
+		return nil, fmt.Errorf("unexpected message type %T", message)
+	}
+
+	bMessage := &messages.BroadcastInstanceMessage{BroadcastInstance: bInstance}
+	bMessage.ProtoReflect().Set(field, protoreflect.ValueOfMessage(message.ProtoReflect()))
+
+	return bMessage, nil
+}
+
+func (c *OneofCodec) Unpack(message *messages.BroadcastInstanceMessage) (proto.Message, error) {
+	field := message.ProtoReflect().WhichOneof(broadcastInstanceMessageOneof)
+	if field == nil {
+		return nil, errors.New("protocol message is missing")
+	}
+	if c.fields[field.Message().FullName()] == nil {
+		return nil, fmt.Errorf("unexpected protocol message type %s", field.Message().FullName())
+	}
+	return message.ProtoReflect().Get(field).Message().Interface(), nil
+}
+
+// PayloadCodec is a codec for protocols which do not have their own cases
+// in the oneof of BroadcastInstanceMessage. Their messages are serialized
+// into the payload of GenericProtocolMessage.
+type PayloadCodec struct {
+	protocol   string
+	newMessage func() proto.Message
+}
+
+// NewPayloadCodec creates a codec for the given protocol, all messages of which
+// are of the type returned by newMessage.
+func NewPayloadCodec(protocol string, newMessage func() proto.Message) *PayloadCodec {
+	return &PayloadCodec{
+		protocol:   protocol,
+		newMessage: newMessage,
+	}
+}
+
+func (c *PayloadCodec) Pack(
+	bInstance *messages.BroadcastInstance,
+	message proto.Message,
+) (*messages.BroadcastInstanceMessage, error) {
+	expectedName := c.newMessage().ProtoReflect().Descriptor().FullName()
+	if name := message.ProtoReflect().Descriptor().FullName(); name != expectedName {
+		return nil, fmt.Errorf("unexpected message type %s, protocol %s uses %s", name, c.protocol, expectedName)
+	}
+
+	payload, e := proto.Marshal(message)
+	if e != nil {
+		return nil, e
+	}
+
+	return &messages.BroadcastInstanceMessage{
+		BroadcastInstance: bInstance,
+		Message: &messages.BroadcastInstanceMessage_GenericProtocolMessage{
+			GenericProtocolMessage: &messages.GenericProtocolMessage{
+				Protocol: c.protocol,
+				Payload:  payload,
+			},
+		},
+	}, nil
+}
+
+func (c *PayloadCodec) Unpack(message *messages.BroadcastInstanceMessage) (proto.Message, error) {
+	genericMessage := message.GetGenericProtocolMessage()
+	if genericMessage == nil {
+		return nil, fmt.Errorf("protocol %s expects a generic protocol message", c.protocol)
+	}
+	if genericMessage.Protocol != c.protocol {
+		return nil, fmt.Errorf(
+			"message of protocol %s was received by protocol %s", genericMessage.Protocol, c.protocol)
+	}
+
+	protocolMessage := c.newMessage()
+	e := proto.Unmarshal(genericMessage.Payload, protocolMessage)
+	if e != nil {
+		return nil, e
+	}
+	return protocolMessage, nil
+}
@@ -0,0 +1,91 @@
+package protocols
+
+import (
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/impl/messages"
+	"testing"
+)
+
+var bInstance = &messages.BroadcastInstance{Author: 1, SeqNumber: 2}
+
+func TestOneofCodec_packUnpack(t *testing.T) {
+	codec := NewOneofCodec(&messages.ReliableProtocolMessage{}, &messages.RecoveryProtocolMessage{})
+	message := &messages.RecoveryProtocolMessage{
+		Stage:                   messages.RecoveryProtocolMessage_ECHO,
+		ReliableProtocolMessage: &messages.ReliableProtocolMessage{Value: 5},
+	}
+
+	packed, e := codec.Pack(bInstance, message)
+	assert.NoError(t, e)
+	assert.True(t, proto.Equal(message, packed.GetRecoveryProtocolMessage()))
+	assert.True(t, proto.Equal(bInstance, packed.BroadcastInstance))
+
+	unpacked, e := codec.Unpack(packed)
+	assert.NoError(t, e)
+	assert.True(t, proto.Equal(message, unpacked))
+}
+
+func TestOneofCodec_rejectsOtherProtocols(t *testing.T) {
+	codec := NewOneofCodec(&messages.BrachaProtocolMessage{})
+
+	_, e := codec.Pack(bInstance, &messages.ScalableProtocolMessage{})
+	assert.Error(t, e)
+
+	_, e = codec.Unpack(&messages.BroadcastInstanceMessage{
+		BroadcastInstance: bInstance,
+		Message: &messages.BroadcastInstanceMessage_ScalableProtocolMessage{
+			ScalableProtocolMessage: &messages.ScalableProtocolMessage{},
+		},
+	})
+	assert.Error(t, e)
+
+	_, e = codec.Unpack(&messages.BroadcastInstanceMessage{BroadcastInstance: bInstance})
+	assert.Error(t, e)
+}
+
+func TestNewOneofCodec_panicsOnUnknownCase(t *testing.T) {
+	assert.Panics(t, func() {
+		NewOneofCodec(&messages.BroadcastInstance{})
+	})
+}
+
+func TestPayloadCodec_packUnpack(t *testing.T) {
+	codec := NewPayloadCodec("test_protocol", func() proto.Message {
+		return &messages.BrachaProtocolMessage{}
+	})
+	message := &messages.BrachaProtocolMessage{Stage: messages.BrachaProtocolMessage_READY, Value: 7}
+
+	packed, e := codec.Pack(bInstance, message)
+	assert.NoError(t, e)
+	assert.Equal(t, "test_protocol", packed.GetGenericProtocolMessage().Protocol)
+
+	unpacked, e := codec.Unpack(packed)
+	assert.NoError(t, e)
+	assert.True(t, proto.Equal(message, unpacked))
+}
+
+func TestPayloadCodec_rejectsOtherProtocols(t *testing.T) {
+	codec := NewPayloadCodec("test_protocol", func() proto.Message {
+		return &messages.BrachaProtocolMessage{}
+	})
+	other := NewPayloadCodec("other_protocol", func() proto.Message {
+		return &messages.BrachaProtocolMessage{}
+	})
+
+	_, e := codec.Pack(bInstance, &messages.ScalableProtocolMessage{})
+	assert.Error(t, e)
+
+	packed, e := other.Pack(bInstance, &messages.BrachaProtocolMessage{})
+	assert.NoError(t, e)
+	_, e = codec.Unpack(packed)
+	assert.ErrorContains(t, e, "other_protocol")
+
+	_, e = codec.Unpack(&messages.BroadcastInstanceMessage{
+		BroadcastInstance: bInstance,
+		Message: &messages.BroadcastInstanceMessage_BrachaProtocolMessage{
+			BrachaProtocolMessage: &messages.BrachaProtocolMessage{},
+		},
+	})
+	assert.Error(t, e)
+}
@@ -0,0 +1,52 @@
+package gossip
+
+import "stochastic-checking-simulation/impl/parameters"
+
+// Parameters of the gossip protocol.
+type Parameters struct {
+	Fanout         int `json:"fanout"`
+	Rounds         int `json:"rounds"`
+	PullIntervalNs int `json:"pull_interval"`
+}
+
+func (gp *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	n := p.ProcessCount
+
+	if gp.Fanout <= 0 {
+		v.Errorf("fanout must be positive, got %d", gp.Fanout)
+	} else if gp.Fanout >= n {
+		v.Warnf("fanout (%d) is not less than n (%d), transactions are pushed to all other processes", gp.Fanout, n)
+	}
+	if gp.Rounds < 0 {
+		v.Errorf("rounds must not be negative, got %d", gp.Rounds)
+	}
+	if gp.PullIntervalNs < 0 {
+		v.Errorf("pull_interval must not be negative, got %d", gp.PullIntervalNs)
+	}
+	if gp.Rounds == 0 && gp.PullIntervalNs == 0 {
+		v.Errorf("either rounds or pull_interval must be positive, otherwise transactions never leave their authors")
+	}
+	if p.FaultyProcesses > 0 {
+		v.Warnf("gossip does not tolerate byzantine processes, f=%d is ignored", p.FaultyProcesses)
+	}
+}
+
+func (gp *Parameters) Thresholds(p *parameters.Parameters) []parameters.Threshold {
+	return []parameters.Threshold{
+		{Name: "maxProcessesReachedByPush", Value: maxProcessesReachedByPush(p.ProcessCount, gp.Fanout, gp.Rounds)},
+	}
+}
+
+// maxProcessesReachedByPush returns the number of processes which receive a transaction
+// during the push phase if the selected peers never overlap.
+func maxProcessesReachedByPush(n int, fanout int, rounds int) int {
+	reached, frontier := 1, 1
+	for i := 0; i < rounds && reached < n; i++ {
+		frontier *= fanout
+		reached += frontier
+	}
+	if reached > n {
+		return n
+	}
+	return reached
+}
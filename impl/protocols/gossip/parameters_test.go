@@ -0,0 +1,38 @@
+package gossip
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/parameters"
+	"testing"
+)
+
+func TestValidate_noDissemination(t *testing.T) {
+	p := &parameters.Parameters{
+		ProcessCount: 4,
+		Protocol:     &Parameters{Fanout: 2, Rounds: 0, PullIntervalNs: 0},
+	}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "either rounds or pull_interval must be positive")
+}
+
+func TestValidate_warnsAboutFaultyProcesses(t *testing.T) {
+	p := &parameters.Parameters{
+		ProcessCount:    4,
+		FaultyProcesses: 1,
+		Protocol:        &Parameters{Fanout: 2, Rounds: 2, PullIntervalNs: 100},
+	}
+
+	warnings, e := p.Validate()
+
+	assert.NoError(t, e)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "does not tolerate byzantine processes")
+}
+
+func TestMaxProcessesReachedByPush(t *testing.T) {
+	assert.Equal(t, 1, maxProcessesReachedByPush(100, 3, 0))
+	assert.Equal(t, 13, maxProcessesReachedByPush(100, 3, 2))
+	assert.Equal(t, 100, maxProcessesReachedByPush(100, 3, 10))
+}
@@ -0,0 +1,377 @@
+package gossip
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"math/rand"
+	"sort"
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"sync"
+	"time"
+)
+
+type ProcessId int32
+
+// pullMessageSize is the largest size of a pull message, which leaves room for the envelope of the message
+// within context.MaxMessageSize. Transactions which do not fit are sent in later pulls.
+const pullMessageSize = context.MaxMessageSize - 256
+
+// Process executes a push-pull epidemic broadcast.
+// A process delivers a transaction the first time it receives it and pushes it
+// to fanout random peers, until the transaction was forwarded the given number of rounds.
+// Periodically a process sends its digest to a random peer, which replies with the transactions
+// missing from the digest, so that transactions the push phase did not reach are eventually delivered.
+// The request also lists transactions delivered past the gaps of the digest, which the reply skips,
+// and both are cut to fit in a datagram, so that a large backlog is pulled over several rounds.
+// Pull messages do not belong to a broadcast instance, they are numbered by the pull rounds of the requesting process,
+// and pulls stop once the context is stopped.
+// The protocol does not tolerate byzantine processes and serves as a baseline for the other protocols.
+type Process struct {
+	processIndex int32
+
+	transactionCounter int32
+	pullCounter        int32
+
+	deliveredTransactions map[ProcessId]map[int32]int32
+	// Number of transactions of each author delivered without gaps
+	deliveredPrefix []int32
+
+	n            int
+	fanout       int
+	rounds       int
+	pullInterval time.Duration
+
+	random *rand.Rand
+	mutex  *sync.Mutex
+
+	context         *context.ReliableContext
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
+}
+
+func (p *Process) InitProcess(
+	processIndex int32,
+	actorPids []string,
+	params *parameters.Parameters,
+	context *context.ReliableContext,
+	logger *eventlogger.EventLogger,
+	deliveryHandler protocols.DeliveryHandler,
+) {
+	p.processIndex = processIndex
+	p.n = len(actorPids)
+
+	protocolParams := params.Protocol.(*Parameters)
+
+	p.transactionCounter = 0
+	p.pullCounter = 0
+
+	p.fanout = protocolParams.Fanout
+	p.rounds = protocolParams.Rounds
+	p.pullInterval = time.Duration(protocolParams.PullIntervalNs)
+
+	p.deliveredTransactions = make(map[ProcessId]map[int32]int32)
+	p.deliveredPrefix = make([]int32, p.n)
+	for index := 0; index < p.n; index++ {
+		p.deliveredTransactions[ProcessId(index)] = make(map[int32]int32)
+	}
+
+	p.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	p.mutex = &sync.Mutex{}
+
+	p.context = context
+	p.logger = logger
+	p.deliveryHandler = deliveryHandler
+
+	if p.pullInterval > 0 {
+		go p.pull()
+	}
+}
+
+func (p *Process) sendProtocolMessage(
+	to ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.GossipProtocolMessage,
+) {
+	bMessage, e := codec.Pack(bInstance, message)
+	if e != nil {
+		p.logger.Fatal(fmt.Sprintf("Could not pack protocol message: %v", e))
+	}
+
+	msg := p.context.MakeNewMessage()
+	msg.Content = &messages.Message_BroadcastInstanceMessage{
+		BroadcastInstanceMessage: bMessage,
+	}
+
+	p.context.Send(int32(to), msg)
+}
+
+// peers returns up to size distinct random processes other than the current one.
+func (p *Process) peers(size int) []ProcessId {
+	peers := make([]ProcessId, 0, p.n-1)
+	for i := 0; i < p.n; i++ {
+		if int32(i) != p.processIndex {
+			peers = append(peers, ProcessId(i))
+		}
+	}
+	p.random.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	if size < len(peers) {
+		peers = peers[:size]
+	}
+	return peers
+}
+
+func (p *Process) push(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+	rounds int,
+) {
+	if rounds <= 0 {
+		return
+	}
+	for _, peer := range p.peers(p.fanout) {
+		p.sendProtocolMessage(
+			peer,
+			bInstance,
+			&messages.GossipProtocolMessage{
+				Stage:  messages.GossipProtocolMessage_PUSH,
+				Value:  value,
+				Rounds: int32(rounds - 1),
+			})
+	}
+}
+
+func (p *Process) delivered(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) bool {
+	deliveredValue, delivered :=
+		p.deliveredTransactions[ProcessId(bInstance.Author)][bInstance.SeqNumber]
+
+	if delivered && deliveredValue != value {
+		p.logger.OnAttack(bInstance, value, deliveredValue)
+	}
+
+	return delivered
+}
+
+// receive delivers the transaction if it was not delivered before.
+// It returns false if the transaction is already delivered.
+// A transaction is delivered upon the first message carrying it.
+func (p *Process) receive(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) bool {
+	if p.delivered(bInstance, value) {
+		return false
+	}
+
+	author := ProcessId(bInstance.Author)
+	p.deliveredTransactions[author][bInstance.SeqNumber] = value
+	for {
+		_, delivered := p.deliveredTransactions[author][p.deliveredPrefix[author]]
+		if !delivered {
+			break
+		}
+		p.deliveredPrefix[author]++
+	}
+
+	p.logger.OnDeliver(bInstance, value, 1)
+	p.deliveryHandler.Deliver(bInstance, value)
+	return true
+}
+
+func (p *Process) pull() {
+	ticker := time.NewTicker(p.pullInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if p.context.Stopped() {
+			return
+		}
+
+		p.mutex.Lock()
+		p.requestPull()
+		p.mutex.Unlock()
+	}
+}
+
+// deliveredFrom returns the sequence numbers of the delivered transactions of the author
+// from the given one on, in increasing order.
+func (p *Process) deliveredFrom(author ProcessId, from int32) []int32 {
+	var seqNumbers []int32
+	for seqNumber := range p.deliveredTransactions[author] {
+		if seqNumber >= from {
+			seqNumbers = append(seqNumbers, seqNumber)
+		}
+	}
+	sort.Slice(seqNumbers, func(i, j int) bool { return seqNumbers[i] < seqNumbers[j] })
+	return seqNumbers
+}
+
+// elementSize returns the number of bytes the message takes as an element of a repeated field.
+func elementSize(message proto.Message) int {
+	return 1 + protowire.SizeBytes(proto.Size(message))
+}
+
+// requestPull sends the digest of delivered transactions to a random peer,
+// with as many of the transactions delivered past the gaps of the digest as fit in the message.
+func (p *Process) requestPull() {
+	request := &messages.GossipProtocolMessage{
+		Stage:  messages.GossipProtocolMessage_PULL_REQUEST,
+		Digest: make([]int32, p.n),
+		Pull:   p.pullCounter,
+	}
+	copy(request.Digest, p.deliveredPrefix)
+
+	size := proto.Size(request)
+fill:
+	for author := 0; author < p.n; author++ {
+		// The first transaction past the prefix is missing
+		for _, seqNumber := range p.deliveredFrom(ProcessId(author), request.Digest[author]+1) {
+			bInstance := &messages.BroadcastInstance{Author: int32(author), SeqNumber: seqNumber}
+			size += elementSize(bInstance)
+			if size > pullMessageSize {
+				break fill
+			}
+			request.Held = append(request.Held, bInstance)
+		}
+	}
+
+	for _, peer := range p.peers(1) {
+		p.sendProtocolMessage(peer, nil, request)
+	}
+	p.pullCounter++
+}
+
+// replyToPull sends the transactions missing from the digest of the request which the requesting process
+// does not hold, as many as fit in the reply, in the order of authors and sequence numbers.
+func (p *Process) replyToPull(
+	senderPid ProcessId,
+	request *messages.GossipProtocolMessage,
+) {
+	digest := request.Digest
+	if len(digest) != p.n {
+		return
+	}
+
+	type transaction struct {
+		author    int32
+		seqNumber int32
+	}
+	held := make(map[transaction]bool)
+	for _, bInstance := range request.Held {
+		if bInstance != nil {
+			held[transaction{bInstance.Author, bInstance.SeqNumber}] = true
+		}
+	}
+
+	reply := &messages.GossipProtocolMessage{
+		Stage: messages.GossipProtocolMessage_PULL_REPLY,
+		Pull:  request.Pull,
+	}
+	size := proto.Size(reply)
+fill:
+	for author := 0; author < p.n; author++ {
+		for _, seqNumber := range p.deliveredFrom(ProcessId(author), digest[author]) {
+			if held[transaction{int32(author), seqNumber}] {
+				continue
+			}
+			gossipTransaction := &messages.GossipTransaction{
+				BroadcastInstance: &messages.BroadcastInstance{
+					Author:    int32(author),
+					SeqNumber: seqNumber,
+				},
+				Value: p.deliveredTransactions[ProcessId(author)][seqNumber],
+			}
+			size += elementSize(gossipTransaction)
+			if size > pullMessageSize {
+				break fill
+			}
+			reply.Transactions = append(reply.Transactions, gossipTransaction)
+		}
+	}
+
+	if len(reply.Transactions) == 0 {
+		return
+	}
+
+	p.sendProtocolMessage(senderPid, nil, reply)
+}
+
+func (p *Process) processProtocolMessage(
+	senderPid ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.GossipProtocolMessage,
+) {
+	switch message.Stage {
+	case messages.GossipProtocolMessage_PUSH:
+		if p.receive(bInstance, message.Value) {
+			p.push(bInstance, message.Value, int(message.Rounds))
+		}
+	case messages.GossipProtocolMessage_PULL_REQUEST:
+		p.replyToPull(senderPid, message)
+	case messages.GossipProtocolMessage_PULL_REPLY:
+		for _, transaction := range message.Transactions {
+			if e := protocols.ValidateBroadcastInstance(transaction.BroadcastInstance, p.n); e != nil {
+				p.logger.OnMessageRejected(int32(senderPid), fmt.Sprintf("pulled transaction: %v", e))
+				continue
+			}
+			p.receive(transaction.BroadcastInstance, transaction.Value)
+		}
+	}
+}
+
+func (p *Process) HandleMessage(
+	sender int32,
+	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
+) {
+	if e := protocols.ValidateSender(sender, p.n); e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+	protocolMessage, e := codec.Unpack(broadcastInstanceMessage)
+	if e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+	// Only pushed transactions belong to a broadcast instance
+	gossipMessage := protocolMessage.(*messages.GossipProtocolMessage)
+	if gossipMessage.Stage == messages.GossipProtocolMessage_PUSH {
+		if e := protocols.ValidateBroadcastInstance(broadcastInstanceMessage.BroadcastInstance, p.n); e != nil {
+			p.logger.OnMessageRejected(sender, e.Error())
+			return
+		}
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.processProtocolMessage(
+		ProcessId(sender),
+		broadcastInstanceMessage.BroadcastInstance,
+		gossipMessage,
+	)
+}
+
+func (p *Process) Broadcast(value int32) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	broadcastInstance := &messages.BroadcastInstance{
+		Author:    p.processIndex,
+		SeqNumber: p.transactionCounter,
+	}
+
+	p.logger.OnTransactionInit(broadcastInstance)
+
+	p.receive(broadcastInstance, value)
+	p.push(broadcastInstance, value, p.rounds)
+
+	p.transactionCounter++
+}
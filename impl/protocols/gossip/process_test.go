@@ -0,0 +1,134 @@
+package gossip
+
+import (
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols/protocoltest"
+	"testing"
+	"time"
+)
+
+// startProcess starts the process with the given index out of 2, which pushes transactions to nobody
+// and pulls with the given interval.
+func startProcess(index int32, pullInterval time.Duration) *protocoltest.Process {
+	return protocoltest.StartProcess(
+		&Process{},
+		index,
+		&parameters.Parameters{ProcessCount: 2, Protocol: &Parameters{PullIntervalNs: int(pullInterval)}},
+	)
+}
+
+// next waits for the next message sent by the process and returns it with its gossip protocol message.
+func next(t *testing.T, p *protocoltest.Process) (*messages.Message, *messages.GossipProtocolMessage) {
+	msg := p.Next(t).Message
+	gossipMessage, e := codec.Unpack(msg.GetBroadcastInstanceMessage())
+	assert.Nil(t, e)
+	return msg, gossipMessage.(*messages.GossipProtocolMessage)
+}
+
+func TestProcess_pullsTransactionsMissingFromDigest(t *testing.T) {
+	requester := startProcess(0, time.Millisecond)
+	responder := startProcess(1, 0)
+	defer requester.Context.Stop()
+
+	responder.Process.Broadcast(42)
+	request, requestMessage := next(t, requester)
+	assert.Equal(t, messages.GossipProtocolMessage_PULL_REQUEST, requestMessage.Stage)
+	assert.Equal(t, int32(0), requestMessage.Pull)
+	// Pulls do not belong to a broadcast instance
+	assert.Nil(t, request.GetBroadcastInstanceMessage().BroadcastInstance)
+
+	responder.Process.HandleMessage(0, request.GetBroadcastInstanceMessage())
+	reply, replyMessage := next(t, responder)
+	assert.Equal(t, messages.GossipProtocolMessage_PULL_REPLY, replyMessage.Stage)
+	assert.Equal(t, int32(0), replyMessage.Pull)
+
+	requester.Process.HandleMessage(1, reply.GetBroadcastInstanceMessage())
+
+	value, delivered := requester.Handler.Value(1)
+	assert.True(t, delivered)
+	assert.Equal(t, int32(42), value)
+	// The next pull numbers itself after the previous one
+	_, requestMessage = next(t, requester)
+	assert.Equal(t, int32(1), requestMessage.Pull)
+}
+
+func TestProcess_pullsStopWithContext(t *testing.T) {
+	p := startProcess(0, time.Millisecond)
+	next(t, p)
+
+	process := p.Process.(*Process)
+	pulls := func() int32 {
+		process.mutex.Lock()
+		defer process.mutex.Unlock()
+		return process.pullCounter
+	}
+	p.Context.Stop()
+	// The pull started before the context stopped might still complete
+	time.Sleep(10 * time.Millisecond)
+	stoppedPulls := pulls()
+	time.Sleep(10 * time.Millisecond)
+
+	assert.Equal(t, stoppedPulls, pulls())
+}
+
+// requestPull makes the process send a pull request and returns it.
+func requestPull(t *testing.T, p *protocoltest.Process) *messages.Message {
+	process := p.Process.(*Process)
+	process.mutex.Lock()
+	process.requestPull()
+	process.mutex.Unlock()
+	return p.Next(t).Message
+}
+
+func TestProcess_pullReplySkipsHeldTransactions(t *testing.T) {
+	requester := startProcess(0, 0)
+	responder := startProcess(1, 0)
+	for value := int32(0); value < 5; value++ {
+		responder.Process.Broadcast(value)
+	}
+	for _, seqNumber := range []int32{1, 3} {
+		push, _ := codec.Pack(
+			&messages.BroadcastInstance{Author: 1, SeqNumber: seqNumber},
+			&messages.GossipProtocolMessage{Stage: messages.GossipProtocolMessage_PUSH, Value: seqNumber},
+		)
+		requester.Process.HandleMessage(1, push)
+	}
+
+	request := requestPull(t, requester)
+	responder.Process.HandleMessage(0, request.GetBroadcastInstanceMessage())
+	_, reply := next(t, responder)
+
+	var pulled []int32
+	for _, transaction := range reply.Transactions {
+		pulled = append(pulled, transaction.BroadcastInstance.SeqNumber)
+	}
+	assert.Equal(t, []int32{0, 2, 4}, pulled)
+}
+
+func TestProcess_largeBacklogPulledOverSeveralReplies(t *testing.T) {
+	const backlog = 20000
+	requester := startProcess(0, 0)
+	responder := startProcess(1, 0)
+	for value := int32(0); value < backlog; value++ {
+		responder.Process.Broadcast(value)
+	}
+
+	replies := 0
+	for len(requester.Handler.Delivered()) < backlog {
+		request := requestPull(t, requester)
+		responder.Process.HandleMessage(0, request.GetBroadcastInstanceMessage())
+		reply, _ := next(t, responder)
+		assert.LessOrEqual(t, proto.Size(reply), context.MaxMessageSize)
+
+		requester.Process.HandleMessage(1, reply.GetBroadcastInstanceMessage())
+		replies++
+	}
+
+	assert.Greater(t, replies, 1)
+	value, _ := requester.Handler.Value(1)
+	assert.Equal(t, int32(backlog-1), value)
+}
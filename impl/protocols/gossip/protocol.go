@@ -0,0 +1,32 @@
+package gossip
+
+import (
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+)
+
+const ProtocolName = "gossip"
+
+var codec = protocols.NewPayloadCodec(ProtocolName, func() proto.Message {
+	return &messages.GossipProtocolMessage{}
+})
+
+func init() {
+	protocols.Register(&protocols.Protocol{
+		Name:        ProtocolName,
+		Description: "push-pull epidemic broadcast, a baseline which does not tolerate byzantine processes",
+		NewProcess: func() protocols.Process {
+			return &Process{}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{
+				Fanout:         3,
+				Rounds:         3,
+				PullIntervalNs: 100000000,
+			}
+		},
+		Codec: codec,
+	})
+}
@@ -0,0 +1,20 @@
+package imbsraynal
+
+import "stochastic-checking-simulation/impl/parameters"
+
+// Parameters of the Imbs-Raynal protocol. The protocol is fully defined by n and f,
+// so it does not declare any parameters of its own.
+type Parameters struct{}
+
+func (ip *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	if 5*p.FaultyProcesses >= p.ProcessCount {
+		v.Errorf("imbs_raynal requires n > 5f, got n=%d and f=%d", p.ProcessCount, p.FaultyProcesses)
+	}
+}
+
+func (ip *Parameters) Thresholds(p *parameters.Parameters) []parameters.Threshold {
+	return []parameters.Threshold{
+		{Name: "messagesForWitness", Value: p.ProcessCount - 2*p.FaultyProcesses},
+		{Name: "messagesForDelivery", Value: p.ProcessCount - p.FaultyProcesses},
+	}
+}
@@ -0,0 +1,27 @@
+package imbsraynal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/parameters"
+	"testing"
+)
+
+func TestValidate_tooManyFaultyProcesses(t *testing.T) {
+	p := &parameters.Parameters{ProcessCount: 5, FaultyProcesses: 1, Protocol: &Parameters{}}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "n > 5f")
+}
+
+func TestThresholds(t *testing.T) {
+	p := &parameters.Parameters{ProcessCount: 6, FaultyProcesses: 1, Protocol: &Parameters{}}
+
+	_, e := p.Validate()
+
+	assert.NoError(t, e)
+	assert.Equal(t, []parameters.Threshold{
+		{Name: "messagesForWitness", Value: 4},
+		{Name: "messagesForDelivery", Value: 5},
+	}, p.Thresholds())
+}
@@ -0,0 +1,235 @@
+package imbsraynal
+
+import (
+	"fmt"
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+)
+
+type ProcessId int32
+
+type messageState struct {
+	receivedWitness map[ProcessId]bool
+	witnessCount    map[int32]int
+
+	sentWitness bool
+
+	receivedMessagesCnt int
+}
+
+func newMessageState() *messageState {
+	ms := new(messageState)
+	ms.receivedWitness = make(map[ProcessId]bool)
+	ms.witnessCount = make(map[int32]int)
+
+	ms.sentWitness = false
+
+	ms.receivedMessagesCnt = 0
+
+	return ms
+}
+
+// Process executes the Imbs-Raynal reliable broadcast protocol.
+// Compared to the Bracha protocol it has a single witness (echo) phase,
+// which saves a communication step at the cost of tolerating only f < n/5 faulty processes.
+type Process struct {
+	processIndex int32
+
+	transactionCounter int32
+
+	deliveredTransactions map[ProcessId]map[int32]int32
+	transactionsLog       map[ProcessId]map[int32]*messageState
+
+	n                   int
+	messagesForWitness  int
+	messagesForDelivery int
+
+	context         *context.ReliableContext
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
+}
+
+func (p *Process) InitProcess(
+	processIndex int32,
+	actorPids []string,
+	params *parameters.Parameters,
+	context *context.ReliableContext,
+	logger *eventlogger.EventLogger,
+	deliveryHandler protocols.DeliveryHandler,
+) {
+	p.processIndex = processIndex
+	p.n = len(actorPids)
+	f := params.FaultyProcesses
+
+	p.transactionCounter = 0
+
+	p.messagesForWitness = p.n - 2*f
+	p.messagesForDelivery = p.n - f
+
+	p.deliveredTransactions = make(map[ProcessId]map[int32]int32)
+	p.transactionsLog = make(map[ProcessId]map[int32]*messageState)
+	for index := 0; index < p.n; index++ {
+		p.deliveredTransactions[ProcessId(index)] = make(map[int32]int32)
+		p.transactionsLog[ProcessId(index)] = make(map[int32]*messageState)
+	}
+
+	p.context = context
+	p.logger = logger
+	p.deliveryHandler = deliveryHandler
+}
+
+func (p *Process) initMessageState(bInstance *messages.BroadcastInstance) *messageState {
+	author := ProcessId(bInstance.Author)
+	msgState := p.transactionsLog[author][bInstance.SeqNumber]
+	if msgState == nil {
+		msgState = newMessageState()
+		p.transactionsLog[author][bInstance.SeqNumber] = msgState
+	}
+	return msgState
+}
+
+func (p *Process) sendProtocolMessage(
+	to ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.ImbsRaynalProtocolMessage,
+) {
+	bMessage, e := codec.Pack(bInstance, message)
+	if e != nil {
+		p.logger.Fatal(fmt.Sprintf("Could not pack protocol message: %v", e))
+	}
+
+	msg := p.context.MakeNewMessage()
+	msg.Content = &messages.Message_BroadcastInstanceMessage{
+		BroadcastInstanceMessage: bMessage,
+	}
+
+	p.context.Send(int32(to), msg)
+}
+
+func (p *Process) broadcast(
+	bInstance *messages.BroadcastInstance,
+	message *messages.ImbsRaynalProtocolMessage,
+) {
+	for i := 0; i < p.n; i++ {
+		p.sendProtocolMessage(ProcessId(i), bInstance, message)
+	}
+}
+
+func (p *Process) broadcastWitness(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+	msgState *messageState,
+) {
+	p.broadcast(
+		bInstance,
+		&messages.ImbsRaynalProtocolMessage{
+			Stage: messages.ImbsRaynalProtocolMessage_WITNESS,
+			Value: value,
+		})
+	msgState.sentWitness = true
+}
+
+func (p *Process) delivered(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) bool {
+	deliveredValue, delivered :=
+		p.deliveredTransactions[ProcessId(bInstance.Author)][bInstance.SeqNumber]
+
+	if delivered && deliveredValue != value {
+		p.logger.OnAttack(bInstance, value, deliveredValue)
+	}
+
+	return delivered
+}
+
+func (p *Process) deliver(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) {
+	author := ProcessId(bInstance.Author)
+	p.deliveredTransactions[author][bInstance.SeqNumber] = value
+	messagesReceived :=
+		p.transactionsLog[author][bInstance.SeqNumber].receivedMessagesCnt
+
+	delete(p.transactionsLog[author], bInstance.SeqNumber)
+	p.logger.OnDeliver(bInstance, value, messagesReceived)
+	p.deliveryHandler.Deliver(bInstance, value)
+}
+
+func (p *Process) processProtocolMessage(
+	senderPid ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.ImbsRaynalProtocolMessage,
+) {
+	value := message.Value
+
+	if p.delivered(bInstance, value) {
+		return
+	}
+
+	msgState := p.initMessageState(bInstance)
+	msgState.receivedMessagesCnt++
+
+	switch message.Stage {
+	case messages.ImbsRaynalProtocolMessage_INITIAL:
+		if int32(senderPid) == bInstance.Author && !msgState.sentWitness {
+			p.broadcastWitness(bInstance, value, msgState)
+		}
+	case messages.ImbsRaynalProtocolMessage_WITNESS:
+		if msgState.receivedWitness[senderPid] {
+			return
+		}
+		msgState.receivedWitness[senderPid] = true
+		msgState.witnessCount[value]++
+
+		if msgState.witnessCount[value] >= p.messagesForWitness && !msgState.sentWitness {
+			p.broadcastWitness(bInstance, value, msgState)
+		}
+		if msgState.witnessCount[value] >= p.messagesForDelivery {
+			p.deliver(bInstance, value)
+		}
+	}
+}
+
+func (p *Process) HandleMessage(
+	sender int32,
+	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
+) {
+	if e := protocols.ValidateMessage(sender, broadcastInstanceMessage, p.n); e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+	protocolMessage, e := codec.Unpack(broadcastInstanceMessage)
+	if e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+
+	p.processProtocolMessage(
+		ProcessId(sender),
+		broadcastInstanceMessage.BroadcastInstance,
+		protocolMessage.(*messages.ImbsRaynalProtocolMessage),
+	)
+}
+
+func (p *Process) Broadcast(value int32) {
+	broadcastInstance := &messages.BroadcastInstance{
+		Author:    p.processIndex,
+		SeqNumber: p.transactionCounter,
+	}
+
+	p.broadcast(
+		broadcastInstance,
+		&messages.ImbsRaynalProtocolMessage{
+			Stage: messages.ImbsRaynalProtocolMessage_INITIAL,
+			Value: value,
+		})
+
+	p.logger.OnTransactionInit(broadcastInstance)
+
+	p.transactionCounter++
+}
@@ -0,0 +1,67 @@
+package imbsraynal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/protocoltest"
+	"testing"
+	"time"
+)
+
+// params are the parameters of 6 processes with f = 1, which witness a transaction with 4 witnesses
+// and deliver it with 5.
+var params = &parameters.Parameters{ProcessCount: 6, FaultyProcesses: 1, Protocol: &Parameters{}}
+
+func witness(p *protocoltest.Process, sender int32, bInstance *messages.BroadcastInstance, value int32) {
+	bMessage, _ := codec.Pack(bInstance, &messages.ImbsRaynalProtocolMessage{
+		Stage: messages.ImbsRaynalProtocolMessage_WITNESS,
+		Value: value,
+	})
+	p.Process.HandleMessage(sender, bMessage)
+}
+
+func TestProcess_deliversWithFSilentProcesses(t *testing.T) {
+	nw := protocoltest.StartNetwork(params, func() protocols.Process { return &Process{} })
+	nw.Silent[5] = true
+
+	nw.Processes[0].Process.Broadcast(42)
+	nw.RouteUntilDelivered(t, 0, 10*time.Second)
+
+	assert.Equal(t, map[int32]int32{0: 42, 1: 42, 2: 42, 3: 42, 4: 42}, nw.DeliveredValues(0))
+}
+
+func TestProcess_doesNotDeliverWithMoreThanFSilentProcesses(t *testing.T) {
+	nw := protocoltest.StartNetwork(params, func() protocols.Process { return &Process{} })
+	nw.Silent[4] = true
+	nw.Silent[5] = true
+
+	nw.Processes[0].Process.Broadcast(42)
+	nw.Route(t, func() bool { return false }, 50*time.Millisecond)
+
+	// All 4 correct processes witness the transaction, but 5 witnesses are required for delivery
+	assert.Empty(t, nw.DeliveredValues(0))
+}
+
+func TestProcess_witnessesAndDeliversAtThresholds(t *testing.T) {
+	p := protocoltest.StartProcess(&Process{}, 1, params)
+	bInstance := &messages.BroadcastInstance{Author: 0, SeqNumber: 0}
+
+	// Without the initial message of the author, the process witnesses the transaction once n - 2f processes have
+	for sender := int32(2); sender < 5; sender++ {
+		witness(p, sender, bInstance, 42)
+	}
+	assert.Empty(t, p.Sent(t))
+	witness(p, 5, bInstance, 42)
+	assert.Len(t, p.Sent(t), 6)
+	assert.Empty(t, p.Handler.Delivered())
+
+	// Witnesses of another value do not count towards the delivery
+	witness(p, 0, bInstance, 43)
+	assert.Empty(t, p.Handler.Delivered())
+
+	witness(p, 1, bInstance, 42)
+	assert.Equal(t, []*messages.BroadcastInstance{bInstance}, p.Handler.Delivered())
+	assert.Empty(t, p.Sent(t))
+}
@@ -0,0 +1,28 @@
+package imbsraynal
+
+import (
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+)
+
+const ProtocolName = "imbs_raynal"
+
+var codec = protocols.NewPayloadCodec(ProtocolName, func() proto.Message {
+	return &messages.ImbsRaynalProtocolMessage{}
+})
+
+func init() {
+	protocols.Register(&protocols.Protocol{
+		Name:        ProtocolName,
+		Description: "Imbs-Raynal protocol, a two-step byzantine reliable broadcast for n > 5f",
+		NewProcess: func() protocols.Process {
+			return &Process{}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{}
+		},
+		Codec: codec,
+	})
+}
@@ -0,0 +1,72 @@
+package protocols
+
+import (
+	"sort"
+	"stochastic-checking-simulation/impl/messages"
+)
+
+// Membership is the set of processes participating in the protocol in an epoch.
+// Processes are identified by their indices in the list of all processes which might join the system.
+type Membership struct {
+	Epoch   int32
+	members []int32
+	member  map[int32]bool
+}
+
+// NewMembership returns the membership of the given epoch consisting of the given processes.
+func NewMembership(epoch int32, members []int32) *Membership {
+	m := &Membership{
+		Epoch:   epoch,
+		members: make([]int32, len(members)),
+		member:  make(map[int32]bool),
+	}
+	copy(m.members, members)
+	sort.Slice(m.members, func(i, j int) bool {
+		return m.members[i] < m.members[j]
+	})
+	for _, pid := range members {
+		m.member[pid] = true
+	}
+	return m
+}
+
+// FullMembership returns the membership of epoch 0 consisting of all n processes.
+func FullMembership(n int) *Membership {
+	members := make([]int32, n)
+	for i := range members {
+		members[i] = int32(i)
+	}
+	return NewMembership(0, members)
+}
+
+func MembershipFromMessage(message *messages.Membership) *Membership {
+	return NewMembership(message.Epoch, message.Members)
+}
+
+func (m *Membership) ToMessage() *messages.Membership {
+	members := make([]int32, len(m.members))
+	copy(members, m.members)
+	return &messages.Membership{Epoch: m.Epoch, Members: members}
+}
+
+// Members returns the indices of the members in ascending order.
+func (m *Membership) Members() []int32 {
+	return m.members
+}
+
+func (m *Membership) Contains(pid int32) bool {
+	return m.member[pid]
+}
+
+func (m *Membership) Size() int {
+	return len(m.members)
+}
+
+// Pids returns the addresses of the members, given the addresses of all processes.
+func (m *Membership) Pids(actorPids []string) []string {
+	pids := make([]string, len(m.members))
+	for i, pid := range m.members {
+		pids[i] = actorPids[pid]
+	}
+	return pids
+}
@@ -0,0 +1,37 @@
+package protocols
+
+import (
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/impl/messages"
+	"testing"
+)
+
+func TestNewMembership_sortsMembers(t *testing.T) {
+	membership := NewMembership(2, []int32{3, 0, 2})
+
+	assert.Equal(t, int32(2), membership.Epoch)
+	assert.Equal(t, []int32{0, 2, 3}, membership.Members())
+	assert.Equal(t, 3, membership.Size())
+	assert.True(t, membership.Contains(2))
+	assert.False(t, membership.Contains(1))
+}
+
+func TestFullMembership(t *testing.T) {
+	membership := FullMembership(3)
+
+	assert.Equal(t, int32(0), membership.Epoch)
+	assert.Equal(t, []int32{0, 1, 2}, membership.Members())
+}
+
+func TestMembership_Pids(t *testing.T) {
+	membership := NewMembership(1, []int32{2, 0})
+
+	assert.Equal(t, []string{"a", "c"}, membership.Pids([]string{"a", "b", "c"}))
+}
+
+func TestMembership_messageRoundTrip(t *testing.T) {
+	message := &messages.Membership{Epoch: 4, Members: []int32{1, 3}}
+
+	assert.True(t, proto.Equal(message, MembershipFromMessage(message).ToMessage()))
+}
@@ -0,0 +1,98 @@
+package ordering
+
+import (
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/utils"
+	"sync"
+)
+
+type bufferedTransaction struct {
+	value int32
+	// Time at which the wrapped process delivered the transaction
+	deliveredAt int64
+}
+
+// Fifo wraps a process executing a broadcast protocol and delivers the transactions of every author
+// in the order of their seq numbers. A transaction delivered by the wrapped process is buffered
+// until all transactions of the same author with smaller seq numbers are delivered.
+// Unlike TotalOrder, transactions of different authors are not ordered relative to each other,
+// so an author which stops broadcasting only stalls its own transactions.
+type Fifo struct {
+	layer
+
+	// Seq number of the next transaction to deliver for every author
+	next     []int32
+	buffered []map[int32]*bufferedTransaction
+
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
+	mutex           *sync.Mutex
+}
+
+func NewFifo(process protocols.Process) *Fifo {
+	return &Fifo{layer: layer{process}}
+}
+
+func (f *Fifo) InitProcess(
+	processIndex int32,
+	actorPids []string,
+	params *parameters.Parameters,
+	context *context.ReliableContext,
+	logger *eventlogger.EventLogger,
+	deliveryHandler protocols.DeliveryHandler,
+) {
+	n := len(actorPids)
+
+	f.next = make([]int32, n)
+	f.buffered = make([]map[int32]*bufferedTransaction, n)
+	for author := 0; author < n; author++ {
+		f.buffered[author] = make(map[int32]*bufferedTransaction)
+	}
+
+	f.logger = logger
+	f.deliveryHandler = deliveryHandler
+	f.mutex = &sync.Mutex{}
+
+	f.Process.InitProcess(processIndex, actorPids, params, context, logger, f)
+}
+
+// Deliver receives transactions delivered by the wrapped process.
+func (f *Fifo) Deliver(bInstance *messages.BroadcastInstance, value int32) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	author := bInstance.Author
+	if bInstance.SeqNumber < f.next[author] {
+		return
+	}
+	f.buffered[author][bInstance.SeqNumber] = &bufferedTransaction{
+		value:       value,
+		deliveredAt: utils.GetNow(),
+	}
+
+	for {
+		transaction := f.buffered[author][f.next[author]]
+		if transaction == nil {
+			return
+		}
+		delete(f.buffered[author], f.next[author])
+
+		fifoInstance := &messages.BroadcastInstance{
+			Author:    author,
+			SeqNumber: f.next[author],
+		}
+		// The transaction which has just been delivered by the wrapped process was not buffered
+		bufferingDelay := int64(0)
+		if fifoInstance.SeqNumber != bInstance.SeqNumber {
+			bufferingDelay = utils.GetNow() - transaction.deliveredAt
+		}
+		f.logger.OnFifoDeliver(fifoInstance, transaction.value, bufferingDelay)
+		f.deliveryHandler.Deliver(fifoInstance, transaction.value)
+
+		f.next[author]++
+	}
+}
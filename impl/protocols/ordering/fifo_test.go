@@ -0,0 +1,51 @@
+package ordering
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols/protocoltest"
+	"testing"
+)
+
+func newFifo(n int) (*fakeProcess, *protocoltest.Handler) {
+	process := &fakeProcess{}
+	handler := &protocoltest.Handler{}
+	logger := eventlogger.InitEventLogger(0, log.New(io.Discard, "", 0))
+
+	NewFifo(process).InitProcess(0, make([]string, n), &parameters.Parameters{}, nil, logger, handler)
+
+	return process, handler
+}
+
+func TestFifo_buffersTransactionsUntilPrecedingOfSameAuthorAreDelivered(t *testing.T) {
+	process, handler := newFifo(2)
+
+	process.deliveryHandler.Deliver(instance(0, 1), 1)
+	process.deliveryHandler.Deliver(instance(0, 2), 2)
+	assert.Empty(t, handler.Delivered())
+
+	process.deliveryHandler.Deliver(instance(0, 0), 0)
+	assert.Equal(t,
+		[]*messages.BroadcastInstance{instance(0, 0), instance(0, 1), instance(0, 2)},
+		handler.Delivered())
+}
+
+func TestFifo_doesNotOrderTransactionsOfDifferentAuthors(t *testing.T) {
+	process, handler := newFifo(2)
+
+	process.deliveryHandler.Deliver(instance(0, 1), 1)
+	process.deliveryHandler.Deliver(instance(1, 0), 0)
+	assert.Equal(t, []*messages.BroadcastInstance{instance(1, 0)}, handler.Delivered())
+}
+
+func TestFifo_ignoresAlreadyDeliveredTransactions(t *testing.T) {
+	process, handler := newFifo(1)
+
+	process.deliveryHandler.Deliver(instance(0, 0), 0)
+	process.deliveryHandler.Deliver(instance(0, 0), 0)
+	assert.Equal(t, []*messages.BroadcastInstance{instance(0, 0)}, handler.Delivered())
+}
@@ -0,0 +1,44 @@
+package ordering
+
+import (
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/signatures"
+)
+
+// layer is embedded by the ordering layers and forwards the optional interfaces of the wrapped process,
+// so that a process under an ordering layer still changes the membership, selects witnesses with checkpoints
+// and signs with its secret keys.
+// Recovery is not forwarded, since the wrapped process does not deliver transactions delivered before a crash again,
+// while the layer loses the transactions it has buffered.
+type layer struct {
+	protocols.Process
+}
+
+// Reconfigure forwards the membership to the wrapped process if it supports reconfiguration.
+func (l layer) Reconfigure(membership *protocols.Membership) {
+	if process, reconfigurable := l.Process.(protocols.Reconfigurable); reconfigurable {
+		process.Reconfigure(membership)
+	}
+}
+
+// CheckpointInterval returns the checkpoint interval of the wrapped process, 0 if it does not use checkpoints.
+func (l layer) CheckpointInterval() int {
+	if process, checkpointed := l.Process.(protocols.Checkpointed); checkpointed {
+		return process.CheckpointInterval()
+	}
+	return 0
+}
+
+// Checkpoint forwards the checkpoint to the wrapped process if it uses checkpoints.
+func (l layer) Checkpoint(epoch int32, cut []int32) {
+	if process, checkpointed := l.Process.(protocols.Checkpointed); checkpointed {
+		process.Checkpoint(epoch, cut)
+	}
+}
+
+// UseKeys forwards the key ring to the wrapped process if it signs with it.
+func (l layer) UseKeys(keys *signatures.KeyRing) {
+	if process, keyed := l.Process.(protocols.Keyed); keyed {
+		process.UseKeys(keys)
+	}
+}
@@ -0,0 +1,67 @@
+package ordering
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/signatures"
+	"testing"
+)
+
+type checkpointedProcess struct {
+	fakeProcess
+	epochs     []int32
+	membership *protocols.Membership
+	keys       *signatures.KeyRing
+}
+
+func (p *checkpointedProcess) CheckpointInterval() int {
+	return 10
+}
+
+func (p *checkpointedProcess) Checkpoint(epoch int32, _ []int32) {
+	p.epochs = append(p.epochs, epoch)
+}
+
+func (p *checkpointedProcess) Reconfigure(membership *protocols.Membership) {
+	p.membership = membership
+}
+
+func (p *checkpointedProcess) UseKeys(keys *signatures.KeyRing) {
+	p.keys = keys
+}
+
+func TestLayer_forwardsOptionalInterfaces(t *testing.T) {
+	for _, wrap := range []func(protocols.Process) protocols.Process{
+		func(process protocols.Process) protocols.Process { return NewFifo(process) },
+		func(process protocols.Process) protocols.Process { return NewTotalOrder(process) },
+	} {
+		process := &checkpointedProcess{}
+		layer := wrap(process)
+
+		checkpointed, isCheckpointed := layer.(protocols.Checkpointed)
+		assert.True(t, isCheckpointed)
+		assert.Equal(t, 10, checkpointed.CheckpointInterval())
+		checkpointed.Checkpoint(1, []int32{10, 0})
+		assert.Equal(t, []int32{1}, process.epochs)
+
+		membership := &protocols.Membership{}
+		layer.(protocols.Reconfigurable).Reconfigure(membership)
+		assert.Same(t, membership, process.membership)
+
+		keys := signatures.NewKeyRing(0, []string{"10.0.0.2:5001"})
+		layer.(protocols.Keyed).UseKeys(keys)
+		assert.Same(t, keys, process.keys)
+
+		_, isRecoverable := layer.(protocols.Recoverable)
+		assert.False(t, isRecoverable)
+	}
+}
+
+func TestLayer_noCheckpointsIfWrappedProcessDoesNotUseThem(t *testing.T) {
+	layer := NewFifo(&fakeProcess{})
+
+	assert.Equal(t, 0, layer.CheckpointInterval())
+	layer.Checkpoint(1, []int32{10, 0})
+	layer.Reconfigure(&protocols.Membership{})
+	layer.UseKeys(signatures.NewKeyRing(0, []string{"10.0.0.2:5001"}))
+}
@@ -0,0 +1,90 @@
+package ordering
+
+import (
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"sync"
+)
+
+type position struct {
+	seqNumber int32
+	author    int32
+}
+
+// TotalOrder wraps a process executing a broadcast protocol and delivers transactions
+// in the same order at all processes. Transactions are sequenced by (seq number, author):
+// the transaction of author a with seq number s is ordered after all transactions with smaller seq numbers
+// and after transactions with seq number s of authors preceding a. A transaction delivered by the wrapped process
+// is held back until all transactions preceding it are delivered, so processes delivering the same transactions
+// order them identically. An author which stops broadcasting stalls the ordered stream.
+type TotalOrder struct {
+	layer
+
+	n int
+
+	next    position
+	ordered int
+	pending map[position]int32
+
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
+	mutex           *sync.Mutex
+}
+
+func NewTotalOrder(process protocols.Process) *TotalOrder {
+	return &TotalOrder{layer: layer{process}}
+}
+
+func (t *TotalOrder) InitProcess(
+	processIndex int32,
+	actorPids []string,
+	params *parameters.Parameters,
+	context *context.ReliableContext,
+	logger *eventlogger.EventLogger,
+	deliveryHandler protocols.DeliveryHandler,
+) {
+	t.n = len(actorPids)
+
+	t.next = position{seqNumber: 0, author: 0}
+	t.ordered = 0
+	t.pending = make(map[position]int32)
+
+	t.logger = logger
+	t.deliveryHandler = deliveryHandler
+	t.mutex = &sync.Mutex{}
+
+	t.Process.InitProcess(processIndex, actorPids, params, context, logger, t)
+}
+
+// Deliver receives transactions delivered by the wrapped process.
+func (t *TotalOrder) Deliver(bInstance *messages.BroadcastInstance, value int32) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pending[position{seqNumber: bInstance.SeqNumber, author: bInstance.Author}] = value
+
+	for {
+		value, delivered := t.pending[t.next]
+		if !delivered {
+			return
+		}
+		delete(t.pending, t.next)
+
+		orderedInstance := &messages.BroadcastInstance{
+			Author:    t.next.author,
+			SeqNumber: t.next.seqNumber,
+		}
+		t.logger.OnOrderedDeliver(orderedInstance, value, t.ordered)
+		t.deliveryHandler.Deliver(orderedInstance, value)
+
+		t.ordered++
+		t.next.author++
+		if int(t.next.author) == t.n {
+			t.next.author = 0
+			t.next.seqNumber++
+		}
+	}
+}
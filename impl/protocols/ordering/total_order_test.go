@@ -0,0 +1,79 @@
+package ordering
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log"
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/protocoltest"
+	"testing"
+)
+
+type fakeProcess struct {
+	deliveryHandler protocols.DeliveryHandler
+}
+
+func (p *fakeProcess) InitProcess(
+	_ int32,
+	_ []string,
+	_ *parameters.Parameters,
+	_ *context.ReliableContext,
+	_ *eventlogger.EventLogger,
+	deliveryHandler protocols.DeliveryHandler,
+) {
+	p.deliveryHandler = deliveryHandler
+}
+
+func (p *fakeProcess) HandleMessage(int32, *messages.BroadcastInstanceMessage) {}
+
+func (p *fakeProcess) Broadcast(int32) {}
+
+func newTotalOrder(n int) (*fakeProcess, *protocoltest.Handler) {
+	process := &fakeProcess{}
+	handler := &protocoltest.Handler{}
+	logger := eventlogger.InitEventLogger(0, log.New(io.Discard, "", 0))
+
+	NewTotalOrder(process).InitProcess(0, make([]string, n), &parameters.Parameters{}, nil, logger, handler)
+
+	return process, handler
+}
+
+func instance(author int32, seqNumber int32) *messages.BroadcastInstance {
+	return &messages.BroadcastInstance{Author: author, SeqNumber: seqNumber}
+}
+
+func TestTotalOrder_holdsBackTransactionsUntilPrecedingAreDelivered(t *testing.T) {
+	process, handler := newTotalOrder(2)
+
+	process.deliveryHandler.Deliver(instance(1, 0), 10)
+	process.deliveryHandler.Deliver(instance(1, 1), 11)
+	assert.Empty(t, handler.Delivered())
+
+	process.deliveryHandler.Deliver(instance(0, 1), 1)
+	assert.Empty(t, handler.Delivered())
+
+	process.deliveryHandler.Deliver(instance(0, 0), 0)
+	assert.Equal(t,
+		[]*messages.BroadcastInstance{instance(0, 0), instance(1, 0), instance(0, 1), instance(1, 1)},
+		handler.Delivered())
+}
+
+func TestTotalOrder_sameOrderForDifferentDeliveryOrders(t *testing.T) {
+	first, firstHandler := newTotalOrder(3)
+	second, secondHandler := newTotalOrder(3)
+
+	instances := []*messages.BroadcastInstance{
+		instance(0, 0), instance(1, 0), instance(2, 0), instance(0, 1), instance(1, 1), instance(2, 1),
+	}
+	for i := range instances {
+		first.deliveryHandler.Deliver(instances[i], 0)
+		second.deliveryHandler.Deliver(instances[len(instances)-1-i], 0)
+	}
+
+	assert.Equal(t, instances, firstHandler.Delivered())
+	assert.Equal(t, firstHandler.Delivered(), secondHandler.Delivered())
+}
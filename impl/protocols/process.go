@@ -5,14 +5,21 @@ import (
 	"stochastic-checking-simulation/impl/eventlogger"
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/signatures"
+	"stochastic-checking-simulation/impl/wal"
 )
 
-// Process interface represents a process executing a reliable broadcast protocol.
-// It exports three methods:
-// InitProcess is a constructor for an instance of Process
-// HandleMessage handles an incoming message, potentially
-// creating new messages to be sent to other processes in the system.
-// Broadcast initiates broadcast of a new message with current process as the source.
+// Process represents a process executing a broadcast protocol.
+// InitProcess initialises the process with its index among the processes with the given addresses,
+// the parameters of the simulation, the context it sends messages through, the event logger and the handler
+// it notifies about every transaction it delivers.
+// HandleMessage handles a message received from the sender, which might belong to any protocol or be malformed,
+// potentially sending new messages to other processes.
+// Broadcast starts the broadcast of a new transaction with the given value, authored by the process.
+// Processes may additionally implement Recoverable to survive crashes, Reconfigurable to support membership
+// changes, Checkpointed to select witnesses with agreed history hashes and Keyed to sign with secret keys.
+// The node refuses to run scenarios with crashes or membership changes for processes which do not implement
+// the respective interface.
 type Process interface {
 	InitProcess(
 		processIndex int32,
@@ -20,8 +27,7 @@ type Process interface {
 		parameters *parameters.Parameters,
 		context *context.ReliableContext,
 		eventLogger *eventlogger.EventLogger,
-		ownDeliveredTransactions chan bool,
-		sendOwnDeliveredTransactions bool,
+		deliveryHandler DeliveryHandler,
 	)
 
 	HandleMessage(
@@ -31,3 +37,46 @@ type Process interface {
 
 	Broadcast(value int32)
 }
+
+// DeliveryHandler is notified about transactions delivered by a process.
+// Deliver is called once per delivered transaction, after the process has updated its state.
+type DeliveryHandler interface {
+	Deliver(bInstance *messages.BroadcastInstance, value int32)
+}
+
+// Recoverable is implemented by processes which can survive a crash by persisting their state
+// in a write-ahead log. Recover is called after InitProcess and before the process handles any message.
+// It restores the state of the process from the records of the log and makes the process append
+// its deliveries, own broadcasts and stage transitions to the log from now on.
+// Transactions delivered before the crash are not delivered again.
+type Recoverable interface {
+	Recover(log *wal.Log)
+}
+
+// Reconfigurable is implemented by processes which support processes joining and leaving the system.
+// InitProcess is called with the addresses of all processes which might ever join,
+// and Reconfigure is called with memberships of increasing epochs, the first one before the simulation starts.
+// The process uses the membership of the current epoch to select the processes it sends messages to,
+// and to derive its thresholds and witness sets.
+type Reconfigurable interface {
+	Reconfigure(membership *Membership)
+}
+
+// Checkpointed is implemented by processes which can select witnesses with history hashes of checkpoints
+// agreed via the main server. CheckpointInterval returns the number of deliveries after which the node reports
+// the transactions it has delivered to the main server, 0 if the process does not use checkpoints.
+// Checkpoint is called with every checkpoint the main server sends, the history hash of which
+// covers the transactions of every process i with sequence numbers below cut[i].
+type Checkpointed interface {
+	CheckpointInterval() int
+	Checkpoint(epoch int32, cut []int32)
+}
+
+// Keyed is implemented by processes which sign their messages or prove that they are witnesses with a private key.
+// UseKeys is called after InitProcess and before Recover with the key ring of the process, which holds a private key
+// only the process knows, and which is given the public keys of all processes exchanged via the main server
+// before the simulation starts. Processes which are not given a key ring use keys derived from pids,
+// which every process can compute, see signatures.NewKeyRing.
+type Keyed interface {
+	UseKeys(keys *signatures.KeyRing)
+}
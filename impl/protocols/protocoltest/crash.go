@@ -0,0 +1,149 @@
+package protocoltest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/wal"
+	"testing"
+)
+
+// StartRecoverable starts the process with the given index out of params.ProcessCount, which recovers
+// from the write-ahead log at the given path and delivers transactions to the given handler.
+func StartRecoverable(
+	t *testing.T,
+	process protocols.Process,
+	index int32,
+	params *parameters.Parameters,
+	path string,
+	handler *Handler,
+) *Process {
+	walLog, e := wal.Open(path)
+	assert.Nil(t, e)
+
+	p := NewProcess(index)
+	p.Handler = handler
+	p.Log = walLog
+	p.Context.UseStampStore(walLog, walLog.NextStamp())
+	p.Init(process, index, Pids(params.ProcessCount), params)
+	process.(protocols.Recoverable).Recover(walLog)
+
+	return p
+}
+
+// CrashNetwork routes the messages of recoverable processes one by one, in the order they were sent
+// or in the reverse order, and crashes process 0 once it has handled a given number of messages.
+// Silent processes are not started, and messages to them are dropped.
+// Process 0 restarts with the handler it had before the crash, so that transactions delivered again are recorded twice.
+type CrashNetwork struct {
+	t          *testing.T
+	params     *parameters.Parameters
+	newProcess func() protocols.Process
+	silent     map[int32]bool
+	reverse    bool
+	paths      []string
+	// Processes, nil for silent processes and for process 0 while it is crashed
+	Processes []*Process
+	queue     []Packet
+	// Messages to the crashed process, which other processes retransmit until it restarts
+	held []Packet
+	// Handler of the crashed process, which keeps the transactions it delivered before the crash
+	crashedHandler *Handler
+}
+
+// StartCrashNetwork starts params.ProcessCount processes created by newProcess, except for the silent ones,
+// each recovering from its own write-ahead log.
+func StartCrashNetwork(
+	t *testing.T,
+	params *parameters.Parameters,
+	newProcess func() protocols.Process,
+	silent map[int32]bool,
+	reverse bool,
+) *CrashNetwork {
+	nw := &CrashNetwork{t: t, params: params, newProcess: newProcess, silent: silent, reverse: reverse}
+	for i := int32(0); i < int32(params.ProcessCount); i++ {
+		nw.paths = append(nw.paths, filepath.Join(t.TempDir(), "process.wal"))
+		var p *Process
+		if !silent[i] {
+			p = StartRecoverable(t, newProcess(), i, params, nw.paths[i], &Handler{})
+		}
+		nw.Processes = append(nw.Processes, p)
+	}
+	return nw
+}
+
+// send queues the messages sent by the process since the previous call, or drops them if the process has crashed
+// before sending them.
+func (nw *CrashNetwork) send(index int32, crashed bool) {
+	for _, packet := range nw.Processes[index].SentPackets(nw.t) {
+		if !crashed && !nw.silent[packet.To] {
+			nw.queue = append(nw.queue, packet)
+		}
+	}
+}
+
+// route passes the queued messages to their recipients until no messages are left. Process 0 crashes once it has
+// handled crashAfter messages, after appending the records of the last one to its log, but before sending
+// the messages caused by it or acknowledging it. Messages to process 0 are held until it restarts.
+func (nw *CrashNetwork) route(handled *int, crashAfter int) {
+	for len(nw.queue) > 0 {
+		next := 0
+		if nw.reverse {
+			next = len(nw.queue) - 1
+		}
+		packet := nw.queue[next]
+		nw.queue = append(nw.queue[:next], nw.queue[next+1:]...)
+		if nw.Processes[packet.To] == nil {
+			nw.held = append(nw.held, packet)
+			continue
+		}
+
+		nw.Processes[packet.To].Process.HandleMessage(
+			packet.Message.Sender, packet.Message.GetBroadcastInstanceMessage())
+
+		if packet.To == 0 {
+			*handled++
+			if *handled == crashAfter {
+				nw.crash()
+				nw.held = append(nw.held, packet)
+				continue
+			}
+		}
+		nw.send(packet.To, false)
+	}
+}
+
+func (nw *CrashNetwork) crash() {
+	nw.send(0, true)
+	nw.crashedHandler = nw.Processes[0].Handler
+	assert.Nil(nw.t, nw.Processes[0].Log.Close())
+	nw.Processes[0] = nil
+}
+
+func (nw *CrashNetwork) restart() {
+	nw.Processes[0] = StartRecoverable(nw.t, nw.newProcess(), 0, nw.params, nw.paths[0], nw.crashedHandler)
+	nw.send(0, false)
+	nw.queue = append(nw.queue, nw.held...)
+	nw.held = nil
+}
+
+// Route passes the messages sent by the processes to their recipients until no messages are left. Process 0 crashes
+// once it has handled crashAfter messages, or right away without sending the messages it has pending
+// if crashAfter is 0, and restarts from its log once no other messages are left.
+func (nw *CrashNetwork) Route(crashAfter int) {
+	handled := 0
+	if crashAfter == 0 {
+		nw.crash()
+	}
+	for i, p := range nw.Processes {
+		if p != nil {
+			nw.send(int32(i), false)
+		}
+	}
+	nw.route(&handled, crashAfter)
+	if nw.Processes[0] == nil {
+		nw.restart()
+		nw.route(&handled, -1)
+	}
+}
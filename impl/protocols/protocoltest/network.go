@@ -0,0 +1,91 @@
+package protocoltest
+
+import (
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"testing"
+	"time"
+)
+
+// Network passes the messages sent by its processes to their recipients.
+type Network struct {
+	Processes []*Process
+	// Silent processes neither send nor receive messages
+	Silent map[int32]bool
+	// Observe, if set, is called with every message before its recipient handles it
+	Observe func(packet Packet)
+}
+
+// StartNetwork starts params.ProcessCount processes created by newProcess.
+func StartNetwork(params *parameters.Parameters, newProcess func() protocols.Process) *Network {
+	nw := &Network{Silent: make(map[int32]bool)}
+	for i := 0; i < params.ProcessCount; i++ {
+		nw.Processes = append(nw.Processes, StartProcess(newProcess(), int32(i), params))
+	}
+	return nw
+}
+
+// Delivered returns the number of processes which delivered a transaction of the author.
+func (nw *Network) Delivered(author int32) int {
+	delivered := 0
+	for _, p := range nw.Processes {
+		if _, ok := p.Handler.Value(author); ok {
+			delivered++
+		}
+	}
+	return delivered
+}
+
+// Route passes the messages sent by the processes to their recipients, dropping messages to and from silent
+// processes, until done returns true or the deadline passes. It returns whether done returned true.
+func (nw *Network) Route(t *testing.T, done func() bool, deadline time.Duration) bool {
+	timeout := time.After(deadline)
+	for !done() {
+		routed := false
+		for i, p := range nw.Processes {
+			select {
+			case data := <-p.WriteChan:
+				routed = true
+				if nw.Silent[int32(i)] || nw.Silent[data.To] {
+					continue
+				}
+				packet := unmarshal(t, data)
+				if nw.Observe != nil {
+					nw.Observe(packet)
+				}
+				nw.Processes[data.To].Process.HandleMessage(
+					packet.Message.Sender,
+					packet.Message.GetBroadcastInstanceMessage(),
+				)
+			default:
+			}
+		}
+		if !routed {
+			select {
+			case <-timeout:
+				return false
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}
+	return true
+}
+
+// RouteUntilDelivered routes messages until every process which is not silent has delivered a transaction
+// of the author or the deadline passes.
+func (nw *Network) RouteUntilDelivered(t *testing.T, author int32, deadline time.Duration) bool {
+	return nw.Route(t, func() bool {
+		return nw.Delivered(author) >= len(nw.Processes)-len(nw.Silent)
+	}, deadline)
+}
+
+// DeliveredValues returns the values of the author's transaction delivered by the processes which are not silent.
+func (nw *Network) DeliveredValues(author int32) map[int32]int32 {
+	values := make(map[int32]int32)
+	for i, p := range nw.Processes {
+		if value, ok := p.Handler.Value(author); ok && !nw.Silent[int32(i)] {
+			values[int32(i)] = value
+		}
+	}
+	return values
+}
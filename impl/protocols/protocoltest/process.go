@@ -0,0 +1,164 @@
+// Package protocoltest provides the processes, delivery handlers and message routing shared by protocol tests.
+package protocoltest
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/utils"
+	"stochastic-checking-simulation/impl/wal"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Pids returns the addresses of n processes.
+func Pids(n int) []string {
+	pids := make([]string, n)
+	for i := range pids {
+		pids[i] = fmt.Sprintf("10.0.0.%d:5001", i+2)
+	}
+	return pids
+}
+
+// Handler records the transactions delivered by a process.
+type Handler struct {
+	mutex     sync.Mutex
+	delivered []*messages.BroadcastInstance
+	values    []int32
+}
+
+func (h *Handler) Deliver(bInstance *messages.BroadcastInstance, value int32) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.delivered = append(h.delivered, bInstance)
+	h.values = append(h.values, value)
+}
+
+// Delivered returns the delivered transactions in the order of delivery.
+func (h *Handler) Delivered() []*messages.BroadcastInstance {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]*messages.BroadcastInstance(nil), h.delivered...)
+}
+
+// Value returns the value of the last delivered transaction of the author.
+func (h *Handler) Value(author int32) (int32, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for i := len(h.delivered) - 1; i >= 0; i-- {
+		if h.delivered[i].Author == author {
+			return h.values[i], true
+		}
+	}
+	return 0, false
+}
+
+// Logs collects the events logged by a process.
+type Logs struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (l *Logs) Write(p []byte) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.buffer.Write(p)
+}
+
+func (l *Logs) String() string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.buffer.String()
+}
+
+// Process is a protocol process whose sent messages are kept until the test takes them.
+type Process struct {
+	Process   protocols.Process
+	Handler   *Handler
+	Logs      *Logs
+	Logger    *eventlogger.EventLogger
+	Context   *context.ReliableContext
+	WriteChan chan context.Packet
+	// Write-ahead log of a recoverable process
+	Log *wal.Log
+}
+
+// NewProcess creates the handler, logger and context of the process with the given index, which can be adjusted
+// before Init.
+func NewProcess(index int32) *Process {
+	logs := &Logs{}
+	logger := eventlogger.InitEventLogger(index, log.New(logs, "", 0))
+	writeChan := make(chan context.Packet, 100000)
+	return &Process{
+		Handler:   &Handler{},
+		Logs:      logs,
+		Logger:    logger,
+		Context:   context.NewReliableContext(index, writeChan, 1e12, logger),
+		WriteChan: writeChan,
+	}
+}
+
+// Init initialises the protocol process, so that it sends messages through the context of the process.
+func (p *Process) Init(process protocols.Process, index int32, pids []string, params *parameters.Parameters) {
+	p.Process = process
+	process.InitProcess(index, pids, params, p.Context, p.Logger, p.Handler)
+}
+
+// StartProcess starts the process with the given index out of params.ProcessCount.
+func StartProcess(process protocols.Process, index int32, params *parameters.Parameters) *Process {
+	p := NewProcess(index)
+	p.Init(process, index, Pids(params.ProcessCount), params)
+	return p
+}
+
+// Packet is a message sent by a process with its recipient.
+type Packet struct {
+	To      int32
+	Message *messages.Message
+}
+
+func unmarshal(t *testing.T, data context.Packet) Packet {
+	msg, e := utils.Unmarshal(data.Data)
+	assert.Nil(t, e)
+	return Packet{To: data.To, Message: msg}
+}
+
+// SentPackets returns the messages sent by the process since the previous call, with their recipients.
+func (p *Process) SentPackets(t *testing.T) []Packet {
+	var sent []Packet
+	for {
+		select {
+		case data := <-p.WriteChan:
+			sent = append(sent, unmarshal(t, data))
+		default:
+			return sent
+		}
+	}
+}
+
+// Sent returns the messages sent by the process since the previous call.
+func (p *Process) Sent(t *testing.T) []*messages.Message {
+	var sent []*messages.Message
+	for _, packet := range p.SentPackets(t) {
+		sent = append(sent, packet.Message)
+	}
+	return sent
+}
+
+// Next waits for the next message sent by the process.
+func (p *Process) Next(t *testing.T) Packet {
+	select {
+	case data := <-p.WriteChan:
+		return unmarshal(t, data)
+	case <-time.After(time.Second):
+		t.Fatal("no message was sent")
+		return Packet{}
+	}
+}
@@ -0,0 +1,58 @@
+package protocols
+
+import (
+	"fmt"
+	"sort"
+	"stochastic-checking-simulation/impl/parameters"
+)
+
+// Protocol describes a broadcast protocol which can be run in the simulation.
+type Protocol struct {
+	// Name identifies the protocol in the input file.
+	Name string
+	// Description is a short summary of the protocol.
+	Description string
+	// NewProcess creates a process executing the protocol, which is yet to be initialised.
+	NewProcess func() Process
+	// NewParameters returns the parameters declared by the protocol filled with their default values.
+	NewParameters func() parameters.ProtocolParameters
+	// Codec converts messages of the protocol to the messages sent over the network and back.
+	Codec MessageCodec
+}
+
+var registeredProtocols = make(map[string]*Protocol)
+
+// Register makes the protocol available to the simulation and registers its parameters.
+// It is meant to be called from init functions of protocol packages.
+func Register(protocol *Protocol) {
+	if _, registered := registeredProtocols[protocol.Name]; registered {
+		panic(fmt.Sprintf("protocol %s is already registered", protocol.Name))
+	}
+	if protocol.NewProcess == nil || protocol.NewParameters == nil || protocol.Codec == nil {
+		panic(fmt.Sprintf("protocol %s must define a process, parameters and a codec", protocol.Name))
+	}
+
+	registeredProtocols[protocol.Name] = protocol
+	parameters.Register(protocol.Name, protocol.NewParameters)
+}
+
+// Get returns the registered protocol with the given name.
+func Get(name string) (*Protocol, error) {
+	protocol, registered := registeredProtocols[name]
+	if !registered {
+		return nil, fmt.Errorf("invalid protocol: %s", name)
+	}
+	return protocol, nil
+}
+
+// List returns all registered protocols sorted by name.
+func List() []*Protocol {
+	protocols := make([]*Protocol, 0, len(registeredProtocols))
+	for _, protocol := range registeredProtocols {
+		protocols = append(protocols, protocol)
+	}
+	sort.Slice(protocols, func(i, j int) bool {
+		return protocols[i].Name < protocols[j].Name
+	})
+	return protocols
+}
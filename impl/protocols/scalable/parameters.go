@@ -0,0 +1,66 @@
+package scalable
+
+import "stochastic-checking-simulation/impl/parameters"
+
+// Parameters of the scalable reliable broadcast protocol.
+type Parameters struct {
+	GossipSampleSize int `json:"g_size"`
+
+	EchoSampleSize int `json:"e_size"`
+	EchoThreshold  int `json:"e_threshold"`
+
+	ReadySampleSize int `json:"r_size"`
+	ReadyThreshold  int `json:"r_threshold"`
+
+	DeliverySampleSize int `json:"d_size"`
+	DeliveryThreshold  int `json:"d_threshold"`
+
+	CleanUpTimeout int `json:"clean_up_timeout"`
+}
+
+func (sp *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	n := p.ProcessCount
+
+	if sp.GossipSampleSize <= 0 {
+		v.Errorf("g_size must be positive, got %d", sp.GossipSampleSize)
+	} else if sp.GossipSampleSize > n {
+		v.Warnf("g_size (%d) is greater than n (%d), gossip samples are capped at n", sp.GossipSampleSize, n)
+	}
+
+	samples := []struct {
+		name          string
+		size          int
+		thresholdName string
+		threshold     int
+	}{
+		{"e_size", sp.EchoSampleSize, "e_threshold", sp.EchoThreshold},
+		{"r_size", sp.ReadySampleSize, "r_threshold", sp.ReadyThreshold},
+		{"d_size", sp.DeliverySampleSize, "d_threshold", sp.DeliveryThreshold},
+	}
+
+	for _, s := range samples {
+		if s.size <= 0 {
+			v.Errorf("%s must be positive, got %d", s.name, s.size)
+		} else if s.size > n {
+			v.Warnf("%s (%d) is greater than n (%d), the sample will contain repeated processes", s.name, s.size, n)
+		}
+		if s.threshold <= 0 {
+			v.Errorf("%s must be positive, got %d", s.thresholdName, s.threshold)
+		} else if s.threshold > s.size {
+			v.Errorf("%s (%d) must not be greater than %s (%d), otherwise it can never be reached",
+				s.thresholdName, s.threshold, s.name, s.size)
+		}
+	}
+
+	if sp.CleanUpTimeout < 0 {
+		v.Errorf("clean_up_timeout must not be negative, got %d", sp.CleanUpTimeout)
+	}
+}
+
+func (sp *Parameters) Thresholds(*parameters.Parameters) []parameters.Threshold {
+	return []parameters.Threshold{
+		{Name: "echoThreshold", Value: sp.EchoThreshold},
+		{Name: "readyThreshold", Value: sp.ReadyThreshold},
+		{Name: "deliveryThreshold", Value: sp.DeliveryThreshold},
+	}
+}
@@ -0,0 +1,46 @@
+package scalable
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/parameters"
+	"testing"
+)
+
+func TestValidate_sampleGreaterThanProcessCountIsWarning(t *testing.T) {
+	p := &parameters.Parameters{
+		ProcessCount: 4,
+		Protocol: &Parameters{
+			GossipSampleSize:   5,
+			EchoSampleSize:     2,
+			EchoThreshold:      1,
+			ReadySampleSize:    2,
+			ReadyThreshold:     1,
+			DeliverySampleSize: 2,
+			DeliveryThreshold:  1,
+		},
+	}
+
+	warnings, e := p.Validate()
+
+	assert.Nil(t, e)
+	assert.Len(t, warnings, 1)
+}
+
+func TestValidate_thresholdGreaterThanSample(t *testing.T) {
+	p := &parameters.Parameters{
+		ProcessCount: 4,
+		Protocol: &Parameters{
+			GossipSampleSize:   2,
+			EchoSampleSize:     2,
+			EchoThreshold:      3,
+			ReadySampleSize:    2,
+			ReadyThreshold:     1,
+			DeliverySampleSize: 2,
+			DeliveryThreshold:  1,
+		},
+	}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "e_threshold (3) must not be greater than e_size (2)")
+}
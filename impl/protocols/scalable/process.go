@@ -9,6 +9,7 @@ import (
 	"stochastic-checking-simulation/impl/eventlogger"
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
 	"sync"
 	"time"
 )
@@ -85,20 +86,18 @@ type Process struct {
 	deliveryThreshold  int
 	cleanUpTimeout     time.Duration
 
-	context                      *context.ReliableContext
-	logger                       *eventlogger.EventLogger
-	ownDeliveredTransactions     chan bool
-	sendOwnDeliveredTransactions bool
+	context         *context.ReliableContext
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
 }
 
 func (p *Process) InitProcess(
 	processIndex int32,
 	actorPids []string,
-	parameters *parameters.Parameters,
+	params *parameters.Parameters,
 	context *context.ReliableContext,
 	logger *eventlogger.EventLogger,
-	ownDeliveredTransactions chan bool,
-	sendOwnDeliveredTransactions bool,
+	deliveryHandler protocols.DeliveryHandler,
 ) {
 	p.processIndex = processIndex
 	p.n = len(actorPids)
@@ -115,19 +114,20 @@ func (p *Process) InitProcess(
 		p.logMutex[ProcessId(i)] = &sync.RWMutex{}
 	}
 
-	p.gossipSampleSize = parameters.GossipSampleSize
-	p.echoSampleSize = parameters.EchoSampleSize
-	p.echoThreshold = parameters.EchoThreshold
-	p.readySampleSize = parameters.ReadySampleSize
-	p.readyThreshold = parameters.ReadyThreshold
-	p.deliverySampleSize = parameters.DeliverySampleSize
-	p.deliveryThreshold = parameters.DeliveryThreshold
-	p.cleanUpTimeout = time.Duration(parameters.CleanUpTimeout)
+	protocolParams := params.Protocol.(*Parameters)
+
+	p.gossipSampleSize = protocolParams.GossipSampleSize
+	p.echoSampleSize = protocolParams.EchoSampleSize
+	p.echoThreshold = protocolParams.EchoThreshold
+	p.readySampleSize = protocolParams.ReadySampleSize
+	p.readyThreshold = protocolParams.ReadyThreshold
+	p.deliverySampleSize = protocolParams.DeliverySampleSize
+	p.deliveryThreshold = protocolParams.DeliveryThreshold
+	p.cleanUpTimeout = time.Duration(protocolParams.CleanUpTimeout)
 
 	p.context = context
 	p.logger = logger
-	p.ownDeliveredTransactions = ownDeliveredTransactions
-	p.sendOwnDeliveredTransactions = sendOwnDeliveredTransactions
+	p.deliveryHandler = deliveryHandler
 }
 
 func (p *Process) initMessageState(
@@ -310,10 +310,7 @@ func (p *Process) deliver(
 	messagesReceived := p.messagesLog[author][bInstance.SeqNumber].receivedMessagesCnt
 
 	p.logger.OnDeliver(bInstance, value, messagesReceived)
-
-	if p.sendOwnDeliveredTransactions && bInstance.Author == p.processIndex {
-		p.ownDeliveredTransactions <- true
-	}
+	p.deliveryHandler.Deliver(bInstance, value)
 
 	go func() {
 		time.Sleep(p.cleanUpTimeout)
@@ -449,6 +446,10 @@ func (p *Process) HandleMessage(
 	sender int32,
 	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
 ) {
+	if e := protocols.ValidateMessage(sender, broadcastInstanceMessage, p.n); e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
 	bInstance := broadcastInstanceMessage.BroadcastInstance
 
 	switch protocolMessage := broadcastInstanceMessage.Message.(type) {
@@ -459,7 +460,7 @@ func (p *Process) HandleMessage(
 			protocolMessage.ScalableProtocolMessage,
 		)
 	default:
-		p.logger.Fatal(fmt.Sprintf("Invalid protocol message type %t", protocolMessage))
+		p.logger.OnMessageRejected(sender, fmt.Sprintf("unexpected protocol message type %T", protocolMessage))
 	}
 }
 
@@ -0,0 +1,30 @@
+package scalable
+
+import (
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+)
+
+func init() {
+	protocols.Register(&protocols.Protocol{
+		Name:        "scalable",
+		Description: "scalable byzantine reliable broadcast protocol",
+		NewProcess: func() protocols.Process {
+			return &Process{}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{
+				GossipSampleSize:   2,
+				EchoSampleSize:     2,
+				EchoThreshold:      1,
+				ReadySampleSize:    2,
+				ReadyThreshold:     1,
+				DeliverySampleSize: 2,
+				DeliveryThreshold:  1,
+				CleanUpTimeout:     20000000000,
+			}
+		},
+		Codec: protocols.NewOneofCodec(&messages.ScalableProtocolMessage{}),
+	})
+}
@@ -0,0 +1,47 @@
+package snowball
+
+import "stochastic-checking-simulation/impl/parameters"
+
+// Parameters of the snowball protocol.
+type Parameters struct {
+	SampleSize     int `json:"k"`
+	QuorumSize     int `json:"alpha"`
+	DecisionRounds int `json:"beta"`
+	// RoundTimeoutNs is the time after which a sample is completed with the replies received so far,
+	// so that silent processes do not stall the query rounds. Zero waits for all replies.
+	RoundTimeoutNs int `json:"round_timeout"`
+}
+
+func (sp *Parameters) Validate(p *parameters.Parameters, v *parameters.Validator) {
+	if sp.SampleSize <= 0 {
+		v.Errorf("k must be positive, got %d", sp.SampleSize)
+	} else if sp.SampleSize > p.ProcessCount {
+		v.Warnf("k (%d) is greater than n (%d), the sample will contain repeated processes",
+			sp.SampleSize, p.ProcessCount)
+	}
+
+	if sp.QuorumSize <= 0 {
+		v.Errorf("alpha must be positive, got %d", sp.QuorumSize)
+	} else if sp.QuorumSize > sp.SampleSize {
+		v.Errorf("alpha (%d) must not be greater than k (%d), otherwise it can never be reached",
+			sp.QuorumSize, sp.SampleSize)
+	} else if 2*sp.QuorumSize <= sp.SampleSize {
+		v.Warnf("alpha (%d) is not a majority of k (%d), samples may support several values at once",
+			sp.QuorumSize, sp.SampleSize)
+	}
+
+	if sp.DecisionRounds <= 0 {
+		v.Errorf("beta must be positive, got %d", sp.DecisionRounds)
+	}
+
+	if sp.RoundTimeoutNs < 0 {
+		v.Errorf("round_timeout must not be negative, got %d", sp.RoundTimeoutNs)
+	}
+}
+
+func (sp *Parameters) Thresholds(*parameters.Parameters) []parameters.Threshold {
+	return []parameters.Threshold{
+		{Name: "alpha", Value: sp.QuorumSize},
+		{Name: "beta", Value: sp.DecisionRounds},
+	}
+}
@@ -0,0 +1,31 @@
+package snowball
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/parameters"
+	"testing"
+)
+
+func TestValidate_alphaGreaterThanK(t *testing.T) {
+	p := &parameters.Parameters{
+		ProcessCount: 4,
+		Protocol:     &Parameters{SampleSize: 2, QuorumSize: 3, DecisionRounds: 1},
+	}
+
+	_, e := p.Validate()
+
+	assert.ErrorContains(t, e, "alpha (3) must not be greater than k (2)")
+}
+
+func TestValidate_alphaNotMajority(t *testing.T) {
+	p := &parameters.Parameters{
+		ProcessCount: 10,
+		Protocol:     &Parameters{SampleSize: 4, QuorumSize: 2, DecisionRounds: 1},
+	}
+
+	warnings, e := p.Validate()
+
+	assert.NoError(t, e)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "is not a majority")
+}
@@ -0,0 +1,337 @@
+package snowball
+
+import (
+	"fmt"
+	"math/rand"
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"sync"
+	"time"
+)
+
+type ProcessId int32
+
+type instanceState struct {
+	preference int32
+	// Number of successful samples supporting each value
+	strength map[int32]int
+
+	lastValue  int32
+	confidence int
+
+	round     int32
+	sample    map[ProcessId]int
+	responded map[ProcessId]bool
+	votes     map[int32]int
+	received  int
+
+	receivedMessagesCnt int
+}
+
+func newInstanceState(value int32) *instanceState {
+	s := new(instanceState)
+	s.preference = value
+	s.strength = make(map[int32]int)
+
+	s.lastValue = value
+	s.confidence = 0
+
+	s.round = 0
+
+	s.receivedMessagesCnt = 0
+
+	return s
+}
+
+// Process executes the Snowball protocol.
+// A process adopts the first value it learns for a broadcast instance as its preference
+// and repeatedly queries a random sample of k processes for their preferences.
+// If at least alpha of the sampled processes prefer the same value, the value gains strength,
+// and the preference switches to the value with the most successful samples.
+// The value is delivered once beta consecutive samples supported it.
+// Processes keep answering queries after delivering, so that slower processes can decide as well.
+// A sample is completed with the replies received so far once the round times out,
+// missing replies counting as support for no value.
+type Process struct {
+	processIndex int32
+
+	transactionCounter int32
+
+	instances             map[ProcessId]map[int32]*instanceState
+	deliveredTransactions map[ProcessId]map[int32]int32
+
+	n              int
+	sampleSize     int
+	quorumSize     int
+	decisionRounds int
+	roundTimeout   time.Duration
+
+	random *rand.Rand
+
+	mutex *sync.Mutex
+
+	context         *context.ReliableContext
+	logger          *eventlogger.EventLogger
+	deliveryHandler protocols.DeliveryHandler
+}
+
+func (p *Process) InitProcess(
+	processIndex int32,
+	actorPids []string,
+	params *parameters.Parameters,
+	context *context.ReliableContext,
+	logger *eventlogger.EventLogger,
+	deliveryHandler protocols.DeliveryHandler,
+) {
+	p.processIndex = processIndex
+	p.n = len(actorPids)
+
+	protocolParams := params.Protocol.(*Parameters)
+
+	p.transactionCounter = 0
+
+	p.sampleSize = protocolParams.SampleSize
+	p.quorumSize = protocolParams.QuorumSize
+	p.decisionRounds = protocolParams.DecisionRounds
+	p.roundTimeout = time.Duration(protocolParams.RoundTimeoutNs)
+
+	p.instances = make(map[ProcessId]map[int32]*instanceState)
+	p.deliveredTransactions = make(map[ProcessId]map[int32]int32)
+	for index := 0; index < p.n; index++ {
+		p.instances[ProcessId(index)] = make(map[int32]*instanceState)
+		p.deliveredTransactions[ProcessId(index)] = make(map[int32]int32)
+	}
+
+	p.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	p.mutex = &sync.Mutex{}
+
+	p.context = context
+	p.logger = logger
+	p.deliveryHandler = deliveryHandler
+}
+
+func (p *Process) sendProtocolMessage(
+	to ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.SnowballProtocolMessage,
+) {
+	bMessage, e := codec.Pack(bInstance, message)
+	if e != nil {
+		p.logger.Fatal(fmt.Sprintf("Could not pack protocol message: %v", e))
+	}
+
+	msg := p.context.MakeNewMessage()
+	msg.Content = &messages.Message_BroadcastInstanceMessage{
+		BroadcastInstanceMessage: bMessage,
+	}
+
+	p.context.Send(int32(to), msg)
+}
+
+func (p *Process) getRandomPid() ProcessId {
+	return ProcessId(p.random.Int() % p.n)
+}
+
+// query sends the preference to a new sample of processes, which reply with their own preferences.
+// As in the scalable protocol, processes are sampled with replacement,
+// and the reply of a process counts as many times as the process was sampled.
+func (p *Process) query(bInstance *messages.BroadcastInstance, state *instanceState) {
+	state.sample = make(map[ProcessId]int)
+	state.responded = make(map[ProcessId]bool)
+	state.votes = make(map[int32]int)
+	state.received = 0
+
+	for i := 0; i < p.sampleSize; i++ {
+		state.sample[p.getRandomPid()]++
+	}
+
+	for pid := range state.sample {
+		p.sendProtocolMessage(
+			pid,
+			bInstance,
+			&messages.SnowballProtocolMessage{
+				Stage: messages.SnowballProtocolMessage_QUERY,
+				Value: state.preference,
+				Round: state.round,
+			})
+	}
+
+	if p.roundTimeout > 0 {
+		round := state.round
+		time.AfterFunc(p.roundTimeout, func() {
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+			p.onRoundTimeout(bInstance, state, round)
+		})
+	}
+}
+
+// onRoundTimeout completes the sample of the round if it is still waiting for replies.
+func (p *Process) onRoundTimeout(bInstance *messages.BroadcastInstance, state *instanceState, round int32) {
+	if p.delivered(bInstance) || state.round != round {
+		return
+	}
+	p.completeSample(bInstance, state)
+}
+
+// initInstanceState adopts the value as the preference of a new broadcast instance and starts sampling.
+func (p *Process) initInstanceState(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+) *instanceState {
+	author := ProcessId(bInstance.Author)
+	state := p.instances[author][bInstance.SeqNumber]
+	if state == nil {
+		state = newInstanceState(value)
+		p.instances[author][bInstance.SeqNumber] = state
+		p.query(bInstance, state)
+	}
+	return state
+}
+
+func (p *Process) delivered(bInstance *messages.BroadcastInstance) bool {
+	_, delivered := p.deliveredTransactions[ProcessId(bInstance.Author)][bInstance.SeqNumber]
+	return delivered
+}
+
+func (p *Process) deliver(
+	bInstance *messages.BroadcastInstance,
+	value int32,
+	state *instanceState,
+) {
+	p.deliveredTransactions[ProcessId(bInstance.Author)][bInstance.SeqNumber] = value
+
+	p.logger.OnDeliver(bInstance, value, state.receivedMessagesCnt)
+	p.deliveryHandler.Deliver(bInstance, value)
+}
+
+func (p *Process) completeSample(bInstance *messages.BroadcastInstance, state *instanceState) {
+	votedValue, votes := state.preference, 0
+	for value, valueVotes := range state.votes {
+		if valueVotes > votes {
+			votedValue, votes = value, valueVotes
+		}
+	}
+
+	if votes >= p.quorumSize {
+		state.strength[votedValue]++
+		if state.strength[votedValue] > state.strength[state.preference] {
+			state.preference = votedValue
+		}
+		if votedValue == state.lastValue {
+			state.confidence++
+		} else {
+			state.lastValue = votedValue
+			state.confidence = 1
+		}
+	} else {
+		state.confidence = 0
+	}
+
+	p.logger.OnSampleCompleted(bInstance, state.round, votedValue, votes, p.sampleSize, state.confidence)
+
+	if state.confidence >= p.decisionRounds {
+		p.deliver(bInstance, state.lastValue, state)
+		return
+	}
+
+	state.round++
+	p.query(bInstance, state)
+}
+
+func (p *Process) processProtocolMessage(
+	senderPid ProcessId,
+	bInstance *messages.BroadcastInstance,
+	message *messages.SnowballProtocolMessage,
+) {
+	switch message.Stage {
+	case messages.SnowballProtocolMessage_PROPOSE:
+		if int32(senderPid) != bInstance.Author {
+			return
+		}
+		state := p.initInstanceState(bInstance, message.Value)
+		state.receivedMessagesCnt++
+	case messages.SnowballProtocolMessage_QUERY:
+		state := p.initInstanceState(bInstance, message.Value)
+		if !p.delivered(bInstance) {
+			state.receivedMessagesCnt++
+		}
+		p.sendProtocolMessage(
+			senderPid,
+			bInstance,
+			&messages.SnowballProtocolMessage{
+				Stage: messages.SnowballProtocolMessage_RESPONSE,
+				Value: state.preference,
+				Round: message.Round,
+			})
+	case messages.SnowballProtocolMessage_RESPONSE:
+		state := p.instances[ProcessId(bInstance.Author)][bInstance.SeqNumber]
+		if state == nil || p.delivered(bInstance) || message.Round != state.round {
+			return
+		}
+		weight := state.sample[senderPid]
+		if weight == 0 || state.responded[senderPid] {
+			return
+		}
+		state.receivedMessagesCnt++
+		state.responded[senderPid] = true
+		state.votes[message.Value] += weight
+		state.received += weight
+
+		if state.received == p.sampleSize {
+			p.completeSample(bInstance, state)
+		}
+	}
+}
+
+func (p *Process) HandleMessage(
+	sender int32,
+	broadcastInstanceMessage *messages.BroadcastInstanceMessage,
+) {
+	if e := protocols.ValidateMessage(sender, broadcastInstanceMessage, p.n); e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+	protocolMessage, e := codec.Unpack(broadcastInstanceMessage)
+	if e != nil {
+		p.logger.OnMessageRejected(sender, e.Error())
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.processProtocolMessage(
+		ProcessId(sender),
+		broadcastInstanceMessage.BroadcastInstance,
+		protocolMessage.(*messages.SnowballProtocolMessage),
+	)
+}
+
+func (p *Process) Broadcast(value int32) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	broadcastInstance := &messages.BroadcastInstance{
+		Author:    p.processIndex,
+		SeqNumber: p.transactionCounter,
+	}
+
+	for i := 0; i < p.n; i++ {
+		p.sendProtocolMessage(
+			ProcessId(i),
+			broadcastInstance,
+			&messages.SnowballProtocolMessage{
+				Stage: messages.SnowballProtocolMessage_PROPOSE,
+				Value: value,
+			})
+	}
+
+	p.logger.OnTransactionInit(broadcastInstance)
+
+	p.transactionCounter++
+}
@@ -0,0 +1,42 @@
+package snowball
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/protocols/protocoltest"
+	"testing"
+	"time"
+)
+
+// startNetwork starts n processes of the protocol with the given parameters.
+func startNetwork(n int, protocolParams *Parameters) *protocoltest.Network {
+	return protocoltest.StartNetwork(
+		&parameters.Parameters{ProcessCount: n, Protocol: protocolParams},
+		func() protocols.Process { return &Process{} },
+	)
+}
+
+func TestProcess_deliversAfterBetaSupportingSamples(t *testing.T) {
+	nw := startNetwork(4, &Parameters{SampleSize: 3, QuorumSize: 2, DecisionRounds: 3})
+
+	nw.Processes[0].Process.Broadcast(7)
+	nw.RouteUntilDelivered(t, 0, 10*time.Second)
+
+	assert.Equal(t, map[int32]int32{0: 7, 1: 7, 2: 7, 3: 7}, nw.DeliveredValues(0))
+}
+
+func TestProcess_roundTimeoutCompletesSamplesIncludingSilentProcess(t *testing.T) {
+	nw := startNetwork(4, &Parameters{
+		SampleSize:     3,
+		QuorumSize:     2,
+		DecisionRounds: 3,
+		RoundTimeoutNs: int(10 * time.Millisecond),
+	})
+	nw.Silent[3] = true
+
+	nw.Processes[0].Process.Broadcast(7)
+	nw.RouteUntilDelivered(t, 0, 10*time.Second)
+
+	assert.Equal(t, map[int32]int32{0: 7, 1: 7, 2: 7}, nw.DeliveredValues(0))
+}
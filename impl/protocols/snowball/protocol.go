@@ -0,0 +1,33 @@
+package snowball
+
+import (
+	"google.golang.org/protobuf/proto"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+)
+
+const ProtocolName = "snowball"
+
+var codec = protocols.NewPayloadCodec(ProtocolName, func() proto.Message {
+	return &messages.SnowballProtocolMessage{}
+})
+
+func init() {
+	protocols.Register(&protocols.Protocol{
+		Name:        ProtocolName,
+		Description: "Snowball protocol, a metastable agreement based on repeated random sampling",
+		NewProcess: func() protocols.Process {
+			return &Process{}
+		},
+		NewParameters: func() parameters.ProtocolParameters {
+			return &Parameters{
+				SampleSize:     2,
+				QuorumSize:     2,
+				DecisionRounds: 3,
+				RoundTimeoutNs: 1000000000,
+			}
+		},
+		Codec: codec,
+	})
+}
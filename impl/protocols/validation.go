@@ -0,0 +1,42 @@
+package protocols
+
+import (
+	"errors"
+	"fmt"
+	"stochastic-checking-simulation/impl/messages"
+)
+
+// ValidateMessage checks the fields of a message received from the network which all protocols rely on:
+// the sender and the author of the transaction must be among the n processes,
+// and the sequence number of the transaction must not be negative.
+// Only byzantine processes send messages which fail the check, processes reject them instead of handling them.
+func ValidateMessage(sender int32, message *messages.BroadcastInstanceMessage, n int) error {
+	if e := ValidateSender(sender, n); e != nil {
+		return e
+	}
+	return ValidateBroadcastInstance(message.BroadcastInstance, n)
+}
+
+// ValidateSender checks that the sender is among the n processes,
+// for messages which do not belong to a broadcast instance.
+func ValidateSender(sender int32, n int) error {
+	if sender < 0 || int(sender) >= n {
+		return fmt.Errorf("sender %d is not in [0, %d)", sender, n)
+	}
+	return nil
+}
+
+// ValidateBroadcastInstance checks that the broadcast instance is present, its author is among the n processes
+// and its sequence number is not negative.
+func ValidateBroadcastInstance(bInstance *messages.BroadcastInstance, n int) error {
+	if bInstance == nil {
+		return errors.New("broadcast instance is missing")
+	}
+	if bInstance.Author < 0 || int(bInstance.Author) >= n {
+		return fmt.Errorf("author %d is not in [0, %d)", bInstance.Author, n)
+	}
+	if bInstance.SeqNumber < 0 {
+		return fmt.Errorf("seq number %d is negative", bInstance.SeqNumber)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package protocols
+
+import (
+	"github.com/stretchr/testify/assert"
+	"stochastic-checking-simulation/impl/messages"
+	"testing"
+)
+
+func TestValidateMessage(t *testing.T) {
+	validate := func(sender int32, bInstance *messages.BroadcastInstance) error {
+		return ValidateMessage(sender, &messages.BroadcastInstanceMessage{BroadcastInstance: bInstance}, 4)
+	}
+
+	assert.Nil(t, validate(3, &messages.BroadcastInstance{Author: 0, SeqNumber: 0}))
+	assert.ErrorContains(t, validate(4, &messages.BroadcastInstance{}), "sender 4 is not in [0, 4)")
+	assert.ErrorContains(t, validate(-1, &messages.BroadcastInstance{}), "sender -1 is not in [0, 4)")
+	assert.ErrorContains(t, validate(0, nil), "broadcast instance is missing")
+	assert.ErrorContains(t, validate(0, &messages.BroadcastInstance{Author: 4}), "author 4 is not in [0, 4)")
+	assert.ErrorContains(t, validate(0, &messages.BroadcastInstance{SeqNumber: -2}), "seq number -2 is negative")
+}
@@ -0,0 +1,91 @@
+package signatures
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+)
+
+// KeyRing holds the private key of the current process and public keys of all processes in the system.
+// Key rings created with NewKeyRing derive keys deterministically from pids, so processes know public keys
+// of each other without a key distribution step, but also private keys of each other. They are only suitable
+// for tests and tools. Nodes of the simulation create their key rings with NewSecretKeyRing from a private key
+// only they know, and learn public keys of other processes from the main server.
+type KeyRing struct {
+	processIndex int32
+	privateKey   ed25519.PrivateKey
+	// Public keys are set by the node while the process might already use the key ring
+	mutex      sync.RWMutex
+	publicKeys []ed25519.PublicKey
+}
+
+func NewKeyRing(processIndex int32, pids []string) *KeyRing {
+	k := new(KeyRing)
+	k.processIndex = processIndex
+	k.publicKeys = make([]ed25519.PublicKey, len(pids))
+	for i, pid := range pids {
+		privateKey := DeriveKey(pid)
+		k.publicKeys[i] = privateKey.Public().(ed25519.PublicKey)
+		if int32(i) == processIndex {
+			k.privateKey = privateKey
+		}
+	}
+	return k
+}
+
+// NewSecretKeyRing returns the key ring of the process with the given index and the private key generated
+// from the secret seed. It verifies no signatures until the public keys of all processes are set.
+func NewSecretKeyRing(processIndex int32, seed []byte) *KeyRing {
+	return &KeyRing{processIndex: processIndex, privateKey: ed25519.NewKeyFromSeed(seed)}
+}
+
+// DeriveKey returns the private key of the process with the given pid.
+func DeriveKey(pid string) ed25519.PrivateKey {
+	seed := sha256.Sum256([]byte("key of " + pid))
+	return ed25519.NewKeyFromSeed(seed[:])
+}
+
+func (k *KeyRing) ProcessIndex() int32 {
+	return k.processIndex
+}
+
+// PublicKey returns the public key of the current process.
+func (k *KeyRing) PublicKey() []byte {
+	return k.privateKey.Public().(ed25519.PublicKey)
+}
+
+// SetPublicKeys sets the public keys of all processes, indexed by process indices. The keys are rejected
+// unless they all have the size of ed25519 public keys and the key of the current process matches its private key.
+func (k *KeyRing) SetPublicKeys(keys [][]byte) error {
+	publicKeys := make([]ed25519.PublicKey, len(keys))
+	for i, key := range keys {
+		if len(key) != ed25519.PublicKeySize {
+			return fmt.Errorf("public key of process %d has %d bytes instead of %d", i, len(key), ed25519.PublicKeySize)
+		}
+		publicKeys[i] = key
+	}
+	if int(k.processIndex) >= len(publicKeys) || !publicKeys[k.processIndex].Equal(k.privateKey.Public()) {
+		return fmt.Errorf("public key of process %d does not match its private key", k.processIndex)
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	k.publicKeys = publicKeys
+	return nil
+}
+
+func (k *KeyRing) Sign(data []byte) []byte {
+	return ed25519.Sign(k.privateKey, data)
+}
+
+// Verify checks that the signature of data was produced by the given signer.
+// Signatures of unknown signers are rejected.
+func (k *KeyRing) Verify(signer int32, data []byte, signature []byte) bool {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	if signer < 0 || int(signer) >= len(k.publicKeys) {
+		return false
+	}
+	return ed25519.Verify(k.publicKeys[signer], data, signature)
+}
@@ -0,0 +1,47 @@
+package signatures
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var pids = []string{"10.0.0.2:5001", "10.0.0.3:5001", "10.0.0.4:5001"}
+
+func TestKeyRing_verifiesSignaturesOfOtherProcesses(t *testing.T) {
+	signer := NewKeyRing(1, pids)
+	verifier := NewKeyRing(2, pids)
+	data := []byte("data")
+
+	signature := signer.Sign(data)
+
+	assert.True(t, verifier.Verify(1, data, signature))
+	assert.False(t, verifier.Verify(0, data, signature))
+	assert.False(t, verifier.Verify(1, []byte("other data"), signature))
+}
+
+func TestKeyRing_rejectsUnknownSigners(t *testing.T) {
+	k := NewKeyRing(0, pids)
+	signature := k.Sign([]byte("data"))
+
+	assert.False(t, k.Verify(-1, []byte("data"), signature))
+	assert.False(t, k.Verify(int32(len(pids)), []byte("data"), signature))
+}
+
+func TestSecretKeyRing_verifiesSignaturesOnceKeysAreSet(t *testing.T) {
+	signer := NewSecretKeyRing(0, make([]byte, 32))
+	verifier := NewSecretKeyRing(1, append(make([]byte, 31), 1))
+	data := []byte("data")
+	signature := signer.Sign(data)
+
+	assert.False(t, verifier.Verify(0, data, signature))
+
+	// The key of the verifier must match its private key
+	assert.NotNil(t, verifier.SetPublicKeys([][]byte{signer.PublicKey(), signer.PublicKey()}))
+	assert.NotNil(t, verifier.SetPublicKeys([][]byte{signer.PublicKey()}))
+	assert.NotNil(t, verifier.SetPublicKeys([][]byte{[]byte("short"), verifier.PublicKey()}))
+
+	assert.Nil(t, verifier.SetPublicKeys([][]byte{signer.PublicKey(), verifier.PublicKey()}))
+	assert.True(t, verifier.Verify(0, data, signature))
+	// Keys derived from pids do not match the secret ones
+	assert.False(t, NewKeyRing(1, pids).Verify(0, data, signature))
+}
@@ -0,0 +1,29 @@
+package signatures
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+)
+
+// vrfDomain separates inputs of the verifiable random function from data signed by the protocols.
+const vrfDomain = "vrf "
+
+// Evaluate returns the output of a verifiable random function of the current process on the input and its proof.
+// The proof is the ed25519 signature of the input and the output is the sha256 hash of the proof,
+// so other processes can not compute the output before receiving the proof, but can verify it.
+// Ed25519 signatures are deterministic, so a correct process has a single output per input. A byzantine one could
+// sign with other nonces and pick among several valid outputs, which a real VRF such as ECVRF (RFC 9381) rules out.
+// Byzantine processes of the simulation do not do it.
+func (k *KeyRing) Evaluate(input []byte) ([sha256.Size]byte, []byte) {
+	proof := ed25519.Sign(k.privateKey, append([]byte(vrfDomain), input...))
+	return sha256.Sum256(proof), proof
+}
+
+// VerifyOutput returns the output of the verifiable random function of the signer on the input given its proof,
+// and whether the proof is valid. Proofs of unknown signers are rejected.
+func (k *KeyRing) VerifyOutput(signer int32, input []byte, proof []byte) ([sha256.Size]byte, bool) {
+	if !k.Verify(signer, append([]byte(vrfDomain), input...), proof) {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(proof), true
+}
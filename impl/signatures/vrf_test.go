@@ -0,0 +1,43 @@
+package signatures
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeyRing_evaluatesVerifiableOutputs(t *testing.T) {
+	prover := NewKeyRing(1, pids)
+	verifier := NewKeyRing(2, pids)
+	input := []byte("input")
+
+	output, proof := prover.Evaluate(input)
+	verified, valid := verifier.VerifyOutput(1, input, proof)
+
+	assert.True(t, valid)
+	assert.Equal(t, output, verified)
+	again, _ := prover.Evaluate(input)
+	assert.Equal(t, output, again)
+}
+
+func TestKeyRing_rejectsInvalidProofs(t *testing.T) {
+	prover := NewKeyRing(1, pids)
+	verifier := NewKeyRing(2, pids)
+	_, proof := prover.Evaluate([]byte("input"))
+
+	_, valid := verifier.VerifyOutput(0, []byte("input"), proof)
+	assert.False(t, valid)
+	_, valid = verifier.VerifyOutput(1, []byte("other input"), proof)
+	assert.False(t, valid)
+	_, valid = verifier.VerifyOutput(1, []byte("input"), nil)
+	assert.False(t, valid)
+	// Signatures of the protocols are not proofs of the verifiable random function
+	_, valid = verifier.VerifyOutput(1, []byte("input"), prover.Sign([]byte("input")))
+	assert.False(t, valid)
+}
+
+func TestKeyRing_outputsDifferBetweenProcesses(t *testing.T) {
+	first, _ := NewKeyRing(0, pids).Evaluate([]byte("input"))
+	second, _ := NewKeyRing(1, pids).Evaluate([]byte("input"))
+
+	assert.NotEqual(t, first, second)
+}
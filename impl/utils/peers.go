@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Peers lists the addresses of the processes in the system and of the main server.
+// Processes are keyed by their index, so that every address can be assigned independently,
+// e.g. when processes run in containers or on hosts with unrelated addresses.
+type Peers struct {
+	MainServer string           `json:"main_server" yaml:"main_server"`
+	Processes  map[int32]string `json:"processes" yaml:"processes"`
+}
+
+// LoadPeers reads the peers file in json or yaml format, depending on its extension,
+// and returns the pids of n processes followed by the pid of the main server.
+func LoadPeers(peersFile string, n int) ([]string, error) {
+	data, e := os.ReadFile(peersFile)
+	if e != nil {
+		return nil, fmt.Errorf("could not read peers file %s: %w", peersFile, e)
+	}
+
+	peers := &Peers{}
+	switch strings.ToLower(filepath.Ext(peersFile)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		e = decoder.Decode(peers)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		e = decoder.Decode(peers)
+	}
+	if e != nil {
+		return nil, fmt.Errorf("could not parse peers file %s: %w", peersFile, e)
+	}
+
+	return peers.Pids(n)
+}
+
+// Pids validates the peers and returns the pids of n processes followed by the pid of the main server.
+func (peers *Peers) Pids(n int) ([]string, error) {
+	var errs []error
+
+	if len(peers.Processes) != n {
+		errs = append(errs, fmt.Errorf("peers file lists %d processes, expected n = %d", len(peers.Processes), n))
+	}
+
+	pids := make([]string, n+1)
+	owners := make(map[string]string)
+	addPid := func(owner string, index int, address string) {
+		if e := validateAddress(address); e != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", owner, e))
+			return
+		}
+		if other, duplicate := owners[address]; duplicate {
+			errs = append(errs, fmt.Errorf("%s has the same address %s as %s", owner, address, other))
+			return
+		}
+		owners[address] = owner
+		pids[index] = address
+	}
+
+	for index := 0; index < n; index++ {
+		address, listed := peers.Processes[int32(index)]
+		if !listed {
+			errs = append(errs, fmt.Errorf("process %d is not listed", index))
+			continue
+		}
+		addPid(fmt.Sprintf("process %d", index), index, address)
+	}
+	for index := range peers.Processes {
+		if index < 0 || int(index) >= n {
+			errs = append(errs, fmt.Errorf("process %d must be in [0, n)", index))
+		}
+	}
+	if peers.MainServer == "" {
+		errs = append(errs, errors.New("main server is not listed"))
+	} else {
+		addPid("main server", n, peers.MainServer)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return pids, nil
+}
+
+// validateAddress checks that the address has the host:port format and the port is valid.
+// Hosts are resolved only when processes start listening, as they might not be resolvable beforehand,
+// e.g. names of containers which are not started yet.
+func validateAddress(address string) error {
+	host, port, e := net.SplitHostPort(address)
+	if e != nil {
+		return fmt.Errorf("address %s must have the host:port format", address)
+	}
+	if host == "" {
+		return fmt.Errorf("address %s has an empty host", address)
+	}
+	portNumber, e := strconv.Atoi(port)
+	if e != nil || portNumber <= 0 || portNumber > 65535 {
+		return fmt.Errorf("address %s has an invalid port", address)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePeersFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadPeers_json(t *testing.T) {
+	path := writePeersFile(t, "peers.json", `{
+		"main_server": "10.0.0.1:5001",
+		"processes": {"1": "node-b:7000", "0": "[::1]:5002"}
+	}`)
+
+	pids, e := LoadPeers(path, 2)
+
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"[::1]:5002", "node-b:7000", "10.0.0.1:5001"}, pids)
+}
+
+func TestLoadPeers_yaml(t *testing.T) {
+	path := writePeersFile(t, "peers.yaml", `
+main_server: server:5001
+processes:
+  0: node-a:5001
+  1: node-b:5001
+`)
+
+	pids, e := LoadPeers(path, 2)
+
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"node-a:5001", "node-b:5001", "server:5001"}, pids)
+}
+
+func TestLoadPeers_unknownField(t *testing.T) {
+	path := writePeersFile(t, "peers.json", `{"mainserver": "10.0.0.1:5001", "processes": {}}`)
+
+	_, e := LoadPeers(path, 0)
+
+	assert.ErrorContains(t, e, "could not parse peers file")
+}
+
+func TestPeers_Pids_invalidPeers(t *testing.T) {
+	peers := &Peers{
+		Processes: map[int32]string{
+			0: "10.0.0.2:5001",
+			1: "10.0.0.2:5001",
+			2: "10.0.0.3",
+			4: "node:70000",
+		},
+	}
+
+	_, e := peers.Pids(4)
+
+	assert.ErrorContains(t, e, "process 1 has the same address 10.0.0.2:5001 as process 0")
+	assert.ErrorContains(t, e, "process 2: address 10.0.0.3 must have the host:port format")
+	assert.ErrorContains(t, e, "process 3 is not listed")
+	assert.ErrorContains(t, e, "process 4 must be in [0, n)")
+	assert.ErrorContains(t, e, "main server is not listed")
+}
+
+func TestPeers_Pids_wrongProcessCount(t *testing.T) {
+	peers := &Peers{
+		MainServer: "10.0.0.1:5001",
+		Processes:  map[int32]string{0: "10.0.0.2:5001"},
+	}
+
+	_, e := peers.Pids(2)
+
+	assert.ErrorContains(t, e, "peers file lists 1 processes, expected n = 2")
+}
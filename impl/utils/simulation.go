@@ -12,6 +12,10 @@ import (
 	"time"
 )
 
+// MainServerReadyLine is logged by the main server once its socket is bound,
+// so that the launcher knows when nodes can start sending to it.
+const MainServerReadyLine = "Main server ready"
+
 func JoinIpAndPort(ip string, port int) string {
 	return fmt.Sprintf("%s:%d", ip, port)
 }
@@ -0,0 +1,192 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"stochastic-checking-simulation/impl/messages"
+	"sync"
+)
+
+type RecordType string
+
+const (
+	// DeliveryRecord is appended before a process delivers a transaction
+	DeliveryRecord RecordType = "delivery"
+	// BroadcastRecord is appended before a process broadcasts its own transaction
+	BroadcastRecord RecordType = "broadcast"
+	// StageRecord is appended before a process sends the messages of a new protocol stage
+	StageRecord RecordType = "stage"
+	// StampRecord is appended when the process reserves a new block of message stamps
+	StampRecord RecordType = "stamp"
+	// ReceivedRecord is appended before a process counts a protocol message of another process
+	ReceivedRecord RecordType = "received"
+	// KeyRecord is appended when the process generates its secret key, which it keeps after a restart
+	KeyRecord RecordType = "key"
+)
+
+// Record is a single entry of the write-ahead log.
+// Author, SeqNumber and Value are set for all records except stamp reservations and keys,
+// Stage is set for stage and received records, Sender for received records, Stamp for stamp reservations
+// and Seed for keys.
+type Record struct {
+	Type      RecordType `json:"type"`
+	Sender    int32      `json:"sender,omitempty"`
+	Author    int32      `json:"author,omitempty"`
+	SeqNumber int32      `json:"seq_number,omitempty"`
+	Value     int32      `json:"value,omitempty"`
+	Stage     string     `json:"stage,omitempty"`
+	Stamp     int32      `json:"stamp,omitempty"`
+	// HistoryHash is the marshaled history hash a stage record was produced with, if the protocol attaches one
+	HistoryHash []byte `json:"history_hash,omitempty"`
+	// Seed is the secret seed the private key of the process is generated from
+	Seed []byte `json:"seed,omitempty"`
+}
+
+func Delivery(bInstance *messages.BroadcastInstance, value int32) Record {
+	return Record{Type: DeliveryRecord, Author: bInstance.Author, SeqNumber: bInstance.SeqNumber, Value: value}
+}
+
+func Broadcast(bInstance *messages.BroadcastInstance, value int32) Record {
+	return Record{Type: BroadcastRecord, Author: bInstance.Author, SeqNumber: bInstance.SeqNumber, Value: value}
+}
+
+func Stage(bInstance *messages.BroadcastInstance, stage string, value int32) Record {
+	return Record{
+		Type:      StageRecord,
+		Author:    bInstance.Author,
+		SeqNumber: bInstance.SeqNumber,
+		Value:     value,
+		Stage:     stage,
+	}
+}
+
+// Received records that the process has counted the message of the given stage sent by the sender.
+func Received(sender int32, bInstance *messages.BroadcastInstance, stage string, value int32) Record {
+	return Record{
+		Type:      ReceivedRecord,
+		Sender:    sender,
+		Author:    bInstance.Author,
+		SeqNumber: bInstance.SeqNumber,
+		Value:     value,
+		Stage:     stage,
+	}
+}
+
+func Key(seed []byte) Record {
+	return Record{Type: KeyRecord, Seed: seed}
+}
+
+func (r Record) BroadcastInstance() *messages.BroadcastInstance {
+	return &messages.BroadcastInstance{Author: r.Author, SeqNumber: r.SeqNumber}
+}
+
+// Log is an append-only log of records stored in a file, one json record per line.
+// Every record is synced to disk before Append returns, so that a record
+// describing an action survives a crash happening right after the action.
+type Log struct {
+	file    *os.File
+	records []Record
+	mutex   *sync.Mutex
+}
+
+// Open reads the records of an existing log at the given path and opens it for appending.
+// The log is created if it does not exist. A partially written last record,
+// left by a crash in the middle of Append, is dropped.
+func Open(path string) (*Log, error) {
+	records, size, e := readRecords(path)
+	if e != nil {
+		return nil, e
+	}
+
+	file, e := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if e != nil {
+		return nil, fmt.Errorf("could not open the write-ahead log %s: %w", path, e)
+	}
+	if e = file.Truncate(size); e != nil {
+		file.Close()
+		return nil, fmt.Errorf("could not drop the incomplete record of the write-ahead log %s: %w", path, e)
+	}
+	if _, e = file.Seek(size, 0); e != nil {
+		file.Close()
+		return nil, fmt.Errorf("could not open the write-ahead log %s: %w", path, e)
+	}
+
+	return &Log{
+		file:    file,
+		records: records,
+		mutex:   &sync.Mutex{},
+	}, nil
+}
+
+// readRecords returns the complete records stored at the given path and their total size in bytes.
+func readRecords(path string) ([]Record, int64, error) {
+	file, e := os.Open(path)
+	if os.IsNotExist(e) {
+		return nil, 0, nil
+	}
+	if e != nil {
+		return nil, 0, fmt.Errorf("could not read the write-ahead log %s: %w", path, e)
+	}
+	defer file.Close()
+
+	var records []Record
+	var size int64
+	reader := bufio.NewReader(file)
+	for {
+		line, e := reader.ReadBytes('\n')
+		if e != nil {
+			// The last line is not terminated, i.e. the process crashed while writing it
+			break
+		}
+		var record Record
+		if json.Unmarshal(line, &record) != nil {
+			return nil, 0, fmt.Errorf("corrupted record in the write-ahead log %s at offset %d", path, size)
+		}
+		records = append(records, record)
+		size += int64(len(line))
+	}
+	return records, size, nil
+}
+
+// Records returns the records which were stored in the log when it was opened, in the order they were appended.
+func (l *Log) Records() []Record {
+	return l.records
+}
+
+// NextStamp returns the first message stamp which was not reserved before the log was opened.
+func (l *Log) NextStamp() int32 {
+	next := int32(0)
+	for _, record := range l.records {
+		if record.Type == StampRecord && record.Stamp > next {
+			next = record.Stamp
+		}
+	}
+	return next
+}
+
+func (l *Log) Append(record Record) error {
+	data, e := json.Marshal(record)
+	if e != nil {
+		return e
+	}
+	data = append(data, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, e = l.file.Write(data); e != nil {
+		return e
+	}
+	return l.file.Sync()
+}
+
+// ReserveStamps records that message stamps below the limit might have been used.
+func (l *Log) ReserveStamps(limit int32) error {
+	return l.Append(Record{Type: StampRecord, Stamp: limit})
+}
+
+func (l *Log) Close() error {
+	return l.file.Close()
+}
@@ -0,0 +1,82 @@
+package wal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"stochastic-checking-simulation/impl/messages"
+	"testing"
+)
+
+func TestOpen_restoresAppendedRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "process.wal")
+	bInstance := &messages.BroadcastInstance{Author: 1, SeqNumber: 2}
+
+	log, e := Open(path)
+	assert.Nil(t, e)
+	assert.Empty(t, log.Records())
+	assert.Nil(t, log.Append(Broadcast(bInstance, 3)))
+	assert.Nil(t, log.Append(Stage(bInstance, "sent_echo", 3)))
+	assert.Nil(t, log.Append(Received(3, bInstance, "ECHO", 3)))
+	assert.Nil(t, log.Append(Delivery(bInstance, 3)))
+	assert.Nil(t, log.Append(Key([]byte{1, 2, 3})))
+	assert.Nil(t, log.Close())
+
+	log, e = Open(path)
+	assert.Nil(t, e)
+	assert.Equal(t,
+		[]Record{
+			Broadcast(bInstance, 3),
+			Stage(bInstance, "sent_echo", 3),
+			Received(3, bInstance, "ECHO", 3),
+			Delivery(bInstance, 3),
+			Key([]byte{1, 2, 3}),
+		},
+		log.Records())
+	assert.Equal(t, bInstance, log.Records()[0].BroadcastInstance())
+}
+
+func TestOpen_dropsIncompleteRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "process.wal")
+	bInstance := &messages.BroadcastInstance{Author: 0, SeqNumber: 0}
+
+	log, _ := Open(path)
+	assert.Nil(t, log.Append(Delivery(bInstance, 1)))
+	assert.Nil(t, log.Close())
+
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	_, _ = file.WriteString(`{"type":"deliv`)
+	assert.Nil(t, file.Close())
+
+	log, e := Open(path)
+	assert.Nil(t, e)
+	assert.Equal(t, []Record{Delivery(bInstance, 1)}, log.Records())
+
+	assert.Nil(t, log.Append(Delivery(bInstance, 2)))
+	assert.Nil(t, log.Close())
+
+	log, e = Open(path)
+	assert.Nil(t, e)
+	assert.Equal(t, []Record{Delivery(bInstance, 1), Delivery(bInstance, 2)}, log.Records())
+}
+
+func TestOpen_corruptedRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "process.wal")
+	assert.Nil(t, os.WriteFile(path, []byte("not a record\n"), 0644))
+
+	_, e := Open(path)
+
+	assert.ErrorContains(t, e, "corrupted record")
+}
+
+func TestNextStamp(t *testing.T) {
+	log, _ := Open(filepath.Join(t.TempDir(), "process.wal"))
+	assert.Equal(t, int32(0), log.NextStamp())
+
+	assert.Nil(t, log.ReserveStamps(1000))
+	assert.Nil(t, log.ReserveStamps(2000))
+	assert.Nil(t, log.Close())
+
+	log, _ = Open(log.file.Name())
+	assert.Equal(t, int32(2000), log.NextStamp())
+}
@@ -1,4 +1,5 @@
 #!/usr/bin/env python3
+import argparse
 import json
 
 SENT_MESSAGE = "Sent message"
@@ -7,7 +8,16 @@ TRANSACTION_INIT = "Initialising transaction"
 TRANSACTION_COMMIT = "Delivered transaction"
 WITNESS_SET_SELECTED = "Witness set selected"
 WITNESS_SET_SELECTION = "Witness set selection"
+WITNESS_SELF_SELECTED = "Witness self-selected"
 SIMULATION_STARTED = "Simulation started"
+SAMPLE_COMPLETED = "Sample completed"
+DELIVERY_PATH = "Delivery path"
+TRANSACTION_ORDERED = "Ordered transaction"
+TRANSACTION_FIFO = "FIFO transaction"
+PROCESS_CRASHED = "Process crashed"
+MEMBERSHIP_EPOCH = "Membership epoch"
+REJECTED_MESSAGE = "Rejected message"
+CERTIFICATE_SENT = "Certificate sent"
 
 RELIABLE_ACCOUNTABILITY = "reliable_accountability"
 CONSISTENT_ACCOUNTABILITY = "consistent_accountability"
@@ -19,7 +29,16 @@ LOG_PREFIXES = {
     TRANSACTION_COMMIT,
     WITNESS_SET_SELECTED,
     WITNESS_SET_SELECTION,
-    SIMULATION_STARTED
+    WITNESS_SELF_SELECTED,
+    SIMULATION_STARTED,
+    SAMPLE_COMPLETED,
+    DELIVERY_PATH,
+    TRANSACTION_ORDERED,
+    TRANSACTION_FIFO,
+    PROCESS_CRASHED,
+    MEMBERSHIP_EPOCH,
+    REJECTED_MESSAGE,
+    CERTIFICATE_SENT
 }
 
 
@@ -71,6 +90,19 @@ def parse_data_from_files(directory, n):
         "own": {},
         "pot": {}
     }
+    transaction_history_epochs = {}
+    transaction_self_selected_witnesses = {
+        "own": {},
+        "pot": {}
+    }
+    transaction_samples = {}
+    certificate_sizes = {}
+    delivery_paths = {}
+    transaction_orders = {}
+    buffering_delays = []
+    crashed_processes = set()
+    epochs = {}
+    rejected_messages = 0
     simulation_start = None
     simulation_end = None
 
@@ -82,6 +114,10 @@ def parse_data_from_files(directory, n):
 
             if prefix == "":
                 continue
+            # Reasons of rejections may contain the separators of the logged data, so they are only counted
+            if prefix == REJECTED_MESSAGE:
+                rejected_messages += 1
+                continue
 
             data = parse_data_from_logged_line(line)
             timestamp = int(data[-1])
@@ -92,9 +128,11 @@ def parse_data_from_files(directory, n):
                 if simulation_start is None or timestamp < simulation_start:
                     simulation_start = timestamp
             elif prefix == SENT_MESSAGE:
-                sent_messages[data[0]] = timestamp
+                # Retransmissions are logged with the same id, e.g. to a crashed process which never acknowledges them,
+                # the latency is measured from the first transmission to the first receipt
+                sent_messages.setdefault(data[0], timestamp)
             elif prefix == RECEIVED_MESSAGE:
-                received_messages[data[0]] = timestamp
+                received_messages.setdefault(data[0], timestamp)
             elif prefix == TRANSACTION_INIT:
                 transaction_inits[data[0]] = \
                     TransactionInitInfo(process_id=process_id, init_timestamp=timestamp)
@@ -109,6 +147,36 @@ def parse_data_from_files(directory, n):
                         received_messages_cnt=received_messages_cnt,
                         commit_timestamp=timestamp)
                 )
+            elif prefix == TRANSACTION_ORDERED:
+                transaction = data[0]
+                if transaction_orders.get(transaction) is None:
+                    transaction_orders[transaction] = {}
+                transaction_orders[transaction][process_id] = timestamp
+            elif prefix == MEMBERSHIP_EPOCH:
+                epoch = int(data[0])
+
+                assert data[1][0] == '[' and data[1][-1] == ']'
+
+                members = set(map(int, data[1][1:-1].split()))
+                # Processes learn about the epoch at different times, the earliest one is taken
+                if epochs.get(epoch) is None or timestamp < epochs[epoch][0]:
+                    epochs[epoch] = (timestamp, members)
+            elif prefix == PROCESS_CRASHED:
+                crashed_processes.add(process_id)
+            elif prefix == TRANSACTION_FIFO:
+                buffering_delays.append(int(data[2]))
+            elif prefix == DELIVERY_PATH:
+                path = data[1]
+                delivery_paths[path] = delivery_paths.get(path, 0) + 1
+            elif prefix == SAMPLE_COMPLETED:
+                transaction = data[0]
+                if transaction_samples.get(transaction) is None:
+                    transaction_samples[transaction] = {}
+                samples = transaction_samples[transaction]
+                samples[process_id] = samples.get(process_id, 0) + 1
+            elif prefix == CERTIFICATE_SENT:
+                stage = data[1]
+                certificate_sizes.setdefault(stage, []).append((int(data[2]), int(data[3])))
             elif prefix == WITNESS_SET_SELECTION:
                 transaction = data[0]
 
@@ -136,6 +204,13 @@ def parse_data_from_files(directory, n):
                 if transaction_witness_sets[ws_type].get(transaction) is None:
                     transaction_witness_sets[ws_type][transaction] = []
                 transaction_witness_sets[ws_type][transaction].append(pids)
+                if ws_type == "own":
+                    transaction_history_epochs.setdefault(transaction, []).append(int(data[3]))
+            elif prefix == WITNESS_SELF_SELECTED:
+                ws_type = data[0]
+                transaction = data[1]
+                witnesses = transaction_self_selected_witnesses[ws_type]
+                witnesses[transaction] = witnesses.get(transaction, 0) + 1
     return {
         "sent_messages": sent_messages,
         "received_messages": received_messages,
@@ -143,6 +218,16 @@ def parse_data_from_files(directory, n):
         "transaction_commit_infos": transaction_commit_infos,
         "transaction_histories": transaction_histories,
         "transaction_witness_sets": transaction_witness_sets,
+        "transaction_history_epochs": transaction_history_epochs,
+        "transaction_self_selected_witnesses": transaction_self_selected_witnesses,
+        "transaction_samples": transaction_samples,
+        "certificate_sizes": certificate_sizes,
+        "delivery_paths": delivery_paths,
+        "transaction_orders": transaction_orders,
+        "buffering_delays": buffering_delays,
+        "crashed_processes": crashed_processes,
+        "epochs": epochs,
+        "rejected_messages": rejected_messages,
         "simulation_start": simulation_start,
         "simulation_end": simulation_end
     }
@@ -170,30 +255,55 @@ def calc_message_latencies(sent_messages, received_messages):
     return min_latency, max_latency, sum_latency / len(latencies)
 
 
-def calc_transaction_stat(n, transaction_inits, transaction_commit_infos, simulation_start, simulation_end):
+def get_expected_pids(n, crashed_processes, epochs, init_timestamp):
+    """Returns processes which must deliver a transaction initialised at the given time:
+    correct processes which are members from the epoch the transaction was initialised in until the end of the run."""
+    expected_pids = set(range(n)).difference(crashed_processes)
+    started_epoch = None
+    for epoch, (timestamp, _) in sorted(epochs.items()):
+        if started_epoch is None or timestamp <= init_timestamp:
+            started_epoch = epoch
+    for epoch, (_, members) in epochs.items():
+        if epoch >= started_epoch:
+            expected_pids = expected_pids.intersection(members)
+    return expected_pids
+
+
+def calc_transaction_stat(n, transaction_inits, transaction_commit_infos, simulation_start, simulation_end,
+                          crashed_processes, epochs):
     sum_latency = 0
     min_latency = None
     max_latency = None
     sum_messages_exchanged = 0
     transaction_cnt = 0
+    sum_dissemination_latency = 0
+    disseminated_transaction_cnt = 0
     for transaction, init_info in transaction_inits.items():
         commit_infos = transaction_commit_infos.get(transaction)
         if commit_infos is None:
             print(f"Transaction {transaction} was not committed")
             continue
 
-        if len(commit_infos) != n:
-            committed_pids = set(map(lambda commit_info: commit_info.process_id, commit_infos))
-            not_committed_pids = set(range(n)).difference(committed_pids)
+        committed_pids = set(map(lambda commit_info: commit_info.process_id, commit_infos))
+        expected_pids = get_expected_pids(n, crashed_processes, epochs, init_info.init_timestamp)
+        not_committed_pids = expected_pids.difference(committed_pids)
+        if len(not_committed_pids) != 0:
             print(f"Transaction {transaction} wasn't committed by processes {not_committed_pids}")
 
         commit_timestamp = None
+        last_commit_timestamp = None
         messages_exchanged = 0
         for commit_info in commit_infos:
             if commit_info.process_id == init_info.process_id:
                 commit_timestamp = commit_info.commit_timestamp
+            if last_commit_timestamp is None or commit_info.commit_timestamp > last_commit_timestamp:
+                last_commit_timestamp = commit_info.commit_timestamp
             messages_exchanged += commit_info.received_messages_cnt
 
+        if len(not_committed_pids) == 0:
+            sum_dissemination_latency += last_commit_timestamp - init_info.init_timestamp
+            disseminated_transaction_cnt += 1
+
         if commit_timestamp is None:
             print(f"Transaction {transaction} wasn't committed by source")
             continue
@@ -212,8 +322,12 @@ def calc_transaction_stat(n, transaction_inits, transaction_commit_infos, simula
     throughput = 0
     if simulation_start is not None and simulation_end is not None:
         throughput = transaction_cnt * 1e9 / (simulation_end - simulation_start)
+    avg_dissemination_latency = 0
+    if disseminated_transaction_cnt != 0:
+        avg_dissemination_latency = sum_dissemination_latency / disseminated_transaction_cnt
     return min_latency, max_latency, sum_latency / transaction_cnt, \
-        int(sum_messages_exchanged / transaction_cnt), throughput
+        int(sum_messages_exchanged / transaction_cnt), throughput, avg_dissemination_latency, \
+        disseminated_transaction_cnt
 
 
 def get_distance_metrics(sets):
@@ -237,6 +351,52 @@ def get_witness_sets_diff_metrics(transaction_witness_sets, n, ws_type):
     return metrics
 
 
+def get_identical_witness_sets_share(transaction_witness_sets, n, ws_type):
+    identical_cnt = 0
+    transaction_cnt = 0
+    for transaction, witness_sets in transaction_witness_sets[ws_type].items():
+        if len(witness_sets) != n:
+            continue
+        transaction_cnt += 1
+        if all(witness_set == witness_sets[0] for witness_set in witness_sets):
+            identical_cnt += 1
+
+    if transaction_cnt == 0:
+        return None
+    return identical_cnt / transaction_cnt
+
+
+def get_avg_witness_set_size(transaction_witness_sets, transaction_self_selected_witnesses, transaction_inits, ws_type):
+    # Witnesses selected with a verifiable random function are only known to themselves,
+    # so the size of a witness set is the number of processes which selected themselves
+    if len(transaction_self_selected_witnesses[ws_type]) != 0:
+        if len(transaction_inits) == 0:
+            return None
+        return sum(transaction_self_selected_witnesses[ws_type].values()) / len(transaction_inits)
+
+    sizes = [len(witness_set)
+             for witness_sets in transaction_witness_sets[ws_type].values()
+             for witness_set in witness_sets]
+    if len(sizes) == 0:
+        return None
+    return sum(sizes) / len(sizes)
+
+
+def get_same_history_epoch_share(transaction_history_epochs, n):
+    same_cnt = 0
+    transaction_cnt = 0
+    for transaction, history_epochs in transaction_history_epochs.items():
+        if len(history_epochs) != n:
+            continue
+        transaction_cnt += 1
+        if len(set(history_epochs)) == 1:
+            same_cnt += 1
+
+    if transaction_cnt == 0:
+        return None
+    return same_cnt / transaction_cnt
+
+
 def get_histories_diff_metrics(transaction_histories, n):
     metrics = []
     for transaction, histories in transaction_histories.items():
@@ -247,6 +407,64 @@ def get_histories_diff_metrics(transaction_histories, n):
     return metrics
 
 
+def calc_avg_samples(transaction_samples):
+    samples_cnt = 0
+    processes_cnt = 0
+    for transaction, samples in transaction_samples.items():
+        samples_cnt += sum(samples.values())
+        processes_cnt += len(samples)
+
+    if processes_cnt == 0:
+        return None
+    return samples_cnt / processes_cnt
+
+
+def calc_certificate_stat(certificate_sizes):
+    stat = {}
+    for stage, sizes in certificate_sizes.items():
+        signatures = [signatures_cnt for signatures_cnt, _ in sizes]
+        message_sizes = [message_size for _, message_size in sizes]
+        stat[stage] = {
+            "avg_signatures": sum(signatures) / len(signatures),
+            "max_signatures": max(signatures),
+            "avg_message_size": sum(message_sizes) / len(message_sizes),
+            "max_message_size": max(message_sizes),
+        }
+    return stat
+
+
+def calc_ordering_stat(transaction_inits, transaction_commit_infos, transaction_orders):
+    ordering_delays = []
+    total_order_latencies = []
+    for transaction, orders in transaction_orders.items():
+        for commit_info in transaction_commit_infos.get(transaction, []):
+            order_timestamp = orders.get(commit_info.process_id)
+            if order_timestamp is not None:
+                ordering_delays.append(order_timestamp - commit_info.commit_timestamp)
+
+        init_info = transaction_inits.get(transaction)
+        if init_info is not None and orders.get(init_info.process_id) is not None:
+            total_order_latencies.append(orders[init_info.process_id] - init_info.init_timestamp)
+
+    if len(ordering_delays) == 0 or len(total_order_latencies) == 0:
+        return None, None
+    return sum(ordering_delays) / len(ordering_delays), sum(total_order_latencies) / len(total_order_latencies)
+
+
+def calc_buffering_stat(buffering_delays):
+    if len(buffering_delays) == 0:
+        return None
+
+    buffered_delays = [delay for delay in buffering_delays if delay > 0]
+    return {
+        "buffered_share": len(buffered_delays) / len(buffering_delays),
+        "avg_buffering_delay": sum(buffering_delays) / len(buffering_delays) / 1e9,
+        "avg_buffering_delay_of_buffered":
+            sum(buffered_delays) / len(buffered_delays) / 1e9 if len(buffered_delays) != 0 else 0,
+        "max_buffering_delay": max(buffering_delays) / 1e9,
+    }
+
+
 def calculate_stat(protocol, directory, n):
     data = parse_data_from_files(directory, n)
 
@@ -255,13 +473,15 @@ def calculate_stat(protocol, directory, n):
             sent_messages=data["sent_messages"],
             received_messages=data["received_messages"]
         )
-    min_transaction_latency, max_transaction_latency, avg_transaction_latency, avg_messages_exchanged, throughput = \
-        calc_transaction_stat(
+    min_transaction_latency, max_transaction_latency, avg_transaction_latency, avg_messages_exchanged, throughput, \
+        avg_dissemination_latency, disseminated_transaction_cnt = calc_transaction_stat(
             n=n,
             transaction_inits=data["transaction_inits"],
             transaction_commit_infos=data["transaction_commit_infos"],
             simulation_start=data["simulation_start"],
-            simulation_end=data["simulation_end"]
+            simulation_end=data["simulation_end"],
+            crashed_processes=data["crashed_processes"],
+            epochs=data["epochs"]
         )
 
     results = {
@@ -271,10 +491,44 @@ def calculate_stat(protocol, directory, n):
         "min_transaction_latency": min_transaction_latency / 1e9,
         "max_transaction_latency": max_transaction_latency / 1e9,
         "avg_transaction_latency": avg_transaction_latency / 1e9,
+        "avg_dissemination_latency": avg_dissemination_latency / 1e9,
         "avg_messages_exchanged": avg_messages_exchanged,
+        "avg_messages_sent": len(data["sent_messages"]) / max(len(data["transaction_inits"]), 1),
         "throughput": throughput,
     }
 
+    if len(data["crashed_processes"]) != 0 or len(data["epochs"]) > 1:
+        results["crashed_processes"] = sorted(data["crashed_processes"])
+        results["epochs"] = len(data["epochs"])
+        results["disseminated_transactions"] = disseminated_transaction_cnt
+        results["transactions"] = len(data["transaction_inits"])
+
+    if len(data["delivery_paths"]) != 0:
+        results["delivery_paths"] = data["delivery_paths"]
+
+    if data["rejected_messages"] != 0:
+        results["rejected_messages"] = data["rejected_messages"]
+
+    avg_ordering_delay, avg_total_order_latency = calc_ordering_stat(
+        transaction_inits=data["transaction_inits"],
+        transaction_commit_infos=data["transaction_commit_infos"],
+        transaction_orders=data["transaction_orders"]
+    )
+    if avg_ordering_delay is not None:
+        results["avg_ordering_delay"] = avg_ordering_delay / 1e9
+        results["avg_total_order_latency"] = avg_total_order_latency / 1e9
+
+    buffering_stat = calc_buffering_stat(data["buffering_delays"])
+    if buffering_stat is not None:
+        results["buffering_stat"] = buffering_stat
+
+    avg_samples = calc_avg_samples(data["transaction_samples"])
+    if avg_samples is not None:
+        results["avg_samples"] = avg_samples
+
+    if len(data["certificate_sizes"]) != 0:
+        results["certificate_sizes"] = calc_certificate_stat(data["certificate_sizes"])
+
     if protocol in {CONSISTENT_ACCOUNTABILITY, RELIABLE_ACCOUNTABILITY}:
         results["own_witness_sets_diff_metrics"] = \
             get_witness_sets_diff_metrics(
@@ -282,6 +536,25 @@ def calculate_stat(protocol, directory, n):
                 n=n,
                 ws_type="own"
             )
+        results["identical_own_witness_sets_share"] = \
+            get_identical_witness_sets_share(
+                transaction_witness_sets=data["transaction_witness_sets"],
+                n=n,
+                ws_type="own"
+            )
+        results["avg_own_witness_set_size"] = \
+            get_avg_witness_set_size(
+                transaction_witness_sets=data["transaction_witness_sets"],
+                transaction_self_selected_witnesses=data["transaction_self_selected_witnesses"],
+                transaction_inits=data["transaction_inits"],
+                ws_type="own"
+            )
+        if any(epoch != 0 for epochs in data["transaction_history_epochs"].values() for epoch in epochs):
+            results["same_history_epoch_share"] = \
+                get_same_history_epoch_share(
+                    transaction_history_epochs=data["transaction_history_epochs"],
+                    n=n
+                )
         if protocol == RELIABLE_ACCOUNTABILITY:
             results["pot_witness_sets_diff_metrics"] = \
                 get_witness_sets_diff_metrics(
@@ -298,16 +571,46 @@ def calculate_stat(protocol, directory, n):
     return results
 
 
-if __name__ == "__main__":
-    input_file = open("input.json")
+# Latency until the source delivers and throughput are not compared: gossip delivers at the source immediately,
+# and throughput is bounded by the rate at which transactions are initialised
+COMPARED_METRICS = [
+    ("avg_dissemination_latency", "Average latency until delivered by all processes"),
+    ("avg_messages_sent", "Average number of sent messages per one transaction"),
+]
+
+
+def calculate_stat_of_run(directory):
+    input_file = open(f"{directory}/input.json")
     input_json = json.load(input_file)
     protocol = input_json["protocol"]
     process_cnt = input_json["parameters"]["n"]
 
-    print(f"Protocol: {protocol}, {process_cnt} processes")
+    stat = calculate_stat(protocol=protocol, directory=f"{directory}/outputs", n=process_cnt)
+    return protocol, process_cnt, stat
+
+
+def print_comparison(stat, baseline_protocol, baseline_stat):
+    print(f"Comparison with the {baseline_protocol} baseline:")
+    for metric, description in COMPARED_METRICS:
+        value, baseline_value = stat[metric], baseline_stat[metric]
+        if baseline_value == 0:
+            print(f"\t{description}: {value} (baseline: {baseline_value})")
+            continue
+        print(f"\t{description}: {value} (baseline: {baseline_value}, ratio: {value / baseline_value:.2f})")
     print()
 
-    stat = calculate_stat(protocol=protocol, directory="outputs", n=process_cnt)
+
+if __name__ == "__main__":
+    arg_parser = argparse.ArgumentParser()
+    arg_parser.add_argument(
+        "--baseline",
+        help="directory with input.json and outputs of a baseline run (e.g. gossip) to compare the results with")
+    args = arg_parser.parse_args()
+
+    protocol, process_cnt, stat = calculate_stat_of_run(".")
+
+    print(f"Protocol: {protocol}, {process_cnt} processes")
+    print()
 
     min_message_latency, max_message_latency, avg_message_latency = \
         stat["min_message_latency"], stat["max_message_latency"], stat["avg_message_latency"]
@@ -325,17 +628,74 @@ if __name__ == "__main__":
     print(f"\tMinimal: {min_transaction_latency}")
     print(f"\tMaximal: {max_transaction_latency}")
     print(f"\tAverage: {avg_transaction_latency}")
+    print(f"\tAverage until delivered by all correct processes: {stat['avg_dissemination_latency']}")
     print()
 
     print(f"Average number of exchanged messages per one transaction: {avg_messages_exchanged}")
+    print(f"Average number of sent messages per one transaction: {stat['avg_messages_sent']}")
     print()
 
     print(f"Throughput per second: {throughput}")
     print()
 
+    if stat.get("crashed_processes") is not None:
+        print(f"Crashed processes: {stat['crashed_processes']}")
+        print(f"Membership epochs: {stat['epochs']}")
+        print(f"Transactions delivered by all correct members: "
+              f"{stat['disseminated_transactions']} of {stat['transactions']}")
+        print()
+
+    if stat.get("rejected_messages") is not None:
+        print(f"Messages rejected as malformed or unexpected: {stat['rejected_messages']}")
+        print()
+
+    if stat.get("avg_ordering_delay") is not None:
+        print("Total order statistics:")
+        print(f"\tAverage delay between the delivery and the ordered delivery: {stat['avg_ordering_delay']}")
+        print(f"\tAverage latency until ordered by the source: {stat['avg_total_order_latency']}")
+        print()
+
+    if stat.get("buffering_stat") is not None:
+        buffering_stat = stat["buffering_stat"]
+        print("FIFO buffering statistics:")
+        print(f"\tShare of buffered transactions: {buffering_stat['buffered_share']:.2%}")
+        print(f"\tAverage buffering delay: {buffering_stat['avg_buffering_delay']}")
+        print(f"\tAverage buffering delay of buffered transactions: {buffering_stat['avg_buffering_delay_of_buffered']}")
+        print(f"\tMaximal buffering delay: {buffering_stat['max_buffering_delay']}")
+        print()
+
+    if stat.get("delivery_paths") is not None:
+        delivery_paths = stat["delivery_paths"]
+        deliveries_cnt = sum(delivery_paths.values())
+        print("Deliveries per path:")
+        for path, path_deliveries_cnt in sorted(delivery_paths.items()):
+            print(f"\t{path}: {path_deliveries_cnt} ({path_deliveries_cnt / deliveries_cnt:.2%})")
+        print()
+
+    if stat.get("certificate_sizes") is not None:
+        print("Certificates as sent over the network, the signatures are listed without aggregation:")
+        for stage, certificate_stat in sorted(stat["certificate_sizes"].items()):
+            print(f"\t{stage}: {certificate_stat['avg_signatures']:.1f} signatures on average "
+                  f"(at most {certificate_stat['max_signatures']}), "
+                  f"messages of {certificate_stat['avg_message_size']:.0f} bytes on average "
+                  f"(at most {certificate_stat['max_message_size']})")
+        print()
+
+    if stat.get("avg_samples") is not None:
+        print(f"Average number of samples taken by a process per one transaction: {stat['avg_samples']}")
+        print()
+
     if stat.get("own_witness_sets_diff_metrics") is not None:
         own_witness_sets_diff_metrics = stat["own_witness_sets_diff_metrics"]
         print(f"Difference metrics for own witness sets: {own_witness_sets_diff_metrics}")
+        if stat.get("identical_own_witness_sets_share") is not None:
+            print(f"Transactions with identical own witness sets at all processes: "
+                  f"{stat['identical_own_witness_sets_share']:.2%}")
+        if stat.get("avg_own_witness_set_size") is not None:
+            print(f"Average own witness set size: {stat['avg_own_witness_set_size']:.2f}")
+        if stat.get("same_history_epoch_share") is not None:
+            print(f"Transactions with witness sets selected with the same history epoch at all processes: "
+                  f"{stat['same_history_epoch_share']:.2%}")
         print()
 
     if stat.get("pot_witness_sets_diff_metrics") is not None:
@@ -347,3 +707,7 @@ if __name__ == "__main__":
         histories_diff_metrics = stat["histories_diff_metrics"]
         print(f"Difference metrics of histories: {histories_diff_metrics}")
         print()
+
+    if args.baseline is not None:
+        baseline_protocol, _, baseline_stat = calculate_stat_of_run(args.baseline)
+        print_comparison(stat, baseline_protocol, baseline_stat)
@@ -1,11 +1,13 @@
 package actor
 
 import (
+	"fmt"
 	"log"
 	"stochastic-checking-simulation/context"
 	"stochastic-checking-simulation/impl/eventlogger"
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/utils"
+	"sync"
 )
 
 const ChannelSize = 200
@@ -34,6 +36,9 @@ type Actor struct {
 
 	mailbox  *Mailbox
 	readChan chan []byte
+
+	stopChan chan struct{}
+	stopOnce sync.Once
 }
 
 func (a *Actor) InitActor(
@@ -43,16 +48,33 @@ func (a *Actor) InitActor(
 	logger *log.Logger,
 	retransmissionTimeoutNs int,
 ) {
+	writeChan := a.init(processIndex, len(nodeAddresses), actorInstance, logger, retransmissionTimeoutNs)
+
+	a.mailbox = newMailbox(processIndex, nodeAddresses, writeChan, a.readChan)
+
+	a.actorInstance.Start(a.context, a.eventLogger)
+
+	a.receiveMessages()
+}
+
+// init sets up the actor of a system of the given number of processes, including the main server,
+// and returns the channel of the packets it sends.
+func (a *Actor) init(
+	processIndex int32,
+	processCount int,
+	actorInstance ActorInstance,
+	logger *log.Logger,
+	retransmissionTimeoutNs int,
+) chan context.Packet {
 	a.receivedMessages = make(map[int32]map[int32]bool)
-	for i := range nodeAddresses {
+	for i := 0; i < processCount; i++ {
 		a.receivedMessages[int32(i)] = make(map[int32]bool)
 	}
 
 	a.readChan = make(chan []byte, ChannelSize)
+	a.stopChan = make(chan struct{})
 	writeChan := make(chan context.Packet, ChannelSize)
 
-	a.mailbox = newMailbox(processIndex, nodeAddresses, writeChan, a.readChan)
-
 	a.actorInstance = actorInstance
 	a.eventLogger = eventlogger.InitEventLogger(processIndex, logger)
 
@@ -64,15 +86,33 @@ func (a *Actor) InitActor(
 			a.eventLogger,
 		)
 
-	a.actorInstance.Start(a.context, a.eventLogger)
+	return writeChan
+}
 
-	a.receiveMessages()
+// Stop crashes the actor: it stops sending and receiving messages, and InitActor returns
+// once the message being processed, if any, is processed.
+func (a *Actor) Stop() {
+	a.stopOnce.Do(func() {
+		a.context.Stop()
+		if a.mailbox != nil {
+			a.mailbox.close()
+		}
+		close(a.stopChan)
+	})
 }
 
 func (a *Actor) receiveMessages() {
-	for data := range a.readChan {
+	for {
+		var data []byte
+		select {
+		case <-a.stopChan:
+			return
+		case data = <-a.readChan:
+		}
+
 		msg, err := utils.Unmarshal(data)
 		if err != nil {
+			a.eventLogger.OnMessageRejected(-1, err.Error())
 			continue
 		}
 
@@ -86,15 +126,22 @@ func (a *Actor) receiveMessages() {
 		sender := msg.Sender
 		stamp := msg.Stamp
 
-		a.eventLogger.OnMessageReceived(sender, stamp)
+		// Messages of unknown senders can neither be acknowledged nor told apart from duplicates
+		receivedMessages, knownSender := a.receivedMessages[sender]
+		if !knownSender {
+			a.eventLogger.OnMessageRejected(sender, fmt.Sprintf("sender %d is unknown", sender))
+			continue
+		}
 
-		a.context.SendAck(sender, stamp)
+		a.eventLogger.OnMessageReceived(sender, stamp)
 
-		if a.receivedMessages[sender][stamp] {
-			continue
+		// A message is acknowledged once it is processed, so that the sender retransmits it
+		// to a process which crashes before it has persisted the effects of the message
+		if !receivedMessages[stamp] {
+			receivedMessages[stamp] = true
+			a.actorInstance.ProcessMessage(msg)
 		}
-		a.receivedMessages[sender][stamp] = true
 
-		a.actorInstance.ProcessMessage(msg)
+		a.context.SendAck(sender, stamp)
 	}
 }
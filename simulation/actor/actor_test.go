@@ -0,0 +1,170 @@
+package actor
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log"
+	"stochastic-checking-simulation/context"
+	"stochastic-checking-simulation/impl/eventlogger"
+	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols"
+	_ "stochastic-checking-simulation/impl/protocols/all"
+	"stochastic-checking-simulation/impl/utils"
+	"testing"
+)
+
+// fuzzedProcessCount is the number of processes of the system the fuzzed actor is part of, the main server excluded
+const fuzzedProcessCount = 4
+
+// protocolInstance runs a process of the protocol and passes it every broadcast instance message,
+// without checking that the message belongs to the protocol as the node does.
+type protocolInstance struct {
+	protocol *protocols.Protocol
+	process  protocols.Process
+}
+
+func (pi *protocolInstance) Start(context *context.ReliableContext, eventLogger *eventlogger.EventLogger) {
+	protocolParameters := pi.protocol.NewParameters()
+
+	pids := make([]string, fuzzedProcessCount)
+	for i := range pids {
+		pids[i] = fmt.Sprintf("10.0.0.%d:5001", i+2)
+	}
+
+	pi.process = pi.protocol.NewProcess()
+	pi.process.InitProcess(
+		0,
+		pids,
+		&parameters.Parameters{ProcessCount: fuzzedProcessCount, FaultyProcesses: 1, Protocol: protocolParameters},
+		context,
+		eventLogger,
+		pi,
+	)
+}
+
+func (pi *protocolInstance) ProcessMessage(message *messages.Message) {
+	if content, isBroadcastInstanceMessage := message.Content.(*messages.Message_BroadcastInstanceMessage); isBroadcastInstanceMessage {
+		pi.process.HandleMessage(message.Sender, content.BroadcastInstanceMessage)
+	}
+}
+
+func (pi *protocolInstance) Deliver(*messages.BroadcastInstance, int32) {}
+
+// receive passes the datagrams to the actor of a process of the protocol one by one, and returns the actor
+// once it has processed all of them.
+func receive(protocol *protocols.Protocol, datagrams ...[]byte) *Actor {
+	a := &Actor{}
+	writeChan := a.init(0, fuzzedProcessCount+1, &protocolInstance{protocol: protocol},
+		log.New(io.Discard, "", 0), 1000000)
+	// Sending a datagram returns only once the previous one is processed
+	a.readChan = make(chan []byte)
+
+	sent := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-writeChan:
+			case <-sent:
+				return
+			}
+		}
+	}()
+	defer close(sent)
+
+	a.actorInstance.Start(a.context, a.eventLogger)
+
+	received := make(chan struct{})
+	go func() {
+		a.receiveMessages()
+		close(received)
+	}()
+	for _, datagram := range datagrams {
+		a.readChan <- datagram
+	}
+	// The last datagram is processed once the next one is read
+	a.readChan <- nil
+	a.Stop()
+	<-received
+
+	return a
+}
+
+// broadcastInstanceMessages returns a message of every protocol within the transaction of the author.
+func broadcastInstanceMessages(sender int32, author int32, seqNumber int32) [][]byte {
+	bInstance := &messages.BroadcastInstance{Author: author, SeqNumber: seqNumber}
+	bMessages := []*messages.BroadcastInstanceMessage{
+		{Message: &messages.BroadcastInstanceMessage_BrachaProtocolMessage{
+			BrachaProtocolMessage: &messages.BrachaProtocolMessage{Stage: messages.BrachaProtocolMessage_INITIAL, Value: 1},
+		}},
+		{Message: &messages.BroadcastInstanceMessage_ConsistentProtocolMessage{
+			ConsistentProtocolMessage: &messages.ConsistentProtocolMessage{Stage: messages.ConsistentProtocolMessage_VERIFY, Value: 1},
+		}},
+		{Message: &messages.BroadcastInstanceMessage_ReliableProtocolMessage{
+			ReliableProtocolMessage: &messages.ReliableProtocolMessage{Stage: messages.ReliableProtocolMessage_NOTIFY, Value: 1},
+		}},
+		{Message: &messages.BroadcastInstanceMessage_RecoveryProtocolMessage{
+			RecoveryProtocolMessage: &messages.RecoveryProtocolMessage{
+				Stage:                   messages.RecoveryProtocolMessage_RECOVER,
+				ReliableProtocolMessage: &messages.ReliableProtocolMessage{Value: 1},
+			},
+		}},
+		{Message: &messages.BroadcastInstanceMessage_ScalableProtocolMessage{
+			ScalableProtocolMessage: &messages.ScalableProtocolMessage{Stage: messages.ScalableProtocolMessage_GOSSIP, Value: 1},
+		}},
+	}
+	for _, protocol := range protocols.List() {
+		// Protocol messages whose fields all have default values are empty payloads
+		if _, isPayloadCodec := protocol.Codec.(*protocols.PayloadCodec); isPayloadCodec {
+			bMessages = append(bMessages, &messages.BroadcastInstanceMessage{
+				Message: &messages.BroadcastInstanceMessage_GenericProtocolMessage{
+					GenericProtocolMessage: &messages.GenericProtocolMessage{Protocol: protocol.Name},
+				},
+			})
+		}
+	}
+
+	datagrams := make([][]byte, len(bMessages))
+	for i, bMessage := range bMessages {
+		bMessage.BroadcastInstance = bInstance
+		datagrams[i], _ = utils.Marshal(&messages.Message{
+			Sender:  sender,
+			Stamp:   int32(i),
+			Content: &messages.Message_BroadcastInstanceMessage{BroadcastInstanceMessage: bMessage},
+		})
+	}
+	return datagrams
+}
+
+func TestReceiveMessages_malformedMessagesRejected(t *testing.T) {
+	bracha, _ := protocols.Get("bracha")
+
+	// Messages of a sender with the same stamp are duplicates, which are dropped without being rejected
+	a := receive(bracha,
+		[]byte{0xff},
+		broadcastInstanceMessages(fuzzedProcessCount+1, 1, 0)[0],
+		broadcastInstanceMessages(fuzzedProcessCount, 1, 0)[0],
+		broadcastInstanceMessages(1, fuzzedProcessCount, 0)[0],
+		broadcastInstanceMessages(2, 1, -1)[0],
+		broadcastInstanceMessages(3, 1, 0)[1],
+		broadcastInstanceMessages(3, 1, 0)[0],
+	)
+
+	assert.Equal(t, int64(6), a.eventLogger.RejectedMessages())
+}
+
+func FuzzActor_receiveMessages(f *testing.F) {
+	for _, seed := range [][3]int32{{1, 1, 0}, {1, 0, 0}, {0, 0, 0}, {3, 2, 5}, {4, 1, 0}, {1, 4, 0}, {1, 1, -1}} {
+		datagrams := broadcastInstanceMessages(seed[0], seed[1], seed[2])
+		for i, datagram := range datagrams {
+			f.Add(datagram, datagrams[(i+1)%len(datagrams)])
+		}
+	}
+	f.Add([]byte{}, []byte{0xff})
+	f.Fuzz(func(t *testing.T, first []byte, second []byte) {
+		for _, protocol := range protocols.List() {
+			receive(protocol, first, second)
+		}
+	})
+}
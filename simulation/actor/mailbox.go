@@ -5,11 +5,10 @@ import (
 	"math"
 	"net"
 	"stochastic-checking-simulation/context"
-	"strconv"
-	"strings"
 )
 
-const BufferSize = 1024
+// BufferSize fits the largest message, so that datagrams are not truncated
+const BufferSize = context.MaxMessageSize
 
 var ReadBufferSize = int(math.Pow(2, 20))
 
@@ -36,24 +35,20 @@ func newMailbox(
 
 	m.udpAddresses = make([]*net.UDPAddr, len(addresses))
 	for i, currAddress := range addresses {
-		hostAndPort := strings.Split(currAddress, ":")
-		port, err := strconv.Atoi(hostAndPort[1])
+		udpAddress, err := net.ResolveUDPAddr("udp", currAddress)
 		if err != nil {
-			log.Fatalf("Port %s is not an integer value\n", hostAndPort[1])
-		}
-		m.udpAddresses[i] = &net.UDPAddr{
-			Port: port,
-			IP:   net.ParseIP(hostAndPort[0]),
+			log.Fatalf("Could not resolve address %s: %v\n", currAddress, err)
 		}
+		m.udpAddresses[i] = udpAddress
 	}
 
-	log.Printf("Listening To %s.\n", addresses[m.id])
-
 	conn, err := net.ListenUDP("udp", m.udpAddresses[m.id])
 	if err != nil {
 		log.Fatalf("P%d: Listening failed: %e\n", m.id, err)
 	}
 
+	log.Printf("Listening To %s.\n", addresses[m.id])
+
 	err = conn.SetReadBuffer(ReadBufferSize)
 	if err != nil {
 		log.Fatalf("P%d: Could not set read buffer size to %d", m.id, ReadBufferSize)
@@ -67,11 +62,16 @@ func newMailbox(
 	return m
 }
 
+// close stops listening for incoming messages.
+func (m *Mailbox) close() {
+	m.conn.Close()
+}
+
 func (m *Mailbox) listenForMessages() {
 	defer m.conn.Close()
 
+	buf := make([]byte, BufferSize)
 	for {
-		buf := make([]byte, BufferSize)
 		size, _, err := m.conn.ReadFromUDP(buf)
 
 		if err != nil {
@@ -79,7 +79,8 @@ func (m *Mailbox) listenForMessages() {
 			return
 		}
 
-		m.readChannel <- buf[:size]
+		// Datagrams are copied out of the buffer, so that small messages do not each hold a buffer of the largest size
+		m.readChannel <- append([]byte(nil), buf[:size]...)
 	}
 }
 
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"stochastic-checking-simulation/impl/hashing"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+	"stochastic-checking-simulation/impl/utils"
+	"strconv"
+	"strings"
+)
+
+var (
+	processCount = flag.Int("n", 0, "Number of processes in the system (excluding the main server)")
+	nodeIdSize   = flag.Int("node_id_size", 256, "node id size")
+	numberOfBins = flag.Int("number_of_bins", 32, "number of bins in history hash")
+	hash         = flag.String("hash", "", "hash function, as the hash parameter of the input file")
+	metric       = flag.String("metric", "", "metric of distances, as the metric parameter of the input file")
+	transactions = flag.Int("transactions", 1000,
+		"number of transactions for which distances of all processes are computed")
+	history = flag.Int("history", 0,
+		"number of transactions inserted into the history hash before the first transaction")
+	targets = flag.String("targets", "",
+		"comma separated expected witness set sizes to compute radii for, e.g. \"8,16\"")
+	histogramBins = flag.Int("histogram_bins", 20, "number of bins in the histogram of distances")
+	seed          = flag.Int64("seed", 1, "seed of the random choice of transactions")
+	baseIpAddress = flag.String("base_ip", "10.0.0.1", "Ip address of the main server, node ids are derived from it")
+	basePort      = flag.Int("base_port", 5001, "Port of the main server, node ids are derived from it")
+)
+
+func main() {
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", 0)
+
+	ap := &accountability.Parameters{
+		NodeIdSize:   *nodeIdSize,
+		NumberOfBins: *numberOfBins,
+		Hash:         *hash,
+		Metric:       *metric,
+		// The selector parameters do not change distances, but must be valid
+		MinOwnWitnessSetSize: 1,
+		MinPotWitnessSetSize: 1,
+		WitnessThreshold:     1,
+	}
+	p := &parameters.Parameters{ProcessCount: *processCount, Protocol: ap}
+	if _, e := p.Validate(); e != nil {
+		logger.Fatalf("Invalid parameters:\n%v", e)
+	}
+	sizes, e := parseTargets(*targets)
+	if e != nil {
+		logger.Fatal(e)
+	}
+	if *transactions <= 0 || *histogramBins <= 0 {
+		logger.Fatal("transactions and histogram_bins must be positive")
+	}
+
+	n := *processCount
+	// Node ids are the pids a local run with the same base address would have
+	pids := utils.GeneratePids(*baseIpAddress, *basePort, 1, n+1, logger)[:n]
+
+	distances := sampleDistances(ap, pids)
+	sort.Float64s(distances)
+
+	metricName := ap.Metric
+	if metricName == "" {
+		metricName = "l1"
+	}
+	fmt.Printf("Distances of %d processes from %d transactions, metric %s, %d bins of %d values\n",
+		n, *transactions, metricName, ap.NumberOfBins,
+		parameters.BinCapacity(ap.NodeIdSize, ap.NumberOfBins))
+	printSummary(distances)
+	printHistogram(distances, *histogramBins)
+	printRadii(distances, n, sizes)
+}
+
+// sampleDistances returns the distances of all processes from random transactions,
+// delivering every transaction after its distances are computed, as processes do.
+func sampleDistances(ap *accountability.Parameters, pids []string) []float64 {
+	random := rand.New(rand.NewSource(*seed))
+	hasher := ap.Hasher()
+	selector := &hashing.WitnessesSelector{Hasher: hasher, Metric: ap.DistanceMetric()}
+	historyHash := ap.NewHistoryHash()
+
+	randomTransaction := func() (string, int32) {
+		return pids[random.Intn(len(pids))], random.Int31()
+	}
+	for i := 0; i < *history; i++ {
+		author, seqNumber := randomTransaction()
+		historyHash.Insert(utils.TransactionToBytes(author, int64(seqNumber)))
+	}
+
+	distances := make([]float64, 0, *transactions*len(pids))
+	for i := 0; i < *transactions; i++ {
+		author, seqNumber := randomTransaction()
+		distances = selector.Distances(pids, author, seqNumber, historyHash, distances)
+		historyHash.Insert(utils.TransactionToBytes(author, int64(seqNumber)))
+	}
+	return distances
+}
+
+func parseTargets(targets string) ([]int, error) {
+	var sizes []int
+	for _, target := range strings.Split(targets, ",") {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		size, e := strconv.Atoi(target)
+		if e != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid witness set size in targets: %q", target)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// quantile returns the q-quantile of the sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func printSummary(sorted []float64) {
+	mean := 0.0
+	for _, d := range sorted {
+		mean += d
+	}
+	mean /= float64(len(sorted))
+	variance := 0.0
+	for _, d := range sorted {
+		variance += (d - mean) * (d - mean)
+	}
+	variance /= float64(len(sorted))
+
+	fmt.Printf("Mean: %.4g, standard deviation: %.4g, min: %.4g, max: %.4g\n",
+		mean, math.Sqrt(variance), sorted[0], sorted[len(sorted)-1])
+	fmt.Println("Quantiles:")
+	for _, q := range []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99} {
+		fmt.Printf("  %5.1f%%: %.6g\n", 100*q, quantile(sorted, q))
+	}
+}
+
+func printHistogram(sorted []float64, bins int) {
+	low, high := sorted[0], sorted[len(sorted)-1]
+	width := (high - low) / float64(bins)
+	if width == 0 {
+		width = 1
+	}
+	counts := make([]int, bins)
+	maxCount := 0
+	for _, d := range sorted {
+		bin := int((d - low) / width)
+		if bin >= bins {
+			bin = bins - 1
+		}
+		counts[bin]++
+		if counts[bin] > maxCount {
+			maxCount = counts[bin]
+		}
+	}
+
+	const barWidth = 50
+	fmt.Println("Histogram:")
+	for i, count := range counts {
+		fmt.Printf("  [%10.6g, %10.6g) %6.2f%% %s\n",
+			low+float64(i)*width, low+float64(i+1)*width,
+			100*float64(count)/float64(len(sorted)),
+			strings.Repeat("#", count*barWidth/maxCount))
+	}
+}
+
+// printRadii prints, for every target size, the smallest radius such that the expected number of processes
+// closer than the radius, which are selected in addition to the closest w or v ones, is at least the target size.
+func printRadii(sorted []float64, n int, sizes []int) {
+	if len(sizes) == 0 {
+		return
+	}
+	integral := true
+	for _, d := range sorted {
+		if d != math.Trunc(d) {
+			integral = false
+			break
+		}
+	}
+
+	fmt.Println("Radii (wr, vr) for expected witness set sizes:")
+	for _, size := range sizes {
+		if size > n {
+			fmt.Printf("  %d: larger than n\n", size)
+			continue
+		}
+		// Processes are selected if their distance is strictly smaller than the radius
+		i := int(math.Ceil(float64(size)/float64(n)*float64(len(sorted)))) - 1
+		radius := math.Nextafter(sorted[i], math.Inf(1))
+		if integral {
+			radius = sorted[i] + 1
+		}
+		closer := sort.SearchFloat64s(sorted, radius)
+		fmt.Printf("  %d: radius %.6g, expected size %.4g\n",
+			size, radius, float64(n)*float64(closer)/float64(len(sorted)))
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// output writes lines of all children to the stderr of the launcher, prefixed with the name of the child.
+type output struct {
+	mutex sync.Mutex
+}
+
+func (o *output) println(name string, line string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	fmt.Fprintf(os.Stderr, "[%s] %s\n", name, line)
+}
+
+// child is a process started by the launcher, either the main server or a node.
+type child struct {
+	name string
+	cmd  *exec.Cmd
+
+	// done is closed once the process exits, err is its exit error
+	done chan struct{}
+	err  error
+	// killed is set if the launcher terminated the process
+	killed bool
+	mutex  sync.Mutex
+}
+
+// startChild starts the binary with the given arguments and streams its stderr.
+// onLine is called for every line the process writes to stderr.
+func startChild(
+	name string,
+	binary string,
+	args []string,
+	out *output,
+	onLine func(line string),
+) (*child, error) {
+	c := &child{
+		name: name,
+		cmd:  exec.Command(binary, args...),
+		done: make(chan struct{}),
+	}
+
+	stderr, e := c.cmd.StderrPipe()
+	if e != nil {
+		return nil, e
+	}
+	if e = c.cmd.Start(); e != nil {
+		return nil, fmt.Errorf("could not start %s: %w", name, e)
+	}
+
+	go func() {
+		c.stream(stderr, out, onLine)
+		// The pipe is closed by Wait, so all lines must be read before waiting for the process
+		c.err = c.cmd.Wait()
+		close(c.done)
+	}()
+
+	return c, nil
+}
+
+func (c *child) stream(stderr io.Reader, out *output, onLine func(line string)) {
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		line := scanner.Text()
+		out.println(c.name, line)
+		if onLine != nil {
+			onLine(line)
+		}
+	}
+}
+
+func (c *child) exited() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// kill terminates the process if it is still running and waits until it exits.
+func (c *child) kill() {
+	c.mutex.Lock()
+	if !c.exited() {
+		c.killed = true
+		_ = c.cmd.Process.Kill()
+	}
+	c.mutex.Unlock()
+	<-c.done
+}
+
+// failure returns the exit error of the process, unless the process was terminated by the launcher.
+func (c *child) failure() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.killed {
+		return nil
+	}
+	return c.err
+}
+
+// waitAll returns a channel which is closed once all the given processes exit.
+func waitAll(children []*child) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		for _, c := range children {
+			<-c.done
+		}
+		close(done)
+	}()
+	return done
+}
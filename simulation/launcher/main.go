@@ -0,0 +1,262 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"stochastic-checking-simulation/impl/parameters"
+	_ "stochastic-checking-simulation/impl/protocols/all"
+	"stochastic-checking-simulation/impl/utils"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	ip = "127.0.0.1"
+	// The main server is ready to receive messages once it logs this line
+	readinessLine = utils.MainServerReadyLine
+	readinessWait = 10 * time.Second
+
+	mainServerPackage = "stochastic-checking-simulation/simulation/mainserver"
+	nodePackage       = "stochastic-checking-simulation/simulation/node"
+)
+
+var (
+	inputFile    = flag.String("input_file", "", "Path to the input file in json format")
+	runsDir      = flag.String("runs_dir", "runs", "Directory in which the run directory is created")
+	transactions = flag.Int("transactions", 5,
+		"number of transactions for every process to broadcast")
+	transactionInitTimeoutNs = flag.Int("transaction_init_timeout_ns", 10000000,
+		"timeout a process should wait before initialising a new transaction")
+	basePort                = flag.Int("base_port", 5001, "Port of the main server, nodes use the following ports")
+	retransmissionTimeoutNs = flag.Int(
+		"retransmission_timeout_ns",
+		1000000000,
+		"retransmission timeout in ns")
+	deadline = flag.Duration(
+		"deadline",
+		2*time.Minute,
+		"Time after which the run is stopped if the processes did not finish")
+	nodeFlags = flag.String(
+		"node_flags",
+		"",
+		"Additional flags passed to every node, e.g. \"--total_order --fifo\"")
+)
+
+func main() {
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+
+	input, e := parameters.ReadInput(*inputFile)
+	if e != nil {
+		logger.Fatal(e)
+	}
+	warnings, e := input.Validate()
+	for _, warning := range warnings {
+		logger.Printf("Warning: %s\n", warning)
+	}
+	if e != nil {
+		logger.Fatalf("Invalid parameters for protocol %s:\n%v", input.Protocol, e)
+	}
+	n := input.Parameters.ProcessCount
+
+	runDir := filepath.Join(*runsDir, time.Now().Format("20060102-150405"))
+	if e = prepareRunDir(runDir); e != nil {
+		logger.Fatal(e)
+	}
+	logger.Printf("Run directory: %s\n", runDir)
+
+	// Binaries are built once, instead of compiling the sources for every process with go run
+	binDir, e := os.MkdirTemp("", "launcher")
+	if e != nil {
+		logger.Fatal(e)
+	}
+	mainServerBinary := filepath.Join(binDir, "mainserver")
+	nodeBinary := filepath.Join(binDir, "node")
+	if e = build(mainServerBinary, mainServerPackage); e == nil {
+		e = build(nodeBinary, nodePackage)
+	}
+	if e != nil {
+		os.RemoveAll(binDir)
+		logger.Fatal(e)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	out := &output{}
+	outcome, children := run(runDir, n, mainServerBinary, nodeBinary, out, signals, logger)
+
+	failed := false
+	for _, c := range children {
+		c.kill()
+		if e = c.failure(); e != nil {
+			logger.Printf("%s failed: %v\n", c.name, e)
+			failed = true
+		}
+	}
+	os.RemoveAll(binDir)
+	logger.Printf("Run %s, logs are in %s\n", outcome, runDir)
+
+	// Only the stress test is expected to run until the deadline
+	if failed || outcome != "finished" && !(outcome == "deadline reached" && stressTest()) {
+		os.Exit(1)
+	}
+}
+
+// stressTest returns whether the nodes run the stress test, which never finishes.
+func stressTest() bool {
+	for _, nodeFlag := range strings.Fields(*nodeFlags) {
+		name, value, _ := strings.Cut(strings.TrimLeft(nodeFlag, "-"), "=")
+		if name == "stress_test" && value != "false" {
+			return true
+		}
+	}
+	return false
+}
+
+// run starts the main server and the nodes, and waits until the nodes exit after the shutdown,
+// the deadline passes or the launcher is interrupted. It returns how the run ended and all started processes.
+func run(
+	runDir string,
+	n int,
+	mainServerBinary string,
+	nodeBinary string,
+	out *output,
+	signals chan os.Signal,
+	logger *log.Logger,
+) (string, []*child) {
+	outputs := filepath.Join(runDir, "outputs")
+	deadlineTimer := time.NewTimer(*deadline)
+
+	ready := make(chan struct{})
+	mainServer, e := startChild(
+		"mainserver",
+		mainServerBinary,
+		[]string{
+			"--n", strconv.Itoa(n),
+			"--nodes", "1",
+			"--base_ip", ip,
+			"--base_port", strconv.Itoa(*basePort),
+			"--log_file", filepath.Join(outputs, "mainserver.txt"),
+			"--retransmission_timeout_ns", strconv.Itoa(*retransmissionTimeoutNs),
+		},
+		out,
+		func(line string) {
+			if strings.Contains(line, readinessLine) {
+				select {
+				case <-ready:
+				default:
+					close(ready)
+				}
+			}
+		})
+	if e != nil {
+		logger.Print(e)
+		return "failed", nil
+	}
+	children := []*child{mainServer}
+
+	// Nodes start once the main server listens, so that their first messages are not lost
+	select {
+	case <-ready:
+	case <-mainServer.done:
+		return "failed", children
+	case <-time.After(readinessWait):
+		logger.Printf("The main server did not start listening in %v\n", readinessWait)
+		return "failed", children
+	case <-signals:
+		return "interrupted", children
+	}
+
+	var nodes []*child
+	for i := 0; i < n; i++ {
+		args := []string{
+			"--input_file", filepath.Join(runDir, "input.json"),
+			"--i", strconv.Itoa(i),
+			"--nodes", "1",
+			"--base_ip", ip,
+			"--base_port", strconv.Itoa(*basePort),
+			"--log_file", filepath.Join(outputs, fmt.Sprintf("process%d.txt", i)),
+			"--transactions", strconv.Itoa(*transactions),
+			"--transaction_init_timeout_ns", strconv.Itoa(*transactionInitTimeoutNs),
+			"--retransmission_timeout_ns", strconv.Itoa(*retransmissionTimeoutNs),
+		}
+		args = append(args, strings.Fields(*nodeFlags)...)
+
+		node, e := startChild(fmt.Sprintf("node %d", i), nodeBinary, args, out, nil)
+		if e != nil {
+			logger.Print(e)
+			return "failed", children
+		}
+		children = append(children, node)
+		nodes = append(nodes, node)
+	}
+
+	select {
+	case <-waitAll(nodes):
+	case <-deadlineTimer.C:
+		return "deadline reached", children
+	case <-signals:
+		return "interrupted", children
+	}
+
+	// The main server stops after retransmitting the shutdown messages for a while
+	select {
+	case <-mainServer.done:
+	case <-deadlineTimer.C:
+	case <-signals:
+		return "interrupted", children
+	}
+	return "finished", children
+}
+
+// prepareRunDir creates the run directory with the input file and the git revision of the sources,
+// in the layout expected by the logs analyzer.
+func prepareRunDir(runDir string) error {
+	if e := os.MkdirAll(filepath.Join(runDir, "outputs"), os.ModePerm); e != nil {
+		return e
+	}
+
+	input, e := os.ReadFile(*inputFile)
+	if e != nil {
+		return e
+	}
+	if e = os.WriteFile(filepath.Join(runDir, "input.json"), input, 0o644); e != nil {
+		return e
+	}
+
+	return os.WriteFile(filepath.Join(runDir, "revision.txt"), []byte(revision()+"\n"), 0o644)
+}
+
+// revision returns the git revision of the sources, marked as dirty if there are uncommitted changes.
+func revision() string {
+	head, e := exec.Command("git", "rev-parse", "HEAD").Output()
+	if e != nil {
+		return "unknown"
+	}
+	rev := strings.TrimSpace(string(head))
+
+	status, e := exec.Command("git", "status", "--porcelain").Output()
+	if e == nil && len(strings.TrimSpace(string(status))) > 0 {
+		rev += "-dirty"
+	}
+	return rev
+}
+
+func build(binary string, pkg string) error {
+	cmd := exec.Command("go", "build", "-o", binary, pkg)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if e := cmd.Run(); e != nil {
+		return fmt.Errorf("could not build %s: %w", pkg, e)
+	}
+	return nil
+}
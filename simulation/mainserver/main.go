@@ -5,6 +5,7 @@ import (
 	"log"
 	"stochastic-checking-simulation/impl/utils"
 	"stochastic-checking-simulation/simulation/actor"
+	"time"
 )
 
 var (
@@ -20,6 +21,11 @@ var (
 		"retransmission_timeout_ns",
 		6000000000,
 		"retransmission timeout in ns")
+	peersFile = flag.String(
+		"peers_file",
+		"",
+		"Path to the json or yaml file listing addresses of the processes and of the main server. "+
+			"If set, nodes, base_ip and base_port are ignored")
 )
 
 func main() {
@@ -30,18 +36,31 @@ func main() {
 
 	n := *processCount
 
-	if (n+1)%*nodes != 0 {
-		logger.Fatal(
-			"Total number of started processes, including the mainserver, must be divisible by the number of nodes",
-		)
-	}
+	var pids []string
+	if *peersFile != "" {
+		var e error
+		pids, e = utils.LoadPeers(*peersFile, n)
+		if e != nil {
+			logger.Fatalf("Invalid peers file:\n%v", e)
+		}
+	} else {
+		if (n+1)%*nodes != 0 {
+			logger.Fatal(
+				"Total number of started processes, including the mainserver, must be divisible by the number of nodes",
+			)
+		}
 
-	processesPerNode := (n + 1) / *nodes
+		processesPerNode := (n + 1) / *nodes
 
-	pids := utils.GeneratePids(*baseIpAddress, *basePort, *nodes, processesPerNode, logger)
+		pids = utils.GeneratePids(*baseIpAddress, *basePort, *nodes, processesPerNode, logger)
+	}
 
-	server := &MainServer{n: n}
+	server := &MainServer{
+		n:               n,
+		shutdownTimeout: 2 * time.Duration(*retransmissionTimeoutNs),
+	}
 
 	a := actor.Actor{}
+	server.stopActor = a.Stop
 	a.InitActor(int32(n), pids, server, logger, *retransmissionTimeoutNs)
 }
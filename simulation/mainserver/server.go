@@ -1,13 +1,23 @@
 package main
 
 import (
+	"log"
 	"stochastic-checking-simulation/context"
 	"stochastic-checking-simulation/impl/eventlogger"
 	"stochastic-checking-simulation/impl/messages"
+	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/utils"
+	"time"
 )
 
 // MainServer is an actor which waits until
 // receiving connections from all the nodes and then starts the simulation.
+// It also orders membership changes: it handles join and leave requests one by one,
+// and sends the membership of every new epoch to all processes.
+// It also agrees on checkpoints of the history: once every member has reported the transactions it has delivered,
+// it sends a checkpoint covering the transactions all members have delivered to all processes.
+// Once all processes which are expected to finish, i.e. are members of every epoch and are not scheduled to crash,
+// report that they have finished, it shuts the processes and itself down.
 type MainServer struct {
 	n int
 
@@ -15,6 +25,27 @@ type MainServer struct {
 	eventLogger *eventlogger.EventLogger
 
 	connectedNodes map[int32]bool
+	initialMembers []int32
+	// Public keys the processes have sent in their started messages, which are kept when they restart
+	publicKeys [][]byte
+	// A node restarted after a crash connects again, which must not start the simulation for the second time
+	// but only for the restarted node
+	started bool
+
+	membership *protocols.Membership
+
+	// The latest history report of every process
+	historyReports map[int32]*messages.HistoryReport
+	// The epoch and the cut of the latest checkpoint
+	checkpointEpoch int32
+	checkpointCut   []int32
+
+	// Processes which reported in their started message that they are expected to finish
+	finishingNodes map[int32]bool
+	finishedNodes  map[int32]bool
+	// The main server keeps retransmitting the shutdown messages for shutdownTimeout before stopping
+	shutdownTimeout time.Duration
+	stopActor       func()
 }
 
 func (ms *MainServer) Start(
@@ -24,23 +55,178 @@ func (ms *MainServer) Start(
 	ms.context = context
 	ms.eventLogger = eventLogger
 	ms.connectedNodes = make(map[int32]bool)
+	ms.finishingNodes = make(map[int32]bool)
+	ms.finishedNodes = make(map[int32]bool)
+	ms.historyReports = make(map[int32]*messages.HistoryReport)
+	ms.checkpointCut = make([]int32, ms.n)
+	ms.publicKeys = make([][]byte, ms.n)
+
+	// The actor starts the main server after binding its socket
+	log.Println(utils.MainServerReadyLine)
 }
 
 func (ms *MainServer) ProcessMessage(message *messages.Message) {
-	ms.connectedNodes[message.Sender] = true
+	// The actor accepts messages of the main server itself, which only a byzantine process forges
+	if message.Sender >= int32(ms.n) {
+		ms.eventLogger.OnMessageRejected(message.Sender, "the main server only handles messages of processes")
+		return
+	}
+
+	switch c := message.Content.(type) {
+	case *messages.Message_Started:
+		if ms.started {
+			ms.restart(message.Sender)
+			return
+		}
+		if ms.connectedNodes[message.Sender] {
+			return
+		}
+		ms.connectedNodes[message.Sender] = true
+		ms.publicKeys[message.Sender] = c.Started.PublicKey
+		if c.Started.Member {
+			ms.initialMembers = append(ms.initialMembers, message.Sender)
+		}
+		if c.Started.Finishes {
+			ms.finishingNodes[message.Sender] = true
+		}
 
-	if len(ms.connectedNodes) == ms.n {
-		ms.eventLogger.OnBroadcastStart()
-		ms.simulate()
+		if len(ms.connectedNodes) == ms.n {
+			ms.started = true
+			ms.membership = protocols.NewMembership(0, ms.initialMembers)
+			ms.eventLogger.OnReconfiguration(ms.membership.Epoch, ms.membership.Members())
+			ms.eventLogger.OnBroadcastStart()
+			ms.simulate()
+		}
+	case *messages.Message_MembershipRequest:
+		if ms.started {
+			ms.changeMembership(message.Sender, c.MembershipRequest.Leave)
+		}
+	case *messages.Message_HistoryReport:
+		if ms.started && len(c.HistoryReport.Delivered) == ms.n {
+			if report := ms.historyReports[message.Sender]; report == nil || report.Epoch < c.HistoryReport.Epoch {
+				ms.historyReports[message.Sender] = c.HistoryReport
+				ms.checkpoint()
+			}
+		}
+	case *messages.Message_Finished:
+		if !ms.finishingNodes[message.Sender] || ms.finishedNodes[message.Sender] {
+			return
+		}
+		ms.finishedNodes[message.Sender] = true
+
+		if len(ms.finishedNodes) == len(ms.finishingNodes) {
+			ms.eventLogger.OnSimulationFinished()
+			ms.shutdown()
+		}
 	}
 }
 
-func (ms *MainServer) simulate() {
+// checkpoint sends a new checkpoint once every member has reported the transactions it has delivered
+// since the latest checkpoint. Its epoch is the lowest epoch of the latest reports of the members,
+// and its cut the element-wise minimum of their numbers of delivered transactions, which never decreases,
+// so processes which report later do not shrink the history of the checkpoints.
+// A member which has crashed stops further checkpoints, which makes the checkpoint history stale.
+func (ms *MainServer) checkpoint() {
+	epoch := int32(-1)
+	for _, member := range ms.membership.Members() {
+		report := ms.historyReports[member]
+		if report == nil {
+			return
+		}
+		if epoch == -1 || report.Epoch < epoch {
+			epoch = report.Epoch
+		}
+	}
+	if epoch <= ms.checkpointEpoch {
+		return
+	}
+
+	cut := make([]int32, ms.n)
+	for i := range cut {
+		cut[i] = -1
+		for _, member := range ms.membership.Members() {
+			if delivered := ms.historyReports[member].Delivered[i]; cut[i] == -1 || delivered < cut[i] {
+				cut[i] = delivered
+			}
+		}
+		if cut[i] < ms.checkpointCut[i] {
+			cut[i] = ms.checkpointCut[i]
+		}
+	}
+	ms.checkpointEpoch = epoch
+	ms.checkpointCut = cut
+	ms.eventLogger.OnCheckpoint(epoch, cut)
+
 	for pid := 0; pid < ms.n; pid++ {
 		msg := ms.context.MakeNewMessage()
-		msg.Content = &messages.Message_Simulate{
-			Simulate: &messages.Simulate{},
+		msg.Content = &messages.Message_Checkpoint{
+			Checkpoint: &messages.Checkpoint{Epoch: epoch, Cut: cut},
 		}
 		ms.context.Send(int32(pid), msg)
 	}
 }
+
+func (ms *MainServer) shutdown() {
+	for pid := 0; pid < ms.n; pid++ {
+		msg := ms.context.MakeNewMessage()
+		msg.Content = &messages.Message_Shutdown{Shutdown: &messages.Shutdown{}}
+		ms.context.Send(int32(pid), msg)
+	}
+	time.AfterFunc(ms.shutdownTimeout, func() {
+		ms.eventLogger.OnStop()
+		ms.stopActor()
+	})
+}
+
+func (ms *MainServer) simulate() {
+	for pid := 0; pid < ms.n; pid++ {
+		ms.sendSimulate(int32(pid))
+	}
+}
+
+// restart starts the simulation for a process which has connected again after a crash,
+// with the membership of the current epoch.
+func (ms *MainServer) restart(pid int32) {
+	ms.eventLogger.OnProcessRestart(pid)
+	ms.sendSimulate(pid)
+}
+
+func (ms *MainServer) sendSimulate(pid int32) {
+	msg := ms.context.MakeNewMessage()
+	msg.Content = &messages.Message_Simulate{
+		Simulate: &messages.Simulate{
+			Membership: ms.membership.ToMessage(),
+			PublicKeys: ms.publicKeys,
+		},
+	}
+	ms.context.Send(pid, msg)
+}
+
+// changeMembership starts a new epoch if the request changes the membership.
+// All processes, including the ones which are not members, receive the new membership.
+func (ms *MainServer) changeMembership(pid int32, leave bool) {
+	if ms.membership.Contains(pid) != leave {
+		return
+	}
+
+	var members []int32
+	for _, member := range ms.membership.Members() {
+		if member != pid {
+			members = append(members, member)
+		}
+	}
+	if !leave {
+		members = append(members, pid)
+	}
+
+	ms.membership = protocols.NewMembership(ms.membership.Epoch+1, members)
+	ms.eventLogger.OnReconfiguration(ms.membership.Epoch, ms.membership.Members())
+
+	for i := 0; i < ms.n; i++ {
+		msg := ms.context.MakeNewMessage()
+		msg.Content = &messages.Message_Membership{
+			Membership: ms.membership.ToMessage(),
+		}
+		ms.context.Send(int32(i), msg)
+	}
+}
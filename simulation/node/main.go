@@ -1,18 +1,17 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
-	"io"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"stochastic-checking-simulation/impl/parameters"
 	"stochastic-checking-simulation/impl/protocols"
-	"stochastic-checking-simulation/impl/protocols/accountability/consistent"
-	"stochastic-checking-simulation/impl/protocols/accountability/reliable"
-	"stochastic-checking-simulation/impl/protocols/bracha"
-	"stochastic-checking-simulation/impl/protocols/scalable"
+	_ "stochastic-checking-simulation/impl/protocols/all"
+	"stochastic-checking-simulation/impl/protocols/ordering"
 	"stochastic-checking-simulation/impl/utils"
+	"stochastic-checking-simulation/impl/wal"
 	"stochastic-checking-simulation/simulation/actor"
 )
 
@@ -37,79 +36,159 @@ var (
 		"stress_test",
 		false,
 		"Defines whether to run the stress test. In this case, transactions are sent out infinitely")
+	dryRun = flag.Bool(
+		"dry_run",
+		false,
+		"Validate the input file, print the thresholds derived from it and exit without starting the process")
+	totalOrder = flag.Bool(
+		"total_order",
+		false,
+		"Deliver transactions in the same order at all processes by running the protocol under the total order layer")
+	fifo = flag.Bool(
+		"fifo",
+		false,
+		"Deliver transactions of every author in the order of their seq numbers by running the protocol under the FIFO layer")
+	walDir = flag.String(
+		"wal_dir",
+		"",
+		"Directory where the process keeps its write-ahead log. If set, a restarted process recovers its state from the log")
+	listProtocols = flag.Bool(
+		"list_protocols",
+		false,
+		"Print the available protocols and exit")
+	peersFile = flag.String(
+		"peers_file",
+		"",
+		"Path to the json or yaml file listing addresses of the processes and of the main server. "+
+			"If set, nodes, base_ip and base_port are ignored")
 )
 
-type Input struct {
-	Protocol   string                `json:"protocol"`
-	Parameters parameters.Parameters `json:"parameters"`
-}
-
 func main() {
 	flag.Parse()
 
-	lFile := utils.OpenLogFile(*logFile)
-	logger := log.New(lFile, "", log.LstdFlags)
+	if *listProtocols {
+		printProtocols()
+		return
+	}
 
-	iFile, e := os.Open(*inputFile)
-	if e != nil {
-		logger.Fatalf("Can't read from file %s", *inputFile)
+	var logger *log.Logger
+	if *dryRun {
+		logger = log.New(os.Stderr, "", 0)
+	} else {
+		lFile := utils.OpenLogFile(*logFile)
+		logger = log.New(lFile, "", log.LstdFlags)
 	}
 
-	byteArray, e := io.ReadAll(iFile)
+	input, e := parameters.ReadInput(*inputFile)
 	if e != nil {
-		logger.Fatalf("Could not read bytes from the input file: %e", e)
+		logger.Fatal(e)
 	}
 
-	var input Input
-	e = json.Unmarshal(byteArray, &input)
+	warnings, e := input.Validate()
+	for _, warning := range warnings {
+		logger.Printf("Warning: %s\n", warning)
+	}
 	if e != nil {
-		logger.Fatalf("Could not parse json from the input file\n%e", e)
+		logger.Fatalf("Invalid parameters for protocol %s:\n%v", input.Protocol, e)
 	}
 
-	if input.Protocol == "" {
-		logger.Fatal("Parameter protocol is mandatory")
+	if *dryRun {
+		printThresholds(input)
+		return
 	}
 
 	processCount := input.Parameters.ProcessCount
 
-	if (processCount+1)%*nodes != 0 {
-		logger.Fatal(
-			"Total number of started processes, including the mainserver, must be divisible by the number of nodes",
-		)
+	var pids []string
+	if *peersFile != "" {
+		pids, e = utils.LoadPeers(*peersFile, processCount)
+		if e != nil {
+			logger.Fatalf("Invalid peers file:\n%v", e)
+		}
+	} else {
+		if (processCount+1)%*nodes != 0 {
+			logger.Fatal(
+				"Total number of started processes, including the mainserver, must be divisible by the number of nodes",
+			)
+		}
+
+		processesPerNode := (processCount + 1) / *nodes
+
+		pids = utils.GeneratePids(*baseIpAddress, *basePort, *nodes, processesPerNode, logger)
 	}
 
-	processesPerNode := (processCount + 1) / *nodes
-
-	pids := utils.GeneratePids(*baseIpAddress, *basePort, *nodes, processesPerNode, logger)
-
-	var process protocols.Process
+	protocol, e := protocols.Get(input.Protocol)
+	if e != nil {
+		logger.Fatal(e)
+	}
 
-	switch input.Protocol {
-	case "reliable_accountability":
-		process = &reliable.Process{}
-	case "consistent_accountability":
-		process = &consistent.Process{}
-	case "bracha":
-		process = &bracha.Process{}
-	case "scalable":
-		process = &scalable.Process{}
-	default:
-		logger.Fatalf("Invalid protocol: %s", input.Protocol)
+	process := protocol.NewProcess()
+	// The ordering layers forward reconfiguration to the process they wrap
+	if _, reconfigurable := process.(protocols.Reconfigurable); input.Scenario.ChangesMembership() && !reconfigurable {
+		logger.Fatalf("Protocol %s does not support reconfiguration\n", input.Protocol)
+	}
+	if *fifo {
+		process = ordering.NewFifo(process)
+	}
+	if *totalOrder {
+		// Transactions of an author which stops broadcasting are never ordered, and neither are all transactions after them
+		if input.Scenario.CrashesProcesses() || input.Scenario.ChangesMembership() {
+			logger.Fatalf("--total_order can not be combined with crashes or membership changes, " +
+				"which stall the ordered stream\n")
+		}
+		process = ordering.NewTotalOrder(process)
 	}
 
 	logger.Printf("Running protocol: %s\n", input.Protocol)
 
 	id := int32(*processIndex)
+
+	var processWal *wal.Log
+	if *walDir != "" {
+		if _, recoverable := process.(protocols.Recoverable); !recoverable {
+			logger.Fatalf("Protocol %s can not recover from the write-ahead log when run under an ordering layer "+
+				"or does not support recovery\n", input.Protocol)
+		}
+		processWal, e = wal.Open(filepath.Join(*walDir, fmt.Sprintf("process%d.wal", id)))
+		if e != nil {
+			logger.Fatal(e)
+		}
+	}
+
+	finishing := make([]bool, processCount)
+	for i := range finishing {
+		finishing[i] = input.Scenario.Finishes(i)
+	}
+
 	node := &Node{
 		processIndex:             id,
 		pids:                     pids,
-		parameters:               &input.Parameters,
+		parameters:               input.Parameters,
 		transactionsToSendOut:    *transactions,
 		transactionInitTimeoutNs: *transactionInitTimeoutNs,
 		process:                  process,
 		stressTest:               *makeStressTest,
+		wal:                      processWal,
+		crash:                    input.Scenario.CrashOf(*processIndex),
+		finishing:                finishing,
+		initialMember:            input.Scenario.IsInitialMember(*processIndex),
+		membershipChanges:        input.Scenario.MembershipChangesOf(*processIndex),
 	}
 
 	a := actor.Actor{}
+	node.stopActor = a.Stop
 	a.InitActor(id, pids, node, logger, *retransmissionTimeoutNs)
 }
+
+func printThresholds(input *parameters.Input) {
+	fmt.Printf("Protocol: %s\n", input.Protocol)
+	for _, threshold := range input.Parameters.Thresholds() {
+		fmt.Printf("\t%s: %d\n", threshold.Name, threshold.Value)
+	}
+}
+
+func printProtocols() {
+	for _, protocol := range protocols.List() {
+		fmt.Printf("%s - %s\n", protocol.Name, protocol.Description)
+	}
+}
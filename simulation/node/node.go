@@ -1,12 +1,20 @@
 package main
 
 import (
+	"crypto/ed25519"
+	cryptorand "crypto/rand"
+	"errors"
+	"fmt"
 	"math/rand"
 	"stochastic-checking-simulation/context"
 	"stochastic-checking-simulation/impl/eventlogger"
 	"stochastic-checking-simulation/impl/messages"
 	"stochastic-checking-simulation/impl/parameters"
 	"stochastic-checking-simulation/impl/protocols"
+	"stochastic-checking-simulation/impl/signatures"
+	"stochastic-checking-simulation/impl/wal"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -22,6 +30,46 @@ type Node struct {
 	process                  protocols.Process
 	ownDeliveredTransactions chan bool
 	stressTest               bool
+	// Write-ahead log of a recoverable process, nil if recovery is disabled
+	wal *wal.Log
+	// Number of transactions the process broadcast before it was restarted
+	broadcasts int
+	// Whether the simulation has started, as the main server starts it again for a restarted process
+	simulating bool
+	// Key ring with the secret key of the process, which is given the public keys of all processes
+	// when the simulation starts
+	keys *signatures.KeyRing
+	// Protocol messages received before the simulation starts, which are handled once the public keys are known
+	pending []*messages.Message
+
+	// Crash of the process scheduled by the scenario, nil if the process does not crash
+	crash     *parameters.Crash
+	stopActor func()
+	crashOnce sync.Once
+
+	deliveries atomic.Int64
+	// finishing[i] is whether the process i is expected to finish, see parameters.Scenario.Finishes
+	finishing []bool
+	// Number of delivered transactions of the processes which are expected to finish
+	countedDeliveries atomic.Int64
+	// Number of counted deliveries after which the process reports to the main server that it has finished,
+	// 0 in the stress test and for processes which are not expected to finish
+	expectedDeliveries int64
+
+	// Number of deliveries after which the process reports the transactions it has delivered to the main server,
+	// 0 if the process does not use checkpoints
+	checkpointInterval int64
+	// delivered[i] holds the delivered transactions of the process i with sequence numbers of at least
+	// contiguous[i], the number of its transactions delivered without gaps
+	delivered    []map[int32]bool
+	contiguous   []int32
+	historyMutex sync.Mutex
+
+	initialMember     bool
+	membershipChanges []parameters.MembershipChange
+	// Membership of the current epoch, nil until the simulation starts
+	membership      atomic.Pointer[protocols.Membership]
+	mainServerIndex int32
 
 	context     *context.ReliableContext
 	eventLogger *eventlogger.EventLogger
@@ -36,36 +84,295 @@ func (node *Node) Start(
 
 	node.ownDeliveredTransactions = make(chan bool, 200)
 
-	mainServerAddr := int32(len(node.pids)) - 1
+	if node.wal != nil {
+		node.context.UseStampStore(node.wal, node.wal.NextStamp())
+		node.restore()
+	}
+	node.keys = signatures.NewSecretKeyRing(node.processIndex, node.keySeed())
+
+	if node.crash != nil && node.crash.AfterMessagesSent > 0 {
+		node.context.StopAfterMessagesSent(node.crash.AfterMessagesSent, func() {
+			node.crashProcess(fmt.Sprintf("sent %d messages", node.crash.AfterMessagesSent))
+		})
+	}
+
+	node.mainServerIndex = int32(len(node.pids)) - 1
+	if !node.stressTest && node.finishing[node.processIndex] {
+		finishingAuthors := 0
+		for _, finishes := range node.finishing {
+			if finishes {
+				finishingAuthors++
+			}
+		}
+		node.expectedDeliveries = int64(finishingAuthors) * int64(node.transactionsToSendOut)
+	}
 	node.process.InitProcess(
 		node.processIndex,
-		node.pids[:mainServerAddr],
+		node.pids[:node.mainServerIndex],
 		node.parameters,
 		node.context,
 		node.eventLogger,
-		node.ownDeliveredTransactions,
-		node.stressTest,
+		node,
 	)
+	if process, keyed := node.process.(protocols.Keyed); keyed {
+		process.UseKeys(node.keys)
+	}
+	if node.wal != nil {
+		restored := node.countedDeliveries.Load()
+		node.process.(protocols.Recoverable).Recover(node.wal)
+		// The process might have crashed before reporting that it has finished
+		if node.expectedDeliveries > 0 && restored >= node.expectedDeliveries {
+			node.finish()
+		}
+	}
+
+	if process, checkpointed := node.process.(protocols.Checkpointed); checkpointed {
+		node.checkpointInterval = int64(process.CheckpointInterval())
+	}
+	if node.checkpointInterval > 0 {
+		if node.wal != nil {
+			node.eventLogger.Fatal("Checkpoints are not persisted, the process can not recover with checkpoint_interval set")
+		}
+		node.delivered = make([]map[int32]bool, node.mainServerIndex)
+		for i := range node.delivered {
+			node.delivered[i] = make(map[int32]bool)
+		}
+		node.contiguous = make([]int32, node.mainServerIndex)
+	}
 
 	startedMessage := node.context.MakeNewMessage()
 	startedMessage.Content = &messages.Message_Started{
-		Started: &messages.Started{},
+		Started: &messages.Started{
+			Member:    node.initialMember,
+			Finishes:  node.finishing[node.processIndex],
+			PublicKey: node.keys.PublicKey(),
+		},
+	}
+	node.context.Send(node.mainServerIndex, startedMessage)
+}
+
+// restore counts the transactions the process delivered and broadcast before it crashed from the write-ahead log.
+// A restarted process does not repeat the crash and the membership changes of the scenario.
+func (node *Node) restore() {
+	if len(node.wal.Records()) == 0 {
+		return
+	}
+	for _, record := range node.wal.Records() {
+		switch record.Type {
+		case wal.DeliveryRecord:
+			node.deliveries.Add(1)
+			if node.finishing[record.Author] {
+				node.countedDeliveries.Add(1)
+			}
+		case wal.BroadcastRecord:
+			node.broadcasts++
+		}
+	}
+	node.crash = nil
+	node.membershipChanges = nil
+}
+
+// keySeed returns the secret seed of the private key of the process. A recoverable process generates it once
+// and keeps it in its write-ahead log, so that its public key known to other processes does not change after a restart.
+func (node *Node) keySeed() []byte {
+	if node.wal != nil {
+		for _, record := range node.wal.Records() {
+			if record.Type == wal.KeyRecord {
+				return record.Seed
+			}
+		}
+	}
+
+	seed := make([]byte, ed25519.SeedSize)
+	if _, e := cryptorand.Read(seed); e != nil {
+		node.eventLogger.Fatal(fmt.Sprintf("Could not generate the key of the process: %v", e))
+	}
+	if node.wal != nil {
+		if e := node.wal.Append(wal.Key(seed)); e != nil {
+			node.eventLogger.Fatal(fmt.Sprintf("Could not write to the write-ahead log: %v", e))
+		}
+	}
+	return seed
+}
+
+// Deliver is called by the process for every delivered transaction. In the stress test a new transaction
+// is initialised once the previous transaction of the current process is delivered.
+// Otherwise, the process reports to the main server once it has delivered the transactions of all processes
+// which are expected to finish.
+func (node *Node) Deliver(bInstance *messages.BroadcastInstance, _ int32) {
+	deliveries := node.deliveries.Add(1)
+	if node.crash != nil && deliveries == int64(node.crash.AfterDeliveries) {
+		node.crashProcess(fmt.Sprintf("delivered %d transactions", deliveries))
+		return
+	}
+
+	if node.checkpointInterval > 0 {
+		node.reportHistory(bInstance, deliveries)
+	}
+
+	if node.finishing[bInstance.Author] && node.countedDeliveries.Add(1) == node.expectedDeliveries {
+		node.finish()
+	}
+
+	if node.stressTest && bInstance.Author == node.processIndex {
+		node.ownDeliveredTransactions <- true
+	}
+}
+
+// finish reports to the main server that the process has delivered all expected transactions.
+func (node *Node) finish() {
+	finishedMessage := node.context.MakeNewMessage()
+	finishedMessage.Content = &messages.Message_Finished{Finished: &messages.Finished{}}
+	node.context.Send(node.mainServerIndex, finishedMessage)
+}
+
+// reportHistory records the delivered transaction, and reports the numbers of transactions of every process
+// delivered without gaps to the main server every checkpointInterval deliveries.
+func (node *Node) reportHistory(bInstance *messages.BroadcastInstance, deliveries int64) {
+	node.historyMutex.Lock()
+	defer node.historyMutex.Unlock()
+
+	author := bInstance.Author
+	if bInstance.SeqNumber >= node.contiguous[author] {
+		node.delivered[author][bInstance.SeqNumber] = true
+		for node.delivered[author][node.contiguous[author]] {
+			delete(node.delivered[author], node.contiguous[author])
+			node.contiguous[author]++
+		}
+	}
+
+	if deliveries%node.checkpointInterval == 0 {
+		reportMessage := node.context.MakeNewMessage()
+		reportMessage.Content = &messages.Message_HistoryReport{
+			HistoryReport: &messages.HistoryReport{
+				Epoch:     int32(deliveries / node.checkpointInterval),
+				Delivered: append([]int32(nil), node.contiguous...),
+			},
+		}
+		node.context.Send(node.mainServerIndex, reportMessage)
 	}
-	node.context.Send(mainServerAddr, startedMessage)
+}
+
+// crashProcess stops the actor, so that the process neither sends nor receives messages anymore.
+func (node *Node) crashProcess(reason string) {
+	node.crashOnce.Do(func() {
+		node.eventLogger.OnCrash(reason)
+		node.stopActor()
+	})
 }
 
 func (node *Node) ProcessMessage(message *messages.Message) {
+	if e := node.validateSender(message); e != nil {
+		node.eventLogger.OnMessageRejected(message.Sender, e.Error())
+		return
+	}
+
 	switch c := message.Content.(type) {
 	case *messages.Message_Broadcast:
 		node.process.Broadcast(c.Broadcast.Value)
 	case *messages.Message_Simulate:
+		if node.simulating {
+			return
+		}
+		if len(c.Simulate.PublicKeys) != int(node.mainServerIndex) {
+			node.eventLogger.Fatal(fmt.Sprintf(
+				"Received %d public keys for %d processes", len(c.Simulate.PublicKeys), node.mainServerIndex))
+		}
+		if e := node.keys.SetPublicKeys(c.Simulate.PublicKeys); e != nil {
+			node.eventLogger.Fatal(fmt.Sprintf("Invalid public keys: %v", e))
+		}
+		node.simulating = true
 		node.eventLogger.OnSimulationStart()
+		node.reconfigure(c.Simulate.Membership)
+		// Messages of processes which have started the simulation earlier are handled now that signatures
+		// and witness proofs can be verified
+		for _, pending := range node.pending {
+			node.process.HandleMessage(pending.Sender, pending.GetBroadcastInstanceMessage())
+		}
+		node.pending = nil
+		for _, change := range node.membershipChanges {
+			leave := change.Leave
+			time.AfterFunc(time.Duration(change.AtNs), func() {
+				node.requestMembershipChange(leave)
+			})
+		}
+		if node.crash != nil && node.crash.AtNs > 0 {
+			time.AfterFunc(time.Duration(node.crash.AtNs), func() {
+				node.crashProcess(fmt.Sprintf("crash time %d ns reached", node.crash.AtNs))
+			})
+		}
 		go node.simulate()
 	case *messages.Message_BroadcastInstanceMessage:
+		if !node.simulating {
+			node.pending = append(node.pending, message)
+			return
+		}
+		// The process decodes the message and rejects it if it does not belong to the running protocol
 		node.process.HandleMessage(message.Sender, c.BroadcastInstanceMessage)
+	case *messages.Message_Membership:
+		node.reconfigure(c.Membership)
+	case *messages.Message_Checkpoint:
+		if process, checkpointed := node.process.(protocols.Checkpointed); checkpointed {
+			process.Checkpoint(c.Checkpoint.Epoch, c.Checkpoint.Cut)
+		}
+	case *messages.Message_Shutdown:
+		node.eventLogger.OnStop()
+		node.stopActor()
+	default:
+		node.eventLogger.OnMessageRejected(message.Sender, fmt.Sprintf("unexpected message type %T", c))
 	}
 }
 
+// validateSender checks that messages controlling the simulation come from the main server,
+// and that only the process itself makes it broadcast a new transaction.
+func (node *Node) validateSender(message *messages.Message) error {
+	switch message.Content.(type) {
+	case *messages.Message_Simulate, *messages.Message_Membership,
+		*messages.Message_Checkpoint, *messages.Message_Shutdown:
+		if message.Sender != node.mainServerIndex {
+			return fmt.Errorf("%T is only sent by the main server", message.Content)
+		}
+	case *messages.Message_Broadcast:
+		if message.Sender != node.processIndex {
+			return errors.New("transactions are only initialised by the process itself")
+		}
+	}
+	return nil
+}
+
+// reconfigure switches the process to the membership of a new epoch.
+// Memberships of older epochs, which might arrive late, are ignored.
+func (node *Node) reconfigure(message *messages.Membership) {
+	if message == nil {
+		return
+	}
+	for _, member := range message.Members {
+		if member < 0 || member >= node.mainServerIndex {
+			node.eventLogger.OnMessageRejected(node.mainServerIndex, fmt.Sprintf("member %d is not a process", member))
+			return
+		}
+	}
+	membership := protocols.MembershipFromMessage(message)
+	current := node.membership.Load()
+	if current != nil && membership.Epoch <= current.Epoch {
+		return
+	}
+	node.membership.Store(membership)
+
+	node.eventLogger.OnReconfiguration(membership.Epoch, membership.Members())
+	if process, reconfigurable := node.process.(protocols.Reconfigurable); reconfigurable {
+		process.Reconfigure(membership)
+	}
+}
+
+func (node *Node) requestMembershipChange(leave bool) {
+	msg := node.context.MakeNewMessage()
+	msg.Content = &messages.Message_MembershipRequest{
+		MembershipRequest: &messages.MembershipRequest{Leave: leave},
+	}
+	node.context.Send(node.mainServerIndex, msg)
+}
+
 func (node *Node) simulate() {
 	if node.stressTest {
 		node.doBroadcast()
@@ -76,14 +383,20 @@ func (node *Node) simulate() {
 			}
 		}
 	} else {
-		for i := 0; i < node.transactionsToSendOut; i++ {
+		// A restarted process only broadcasts the transactions it has not broadcast before the crash
+		for i := node.broadcasts; i < node.transactionsToSendOut; i++ {
 			node.doBroadcast()
 			time.Sleep(time.Duration(node.transactionInitTimeoutNs))
 		}
 	}
 }
 
+// doBroadcast initialises a new transaction, unless the process is not a member of the current epoch.
 func (node *Node) doBroadcast() {
+	if membership := node.membership.Load(); membership != nil && !membership.Contains(node.processIndex) {
+		return
+	}
+
 	msg := node.context.MakeNewMessage()
 	msg.Content = &messages.Message_Broadcast{
 		Broadcast: &messages.Broadcast{
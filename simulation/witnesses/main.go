@@ -0,0 +1,316 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"stochastic-checking-simulation/impl/hashing"
+	"stochastic-checking-simulation/impl/parameters"
+	"stochastic-checking-simulation/impl/protocols/accountability"
+	"stochastic-checking-simulation/impl/utils"
+)
+
+var defaults = accountability.DefaultParameters()
+
+var (
+	processCount    = flag.Int("n", 0, "Number of processes in the system (excluding the main server)")
+	faultyProcesses = flag.Int("f", 0, "max number of faulty processes in the system")
+	ownSize         = flag.Int("w", defaults.MinOwnWitnessSetSize, "minimal size of the own witness set W")
+	potSize         = flag.Int("v", defaults.MinPotWitnessSetSize, "minimal size of the pot witness set V")
+	ownRadius       = flag.Float64("wr", defaults.OwnWitnessSetRadius, "own witness set radius")
+	potRadius       = flag.Float64("vr", defaults.PotWitnessSetRadius, "pot witness set radius")
+	threshold       = flag.Int("u", defaults.WitnessThreshold, "witnesses threshold to accept a transaction")
+	nodeIdSize      = flag.Int("node_id_size", defaults.NodeIdSize, "node id size")
+	numberOfBins    = flag.Int("number_of_bins", defaults.NumberOfBins, "number of bins in history hash")
+	hash            = flag.String("hash", "", "hash function, as the hash parameter of the input file")
+	metric          = flag.String("metric", "", "metric of distances, as the metric parameter of the input file")
+	selection       = flag.String("witness_selection", "",
+		"distance or vrf, as the witness_selection parameter of the input file")
+	transactions = flag.Int("transactions", 1000, "number of transactions for which witness sets are selected")
+	history      = flag.Int("history", 0,
+		"number of transactions inserted into the history hashes before the first transaction")
+	historyWindow = flag.Int("history_window", 0,
+		"number of the last delivered transactions contributing to the history hashes, unlimited if 0")
+	views = flag.Int("views", 4,
+		"number of processes whose witness sets are compared, each with its own history hash")
+	pending = flag.Int("pending", 0,
+		"number of the most recent transactions which every process has delivered with probability 1/2, "+
+			"older transactions are delivered by all processes")
+	seed          = flag.Int64("seed", 1, "seed of the random choice of transactions and faulty processes")
+	baseIpAddress = flag.String("base_ip", "10.0.0.1", "Ip address of the main server, node ids are derived from it")
+	basePort      = flag.Int("base_port", 5001, "Port of the main server, node ids are derived from it")
+)
+
+type transaction struct {
+	authorIndex int32
+	author      string
+	seqNumber   int32
+	// delivered[i] is true if the process i inserted the transaction into its history hash
+	delivered []bool
+}
+
+// stats collects the values measured for every transaction.
+type stats struct {
+	ownSizes     []float64
+	potSizes     []float64
+	ownOverlaps  []float64
+	potOverlaps  []float64
+	identicalOwn int
+	// Number of transactions with fewer than u own witnesses, which can not be accepted
+	tooFewOwn       int
+	captured        int
+	capturedByModel float64
+}
+
+func main() {
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", 0)
+
+	ap := &accountability.Parameters{
+		MinOwnWitnessSetSize: *ownSize,
+		MinPotWitnessSetSize: *potSize,
+		OwnWitnessSetRadius:  *ownRadius,
+		PotWitnessSetRadius:  *potRadius,
+		WitnessThreshold:     *threshold,
+		NodeIdSize:           *nodeIdSize,
+		NumberOfBins:         *numberOfBins,
+		Hash:                 *hash,
+		Metric:               *metric,
+		HistoryWindow:        *historyWindow,
+		WitnessSelection:     *selection,
+	}
+	p := &parameters.Parameters{ProcessCount: *processCount, FaultyProcesses: *faultyProcesses, Protocol: ap}
+	warnings, e := p.Validate()
+	for _, warning := range warnings {
+		logger.Printf("Warning: %s\n", warning)
+	}
+	if e != nil {
+		logger.Fatalf("Invalid parameters:\n%v", e)
+	}
+	if *faultyProcesses > *processCount {
+		logger.Fatalf("f (%d) must not be greater than n (%d)", *faultyProcesses, *processCount)
+	}
+	if *transactions <= 0 || *views <= 0 || *history < 0 || *pending < 0 {
+		logger.Fatal("transactions and views must be positive, history and pending must not be negative")
+	}
+
+	n := *processCount
+	// Node ids are the pids a local run with the same base address would have
+	pids := utils.GeneratePids(*baseIpAddress, *basePort, 1, n+1, logger)[:n]
+
+	s := simulate(ap, pids)
+
+	fmt.Printf("Witness sets of %d transactions for n=%d, f=%d, w=%d, v=%d, wr=%v, vr=%v, u=%d\n",
+		*transactions, n, p.FaultyProcesses, ap.MinOwnWitnessSetSize, ap.MinPotWitnessSetSize,
+		ap.OwnWitnessSetRadius, ap.PotWitnessSetRadius, ap.WitnessThreshold)
+	printDistribution("Own witness set size", s.ownSizes)
+	printDistribution("Pot witness set size", s.potSizes)
+	fmt.Printf("Transactions with fewer than u=%d own witnesses: %.2f%%\n",
+		ap.WitnessThreshold, 100*float64(s.tooFewOwn)/float64(*transactions))
+	if *views > 1 {
+		fmt.Printf("Overlap of witness sets of %d processes, with %d pending transactions:\n", *views, *pending)
+		printDistribution("Own witness sets (Jaccard index)", s.ownOverlaps)
+		printDistribution("Pot witness sets (Jaccard index)", s.potOverlaps)
+		fmt.Printf("Transactions with identical own witness sets at all processes: %.2f%%\n",
+			100*float64(s.identicalOwn)/float64(*transactions))
+	}
+	fmt.Printf("Probability that %d faulty processes are at least u=%d own witnesses:\n",
+		p.FaultyProcesses, ap.WitnessThreshold)
+	fmt.Printf("  Measured: %.4g (%d of %d transactions)\n",
+		float64(s.captured)/float64(*transactions), s.captured, *transactions)
+	fmt.Printf("  Hypergeometric, given the measured set sizes: %.4g\n", s.capturedByModel/float64(*transactions))
+}
+
+// simulate selects witness sets for random transactions at every process of views.
+// Processes deliver every transaction after selecting its witnesses. The pending most recent transactions
+// are delivered by every process with probability 1/2 and older ones by all processes,
+// so that the history hashes of the processes differ as if transactions were delivered at different times.
+// Faulty processes are chosen at random for every transaction.
+func simulate(ap *accountability.Parameters, pids []string) *stats {
+	n := len(pids)
+	random := rand.New(rand.NewSource(*seed))
+	hasher := ap.Hasher()
+	selector := &hashing.WitnessesSelector{
+		Hasher:               hasher,
+		Metric:               ap.DistanceMetric(),
+		MinPotWitnessSetSize: ap.MinPotWitnessSetSize,
+		MinOwnWitnessSetSize: ap.MinOwnWitnessSetSize,
+		PotWitnessSetRadius:  ap.PotWitnessSetRadius,
+		OwnWitnessSetRadius:  ap.OwnWitnessSetRadius,
+	}
+	historyHashes := make([]*hashing.HistoryHash, *views)
+	for i := range historyHashes {
+		historyHashes[i] = ap.NewHistoryHash()
+	}
+	var vrfSelectors []*accountability.VrfSelector
+	if ap.WitnessSelection == accountability.VrfWitnessSelection {
+		for i := range pids {
+			vrfSelectors = append(vrfSelectors, accountability.NewVrfSelector(ap, int32(i), pids))
+		}
+	}
+
+	randomTransaction := func() *transaction {
+		authorIndex := int32(random.Intn(n))
+		return &transaction{
+			authorIndex: authorIndex,
+			author:      pids[authorIndex],
+			seqNumber:   random.Int31(),
+			delivered:   make([]bool, *views),
+		}
+	}
+	deliver := func(t *transaction, view int) {
+		if !t.delivered[view] {
+			t.delivered[view] = true
+			historyHashes[view].Insert(utils.TransactionToBytes(t.author, int64(t.seqNumber)))
+		}
+	}
+	for i := 0; i < *history; i++ {
+		t := randomTransaction()
+		for view := range historyHashes {
+			deliver(t, view)
+		}
+	}
+
+	s := &stats{}
+	var window []*transaction
+	for i := 0; i < *transactions; i++ {
+		t := randomTransaction()
+
+		ownSets := make([]map[string]bool, *views)
+		potSets := make([]map[string]bool, *views)
+		for view, historyHash := range historyHashes {
+			if vrfSelectors != nil {
+				// Witnesses selected with the verifiable random function do not depend on the history
+				if view == 0 {
+					ownSets[view], potSets[view] = vrfWitnessSets(vrfSelectors, pids, t)
+				} else {
+					ownSets[view], potSets[view] = ownSets[0], potSets[0]
+				}
+				continue
+			}
+			ownSets[view], potSets[view] = selector.GetWitnessSetOf(pids, t.author, t.seqNumber, historyHash)
+		}
+
+		s.ownSizes = append(s.ownSizes, float64(len(ownSets[0])))
+		s.potSizes = append(s.potSizes, float64(len(potSets[0])))
+		if len(ownSets[0]) < ap.WitnessThreshold {
+			s.tooFewOwn++
+		}
+		identical := true
+		for view := 1; view < *views; view++ {
+			s.ownOverlaps = append(s.ownOverlaps, jaccard(ownSets[0], ownSets[view]))
+			s.potOverlaps = append(s.potOverlaps, jaccard(potSets[0], potSets[view]))
+			identical = identical && jaccard(ownSets[0], ownSets[view]) == 1
+		}
+		if identical {
+			s.identicalOwn++
+		}
+
+		faultyOwn := 0
+		for _, pid := range random.Perm(n)[:*faultyProcesses] {
+			if ownSets[0][pids[pid]] {
+				faultyOwn++
+			}
+		}
+		if faultyOwn >= ap.WitnessThreshold {
+			s.captured++
+		}
+		s.capturedByModel += hypergeometricTail(n, *faultyProcesses, len(ownSets[0]), ap.WitnessThreshold)
+
+		for view := range historyHashes {
+			if random.Intn(2) == 0 {
+				deliver(t, view)
+			}
+		}
+		window = append(window, t)
+		if len(window) > *pending {
+			for view := range historyHashes {
+				deliver(window[0], view)
+			}
+			window = window[1:]
+		}
+	}
+	return s
+}
+
+// vrfWitnessSets returns the own and pot witness sets of the transaction, made of the processes which select
+// themselves with the verifiable random function.
+func vrfWitnessSets(
+	vrfSelectors []*accountability.VrfSelector,
+	pids []string,
+	t *transaction,
+) (map[string]bool, map[string]bool) {
+	ownSet := make(map[string]bool)
+	potSet := make(map[string]bool)
+	for i, vrfSelector := range vrfSelectors {
+		_, own, pot := vrfSelector.Prove(t.authorIndex, t.seqNumber, len(pids))
+		if own {
+			ownSet[pids[i]] = true
+		}
+		if pot {
+			potSet[pids[i]] = true
+		}
+	}
+	return ownSet, potSet
+}
+
+// jaccard returns the size of the intersection of the sets divided by the size of their union.
+func jaccard(s1 map[string]bool, s2 map[string]bool) float64 {
+	intersection := 0
+	for id := range s1 {
+		if s2[id] {
+			intersection++
+		}
+	}
+	union := len(s1) + len(s2) - intersection
+	if union == 0 {
+		return 1
+	}
+	return float64(intersection) / float64(union)
+}
+
+// hypergeometricTail returns the probability that at least k of the given number of processes drawn
+// from the n processes are among the f faulty ones.
+func hypergeometricTail(n int, f int, draws int, k int) float64 {
+	logBinomial := func(n int, k int) float64 {
+		a, _ := math.Lgamma(float64(n + 1))
+		b, _ := math.Lgamma(float64(k + 1))
+		c, _ := math.Lgamma(float64(n - k + 1))
+		return a - b - c
+	}
+
+	probability := 0.0
+	for i := k; i <= f && i <= draws; i++ {
+		if draws-i > n-f {
+			continue
+		}
+		probability += math.Exp(logBinomial(f, i) + logBinomial(n-f, draws-i) - logBinomial(n, draws))
+	}
+	return math.Min(probability, 1)
+}
+
+func printDistribution(name string, values []float64) {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mean := 0.0
+	for _, value := range sorted {
+		mean += value
+	}
+	mean /= float64(len(sorted))
+
+	quantile := func(q float64) float64 {
+		i := int(math.Ceil(q*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	fmt.Printf("%s: mean %.4g, min %.4g, 1%% %.4g, 5%% %.4g, median %.4g, 95%% %.4g, 99%% %.4g, max %.4g\n",
+		name, mean, sorted[0], quantile(0.01), quantile(0.05), quantile(0.5), quantile(0.95), quantile(0.99),
+		sorted[len(sorted)-1])
+}